@@ -0,0 +1,546 @@
+package arraylist
+
+import (
+	"fmt"
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/listview"
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+	"strings"
+)
+
+// ArrayList is an implementation of a list backed
+// by a slice which grows as needed.
+//
+// The value equality function may be omitted (nil)
+// if no methods are called which use it.
+type ArrayList[V any] struct {
+	capFn    structs.CapacityFunc
+	shrinkFn structs.CapacityFunc // nil to never shrink
+	eq       structs.EqualFunc[V]
+	data     []V
+	size     int
+	// modCount is incremented whenever the list is structurally
+	// modified, which allows iterators to detect concurrent changes.
+	modCount int
+}
+
+var _ structs.List[int] = (*ArrayList[int])(nil)
+
+// New creates an empty ArrayList.
+func New[V any](eq structs.EqualFunc[V]) *ArrayList[V] {
+	return NewCap(0, eq)
+}
+
+// NewOrdered creates an empty ArrayList from a type that implements constraints.Ordered.
+func NewOrdered[V constraints.Ordered]() *ArrayList[V] {
+	return NewCap(0, structs.EqualOrdered[V])
+}
+
+// NewCap creates an empty ArrayList with an initial capacity.
+func NewCap[V any](capacity int, eq structs.EqualFunc[V]) *ArrayList[V] {
+	return &ArrayList[V]{
+		capFn: structs.DoubleCapacity,
+		eq:    eq,
+		data:  make([]V, capacity),
+	}
+}
+
+// NewOrderedCap creates an empty ArrayList with an initial
+// capacity from a type that implements constraints.Ordered.
+func NewOrderedCap[V constraints.Ordered](capacity int) *ArrayList[V] {
+	return NewCap(capacity, structs.EqualOrdered[V])
+}
+
+// From creates an ArrayList from an existing collection.
+func From[V any](eq structs.EqualFunc[V], other structs.Collection[V]) *ArrayList[V] {
+	list := NewCap(other.Size(), eq)
+	list.AddAll(other)
+	return list
+}
+
+// FromOrdered creates an ArrayList from an existing collection of a type that implements constraints.Ordered.
+func FromOrdered[V constraints.Ordered](other structs.Collection[V]) *ArrayList[V] {
+	return From(structs.EqualOrdered[V], other)
+}
+
+// Of creates an ArrayList from an existing slice. The values are copied.
+func Of[V any](eq structs.EqualFunc[V], values ...V) *ArrayList[V] {
+	list := NewCap(len(values), eq)
+	copy(list.data, values)
+	list.size = len(values)
+	return list
+}
+
+// OfOrdered creates an ArrayList from an existing slice of a
+// type that implements constraints.Ordered. The values are copied.
+func OfOrdered[V constraints.Ordered](values ...V) *ArrayList[V] {
+	return Of(structs.EqualOrdered[V], values...)
+}
+
+// SetCapFunc sets the function used to increase the capacity of the list.
+func (l *ArrayList[V]) SetCapFunc(capFn structs.CapacityFunc) {
+	l.capFn = capFn
+}
+
+// SetShrinkFunc sets the function used to decrease the capacity of
+// the list after values are removed, for example structs.HalveCapacity.
+// If it is nil, which is the default, the list never shrinks.
+func (l *ArrayList[V]) SetShrinkFunc(shrinkFn structs.CapacityFunc) {
+	l.shrinkFn = shrinkFn
+}
+
+// Add adds a value to the end of the list.
+//
+// Time Complexity: amortized O(1)
+func (l *ArrayList[V]) Add(value V) bool {
+	l.grow(l.size + 1)
+	l.data[l.size] = value
+	l.size++
+	l.modCount++
+	return true
+}
+
+// AddAt adds a value at the specified index in the list,
+// shifting the value at the index and later values right.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n)
+func (l *ArrayList[V]) AddAt(index int, value V) {
+	l.checkBounds(index, true)
+
+	l.grow(l.size + 1)
+	copy(l.data[index+1:], l.data[index:l.size])
+	l.data[index] = value
+	l.size++
+	l.modCount++
+}
+
+// AddAll adds all the values in the other collection to the end of the list,
+// growing the list at most once.
+//
+// Time Complexity: O(m)
+func (l *ArrayList[V]) AddAll(other structs.Collection[V]) bool {
+	return l.AddAllAt(l.size, other)
+}
+
+// AddAllAt adds all the values in the other collection to the list, starting
+// at the index provided and shifting existing values right, growing the list
+// at most once.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n+m)
+func (l *ArrayList[V]) AddAllAt(index int, other structs.Collection[V]) bool {
+	l.checkBounds(index, true)
+
+	// copy the values first, in case the other collection is this list
+	values := other.Values()
+	if len(values) == 0 {
+		return false
+	}
+	l.grow(l.size + len(values))
+	copy(l.data[index+len(values):], l.data[index:l.size])
+	copy(l.data[index:], values)
+	l.size += len(values)
+	l.modCount++
+	return true
+}
+
+// AddIterator adds all the values in the iterator to the end of the list.
+//
+// Time Complexity: O(m)
+func (l *ArrayList[V]) AddIterator(iter structs.Iterator[V]) bool {
+	changed := iter.HasNext()
+	for iter.HasNext() {
+		l.Add(iter.Next())
+	}
+	return changed
+}
+
+// BinarySearch searches for a value in the list, which must be sorted
+// in ascending order by the comparison function, and returns the index
+// it was found at and true. If the value is not present, it returns the
+// index it would be inserted at to keep the list sorted and false.
+//
+// Time Complexity: O(logn)
+func (l *ArrayList[V]) BinarySearch(value V, cmp structs.CompareFunc[V]) (int, bool) {
+	low, high := 0, l.size
+	for low < high {
+		mid := int(uint(low+high) >> 1)
+		if cmp(l.data[mid], value) < 0 {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return low, low < l.size && cmp(l.data[low], value) == 0
+}
+
+// InsertSorted inserts a value into the list, which must be sorted
+// in ascending order by the comparison function, so the list remains
+// sorted, and returns the index it was inserted at. The value is
+// inserted after any values which compare equal to it.
+//
+// Time Complexity: O(n)
+func (l *ArrayList[V]) InsertSorted(value V, cmp structs.CompareFunc[V]) int {
+	low, high := 0, l.size
+	for low < high {
+		mid := int(uint(low+high) >> 1)
+		if cmp(l.data[mid], value) <= 0 {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	l.AddAt(low, value)
+	return low
+}
+
+// Cap returns the capacity of the list.
+func (l *ArrayList[V]) Cap() int {
+	return len(l.data)
+}
+
+// Clear clears the list. Any references held by
+// the list are released, so they may be garbage collected.
+//
+// Time Complexity: O(n)
+func (l *ArrayList[V]) Clear() {
+	l.removeRange(0, l.size)
+}
+
+// Clone creates a new list with the same values.
+//
+// Time Complexity: O(n)
+//
+// Space Complexity: O(n)
+func (l *ArrayList[V]) Clone() *ArrayList[V] {
+	list := Of(l.eq, l.data[:l.size]...)
+	list.capFn = l.capFn
+	list.shrinkFn = l.shrinkFn
+	return list
+}
+
+// Contains returns whether the value is present in the list.
+//
+// Time Complexity: O(n)
+func (l *ArrayList[V]) Contains(value V) bool {
+	return l.IndexOf(value) >= 0
+}
+
+// ContainsAll returns whether all the values in the other collection are present in the list.
+//
+// Time Complexity: O(nm)
+func (l *ArrayList[V]) ContainsAll(other structs.Collection[V]) bool {
+	return l.ContainsIterator(other.Iterator())
+}
+
+// ContainsIterator returns whether all the values in the iterator are present in the list.
+//
+// Time Complexity: O(nm)
+func (l *ArrayList[V]) ContainsIterator(iter structs.Iterator[V]) bool {
+	for iter.HasNext() {
+		if !l.Contains(iter.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Get returns the value at the specified index.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(1)
+func (l *ArrayList[V]) Get(index int) V {
+	l.checkBounds(index, false)
+
+	return l.data[index]
+}
+
+// IndexOf returns the first index of a value in the list,
+// or -1 if the value is not present in the list.
+//
+// Time Complexity: O(n)
+func (l *ArrayList[V]) IndexOf(value V) int {
+	return l.indexOf(value, 0, l.size)
+}
+
+// IsEmpty returns whether the list is empty.
+func (l *ArrayList[V]) IsEmpty() bool {
+	return l.size == 0
+}
+
+// IsSorted returns whether the list is sorted based on a comparator.
+//
+// Time Complexity: O(n)
+func (l *ArrayList[V]) IsSorted(cmp structs.LessFunc[V]) bool {
+	for i := 1; i < l.size; i++ {
+		if cmp(l.data[i], l.data[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Iterator returns an Iterator over the list.
+func (l *ArrayList[V]) Iterator() structs.Iterator[V] {
+	return l.ListIterator(0)
+}
+
+// LastIndexOf returns the last index of a value in the list,
+// or -1 if the value is not present in the list.
+//
+// Time Complexity: O(n)
+func (l *ArrayList[V]) LastIndexOf(value V) int {
+	return l.lastIndexOf(value, 0, l.size)
+}
+
+// Remove removes the first occurrence of a value from
+// the list and returns whether the value was present.
+//
+// Time Complexity: O(n)
+func (l *ArrayList[V]) Remove(value V) bool {
+	i := l.IndexOf(value)
+	if i < 0 {
+		return false
+	}
+	l.removeAt(i)
+	return true
+}
+
+// RemoveAll removes all the values in the other collection from the list.
+//
+// Time Complexity: O(nm)
+func (l *ArrayList[V]) RemoveAll(other structs.Collection[V]) bool {
+	return l.RemoveIterator(other.Iterator())
+}
+
+// RemoveAt removes the value at the specified index from the list and returns it.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n)
+func (l *ArrayList[V]) RemoveAt(index int) V {
+	l.checkBounds(index, false)
+
+	return l.removeAt(index)
+}
+
+// RemoveIterator removes all the values in the iterator from the list.
+//
+// Time Complexity: O(nm)
+func (l *ArrayList[V]) RemoveIterator(iter structs.Iterator[V]) bool {
+	changed := false
+	for iter.HasNext() {
+		if l.Remove(iter.Next()) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// RetainAll removes all the values not present in the other collection from the list.
+//
+// Time Complexity: O(nm)
+//
+//	n = size of the list
+//	m = time complexity of Contains on the other collection
+func (l *ArrayList[V]) RetainAll(other structs.Collection[V]) bool {
+	return l.retainRange(other, 0, l.size) > 0
+}
+
+// Set sets the value at the specified index, returning the old value.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(1)
+func (l *ArrayList[V]) Set(index int, value V) V {
+	l.checkBounds(index, false)
+
+	oldValue := l.data[index]
+	l.data[index] = value
+	return oldValue
+}
+
+// Size returns the number of values in the list.
+//
+// Time Complexity: O(1)
+func (l *ArrayList[V]) Size() int {
+	return l.size
+}
+
+// Sort sorts the list based on a comparator.
+//
+// Time Complexity: O(nlogn)
+// See slices.SortFunc (algorithm: pattern-defeating quicksort).
+func (l *ArrayList[V]) Sort(cmp structs.LessFunc[V]) {
+	slices.SortFunc(l.data[:l.size], cmp)
+}
+
+// SortStable sorts the list based on a comparator, keeping
+// equal values in their relative order.
+//
+// Time Complexity: O(nlogn)
+// See slices.SortStableFunc (algorithm: insertion sort and symmerge).
+func (l *ArrayList[V]) SortStable(cmp structs.LessFunc[V]) {
+	slices.SortStableFunc(l.data[:l.size], cmp)
+}
+
+// String returns a string representation of the list, with brackets and comma separated.
+func (l *ArrayList[V]) String() string {
+	var buf strings.Builder
+	buf.WriteRune('[')
+	for i, value := range l.data[:l.size] {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(fmt.Sprint(value))
+	}
+	buf.WriteRune(']')
+	return buf.String()
+}
+
+// SubList returns a view of the list between the from index, inclusive,
+// and the to index, exclusive. The view is backed by the list, and
+// becomes invalid if the list is structurally modified other than
+// through the view.
+//
+// Panics if the range is out of bounds.
+//
+// Time Complexity: O(1)
+func (l *ArrayList[V]) SubList(from, to int) structs.List[V] {
+	return listview.New(&listview.Backing[V]{
+		Equal:       l.eq,
+		Get:         l.Get,
+		Set:         l.Set,
+		AddAt:       l.AddAt,
+		RemoveAt:    l.RemoveAt,
+		ModCount:    func() int { return l.modCount },
+		RemoveRange: l.removeRange,
+		Sort: func(from, to int, cmp structs.LessFunc[V]) {
+			slices.SortFunc(l.data[from:to], cmp)
+		},
+	}, l.size, from, to)
+}
+
+// TrimToSize reduces the capacity of the list to its size.
+//
+// Time Complexity: O(n)
+func (l *ArrayList[V]) TrimToSize() {
+	if l.size < len(l.data) {
+		l.data = structs.Realloc(l.size, l.data)
+	}
+}
+
+// Values returns a slice of the values in the list.
+//
+// Time Complexity: O(n)
+//
+// Space Complexity: O(n)
+func (l *ArrayList[V]) Values() []V {
+	values := make([]V, l.size)
+	copy(values, l.data)
+	return values
+}
+
+// indexOf returns the first index of a value between
+// from, inclusive, and to, exclusive, or -1.
+func (l *ArrayList[V]) indexOf(value V, from, to int) int {
+	for i := from; i < to; i++ {
+		if l.eq(l.data[i], value) {
+			return i
+		}
+	}
+	return -1
+}
+
+// lastIndexOf returns the last index of a value between
+// from, inclusive, and to, exclusive, or -1.
+func (l *ArrayList[V]) lastIndexOf(value V, from, to int) int {
+	for i := to - 1; i >= from; i-- {
+		if l.eq(l.data[i], value) {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeAt removes the value at the index, shifting later values left.
+func (l *ArrayList[V]) removeAt(index int) V {
+	value := l.data[index]
+	l.removeRange(index, index+1)
+	return value
+}
+
+// removeRange removes the values between from, inclusive,
+// and to, exclusive, shifting later values left.
+func (l *ArrayList[V]) removeRange(from, to int) {
+	if from == to {
+		return
+	}
+	copy(l.data[from:], l.data[to:l.size])
+
+	var null V
+	newSize := l.size - (to - from)
+	for i := newSize; i < l.size; i++ {
+		l.data[i] = null
+	}
+	l.size = newSize
+	l.modCount++
+	l.shrink()
+}
+
+// retainRange removes the values between from, inclusive, and to,
+// exclusive, which are not present in the other collection, and
+// returns the number of values removed.
+func (l *ArrayList[V]) retainRange(other structs.Collection[V], from, to int) int {
+	// compact the kept values toward the front in a single pass
+	kept := from
+	for i := from; i < to; i++ {
+		if other.Contains(l.data[i]) {
+			l.data[kept] = l.data[i]
+			kept++
+		}
+	}
+	l.removeRange(kept, to)
+	return to - kept
+}
+
+// grow reallocates the data if it cannot hold need values.
+func (l *ArrayList[V]) grow(need int) {
+	if need > len(l.data) {
+		capacity := l.capFn(len(l.data), need)
+		if capacity < need {
+			capacity = need
+		}
+		l.data = structs.Realloc(capacity, l.data[:l.size])
+	}
+}
+
+// shrink reallocates the data with a smaller
+// capacity if the shrink function permits it.
+func (l *ArrayList[V]) shrink() {
+	if l.shrinkFn == nil {
+		return
+	}
+	size := l.shrinkFn(len(l.data), l.size)
+	if size < l.size {
+		size = l.size
+	}
+	if size < len(l.data) {
+		l.data = structs.Realloc(size, l.data[:l.size])
+	}
+}
+
+// checkBounds checks whether an index is within the bounds of the list.
+//
+// if allowEnd is true, index == l.size is allowed. this is useful
+// for operations which may insert after the list's end.
+func (l *ArrayList[V]) checkBounds(index int, allowEnd bool) {
+	if index < 0 || index > l.size || (!allowEnd && index == l.size) {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+}
@@ -0,0 +1,177 @@
+package arraylist
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/listtest"
+	"github.com/zytekaron/structs/list"
+	"golang.org/x/exp/slices"
+	"testing"
+)
+
+func TestArrayList(t *testing.T) {
+	listtest.Run(t, func() structs.List[int] {
+		return NewOrdered[int]()
+	})
+}
+
+func TestArrayList_Shrink(t *testing.T) {
+	listtest.Run(t, func() structs.List[int] {
+		l := NewOrderedCap[int](4)
+		l.SetShrinkFunc(structs.HalveCapacity)
+		return l
+	})
+}
+
+func TestSubList(t *testing.T) {
+	listtest.Run(t, func() structs.List[int] {
+		// an empty view in the middle of a list
+		return OfOrdered(-1, -2).SubList(1, 1)
+	})
+}
+
+func TestArrayList_AddAll(t *testing.T) {
+	l := OfOrdered(1, 2)
+	grows := 0
+	l.SetCapFunc(func(before, need int) int {
+		grows++
+		return structs.DoubleCapacity(before, need)
+	})
+
+	l.AddAll(list.OfOrdered(3, 4, 5, 6, 7))
+	if grows != 1 {
+		t.Errorf("expected the list to grow once but it grew %d times", grows)
+	}
+	l.AddAllAt(1, list.OfOrdered(10, 11))
+	expect := []int{1, 10, 11, 2, 3, 4, 5, 6, 7}
+	if !slices.Equal(l.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, l.Values())
+	}
+
+	// adding the list to itself adds a copy of its values
+	l = OfOrdered(1, 2)
+	l.AddAllAt(1, l)
+	expect = []int{1, 1, 2, 2}
+	if !slices.Equal(l.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, l.Values())
+	}
+}
+
+func TestArrayList_Capacity(t *testing.T) {
+	l := NewOrdered[int]()
+	l.SetShrinkFunc(structs.HalveCapacity)
+	for i := 0; i < 64; i++ {
+		l.Add(i)
+	}
+	if l.Cap() != 64 {
+		t.Errorf("expected capacity 64 but got %d", l.Cap())
+	}
+	l.SubList(4, 64).Clear()
+	if l.Cap() >= 64 {
+		t.Errorf("expected capacity to shrink below 64 but got %d", l.Cap())
+	}
+
+	l.SetShrinkFunc(nil)
+	l.Add(4)
+	l.TrimToSize()
+	if l.Cap() != 5 {
+		t.Errorf("expected capacity 5 after trimming but got %d", l.Cap())
+	}
+	if got := l.String(); got != "[0, 1, 2, 3, 4]" {
+		t.Errorf("expected string [0, 1, 2, 3, 4] but got %s", got)
+	}
+}
+
+func TestArrayList_CapacityClamped(t *testing.T) {
+	l := NewOrdered[int]()
+	// capacity functions returning too little are clamped
+	// to the number of values the list needs to hold
+	l.SetCapFunc(func(before, need int) int { return 0 })
+	l.SetShrinkFunc(func(before, need int) int { return 0 })
+	for i := 0; i < 8; i++ {
+		l.Add(i)
+	}
+	l.RemoveAt(0)
+	expect := []int{1, 2, 3, 4, 5, 6, 7}
+	if !slices.Equal(l.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, l.Values())
+	}
+	if l.Cap() != 7 {
+		t.Errorf("expected capacity 7 but got %d", l.Cap())
+	}
+}
+
+func TestArrayList_BinarySearch(t *testing.T) {
+	l := OfOrdered(1, 3, 3, 5, 7)
+	tests := []struct {
+		value int
+		index int
+		found bool
+	}{
+		{0, 0, false},
+		{1, 0, true},
+		{3, 1, true},
+		{4, 3, false},
+		{7, 4, true},
+		{8, 5, false},
+	}
+	for _, test := range tests {
+		index, found := l.BinarySearch(test.value, structs.CompareOrdered[int])
+		if index != test.index || found != test.found {
+			t.Errorf("expected BinarySearch(%d) to return %d, %t but got %d, %t",
+				test.value, test.index, test.found, index, found)
+		}
+	}
+
+	if i := l.InsertSorted(3, structs.CompareOrdered[int]); i != 3 {
+		t.Errorf("expected 3 to be inserted at index 3 but got %d", i)
+	}
+	l.InsertSorted(0, structs.CompareOrdered[int])
+	l.InsertSorted(9, structs.CompareOrdered[int])
+	expect := []int{0, 1, 3, 3, 3, 5, 7, 9}
+	if !slices.Equal(l.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, l.Values())
+	}
+	if !l.IsSorted(structs.LessOrdered[int]) {
+		t.Error("expected list to be sorted")
+	}
+}
+
+func TestArrayList_SortStable(t *testing.T) {
+	type pair struct{ key, order int }
+	l := Of(nil, pair{2, 0}, pair{1, 1}, pair{2, 2}, pair{1, 3})
+	l.SortStable(func(a, b pair) bool { return a.key < b.key })
+
+	expect := []pair{{1, 1}, {1, 3}, {2, 0}, {2, 2}}
+	if !slices.Equal(l.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, l.Values())
+	}
+}
+
+func TestArrayList_ListIterator(t *testing.T) {
+	l := OfOrdered(0, 1, 2, 3)
+	it := l.ListIterator(2)
+	if got := it.Previous(); got != 1 {
+		t.Errorf("expected previous 1 but got %d", got)
+	}
+	it.Set(10)
+	it.Add(5)
+	if got := it.Next(); got != 10 {
+		t.Errorf("expected next 10 but got %d", got)
+	}
+	it.Remove()
+	if it.NextIndex() != 2 || it.PreviousIndex() != 1 {
+		t.Errorf("expected indices 2 and 1 but got %d and %d", it.NextIndex(), it.PreviousIndex())
+	}
+
+	expect := []int{0, 5, 2, 3}
+	if !slices.Equal(l.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, l.Values())
+	}
+
+	defer func() {
+		if r := recover(); r != structs.PanicIllegalState {
+			t.Errorf("expected panic %q but got %v", structs.PanicIllegalState, r)
+		}
+	}()
+	it.Set(0)
+}
@@ -0,0 +1,122 @@
+package arraylist
+
+import "github.com/zytekaron/structs"
+
+// Iterator is a bidirectional iterator over the values of an ArrayList.
+//
+// Like a Java ListIterator, it has no current value: its cursor lies
+// between the value that would be returned by Previous and the value
+// that would be returned by Next. Remove and Set operate on the value
+// last returned by Next or Previous, and Add inserts at the cursor.
+type Iterator[V any] struct {
+	list   *ArrayList[V]
+	cursor int // index of the value Next would return
+	last   int // index of the last value returned, or -1
+
+	expectedModCount int
+}
+
+var _ structs.ListIterator[int] = (*Iterator[int])(nil)
+
+// ListIterator returns a ListIterator over the list whose cursor is
+// before the value at the index provided, so the first call to Next
+// returns that value and the first call to Previous returns the value
+// before it. The index may be the size of the list, to start at the end.
+//
+// Panics if the index is out of bounds.
+func (l *ArrayList[V]) ListIterator(index int) structs.ListIterator[V] {
+	l.checkBounds(index, true)
+
+	return &Iterator[V]{
+		list:             l,
+		cursor:           index,
+		last:             -1,
+		expectedModCount: l.modCount,
+	}
+}
+
+// Add inserts a value into the list at the cursor, so it would be
+// returned by Previous and subsequent calls to Next are unaffected.
+func (it *Iterator[V]) Add(value V) {
+	it.checkModCount()
+
+	it.list.AddAt(it.cursor, value)
+	it.cursor++
+	it.last = -1
+	it.expectedModCount = it.list.modCount
+}
+
+func (it *Iterator[V]) HasNext() bool {
+	return it.cursor < it.list.size
+}
+
+func (it *Iterator[V]) HasPrevious() bool {
+	return it.cursor > 0
+}
+
+func (it *Iterator[V]) Next() V {
+	it.checkModCount()
+	if !it.HasNext() {
+		panic(structs.PanicNoSuchElement)
+	}
+
+	it.last = it.cursor
+	it.cursor++
+	return it.list.data[it.last]
+}
+
+func (it *Iterator[V]) NextIndex() int {
+	return it.cursor
+}
+
+func (it *Iterator[V]) Previous() V {
+	it.checkModCount()
+	if !it.HasPrevious() {
+		panic(structs.PanicNoSuchElement)
+	}
+
+	it.cursor--
+	it.last = it.cursor
+	return it.list.data[it.last]
+}
+
+func (it *Iterator[V]) PreviousIndex() int {
+	return it.cursor - 1
+}
+
+// Remove removes the value last returned by Next or Previous.
+//
+// Panics if neither has been called since the last call to Remove or Add.
+func (it *Iterator[V]) Remove() {
+	it.checkModCount()
+	if it.last < 0 {
+		panic(structs.PanicIllegalState)
+	}
+
+	it.list.removeAt(it.last)
+	// values after the removed value shift left
+	if it.last < it.cursor {
+		it.cursor--
+	}
+	it.last = -1
+	it.expectedModCount = it.list.modCount
+}
+
+// Set replaces the value last returned by Next or Previous.
+//
+// Panics if neither has been called since the last call to Remove or Add.
+func (it *Iterator[V]) Set(value V) {
+	it.checkModCount()
+	if it.last < 0 {
+		panic(structs.PanicIllegalState)
+	}
+	it.list.data[it.last] = value
+}
+
+// checkModCount panics if the list was modified
+// other than through this iterator.
+func (it *Iterator[V]) checkModCount() {
+	if it.list.modCount != it.expectedModCount {
+		panic(structs.PanicConcurrentModification)
+	}
+}
@@ -6,10 +6,14 @@ import (
 )
 
 type Heap[V any] struct {
-	capFn structs.CapacityFunc
-	cmp   structs.CompareFunc[V]
-	data  []V
-	size  int
+	capFn    structs.CapacityFunc
+	shrinkFn structs.CapacityFunc // nil to never shrink
+	cmp      structs.CompareFunc[V]
+	data     []V
+	size     int
+	// modCount is incremented whenever the heap is structurally
+	// modified, which allows iterators to detect concurrent changes.
+	modCount int
 }
 
 func New[V any](cmp structs.CompareFunc[V]) *Heap[V] {
@@ -55,9 +59,7 @@ func From[V any](data []V, cmp structs.CompareFunc[V]) *Heap[V] {
 		data:  data,
 		size:  len(data),
 	}
-	for i := 0; i < h.size; i++ {
-		h.bubbleDown(i)
-	}
+	h.heapify()
 	return h
 }
 
@@ -68,9 +70,7 @@ func FromOrdered[V constraints.Ordered](data []V) *Heap[V] {
 		data:  data,
 		size:  len(data),
 	}
-	for i := 0; i < h.size; i++ {
-		h.bubbleDown(i)
-	}
+	h.heapify()
 	return h
 }
 
@@ -96,6 +96,44 @@ func (h *Heap[V]) SetCapFunc(capFn structs.CapacityFunc) {
 	h.capFn = capFn
 }
 
+// CompareFunc returns the function used to order the heap's values.
+func (h *Heap[V]) CompareFunc() structs.CompareFunc[V] {
+	return h.cmp
+}
+
+// SetShrinkFunc sets the function used to decrease the capacity of
+// the heap after values are removed, for example structs.HalveCapacity.
+// If it is nil, which is the default, the heap never shrinks.
+func (h *Heap[V]) SetShrinkFunc(shrinkFn structs.CapacityFunc) {
+	h.shrinkFn = shrinkFn
+}
+
+// Add pushes a value onto the heap. It always returns true.
+//
+// Time Complexity: O(logn)
+func (h *Heap[V]) Add(value V) bool {
+	h.Push(value)
+	return true
+}
+
+// AddAll pushes all the values in the other collection onto the heap.
+//
+// Time Complexity: O(mlog(n+m))
+func (h *Heap[V]) AddAll(other structs.Collection[V]) bool {
+	return h.AddIterator(other.Iterator())
+}
+
+// AddIterator pushes all the values in the iterator onto the heap.
+//
+// Time Complexity: O(mlog(n+m))
+func (h *Heap[V]) AddIterator(iter structs.Iterator[V]) bool {
+	changed := iter.HasNext()
+	for iter.HasNext() {
+		h.Push(iter.Next())
+	}
+	return changed
+}
+
 func (h *Heap[V]) IsEmpty() bool {
 	return h.size == 0
 }
@@ -122,10 +160,11 @@ func (h *Heap[V]) Push(value V) {
 	}
 	h.data[h.size-1] = value
 	h.bubbleUp(h.size - 1)
+	h.modCount++
 }
 
 func (h *Heap[V]) Index(value V) int {
-	for i, val := range h.data {
+	for i, val := range h.data[:h.size] {
 		if h.cmp(val, value) == 0 {
 			return i
 		}
@@ -137,12 +176,63 @@ func (h *Heap[V]) Contains(value V) bool {
 	return h.Index(value) >= 0
 }
 
+// ContainsAll returns whether all the values in the other collection are present in the heap.
+//
+// Time Complexity: O(nm)
+func (h *Heap[V]) ContainsAll(other structs.Collection[V]) bool {
+	return h.ContainsIterator(other.Iterator())
+}
+
+// ContainsIterator returns whether all the values in the iterator are present in the heap.
+//
+// Time Complexity: O(nm)
+func (h *Heap[V]) ContainsIterator(iter structs.Iterator[V]) bool {
+	for iter.HasNext() {
+		if !h.Contains(iter.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Iterator returns an Iterator over the heap's values in
+// array order, which is not necessarily priority order.
+//
+// See OrderedIterator to iterate in priority order.
+func (h *Heap[V]) Iterator() structs.Iterator[V] {
+	return &Iterator[V]{
+		heap:              h,
+		lastReturnedIndex: -1,
+		expectedModCount:  h.modCount,
+	}
+}
+
+// OrderedIterator returns an Iterator over the heap's values
+// in priority order. The heap is not modified by iteration,
+// and the returned iterator does not support Remove.
+//
+// Time Complexity: O(klogk) to read the first k values
+func (h *Heap[V]) OrderedIterator() structs.Iterator[V] {
+	it := &OrderedIterator[V]{
+		heap:             h,
+		expectedModCount: h.modCount,
+		indices: New[int](func(a, b int) int {
+			return h.cmp(h.data[a], h.data[b])
+		}),
+	}
+	if h.size > 0 {
+		it.indices.Push(0)
+	}
+	return it
+}
+
 func (h *Heap[V]) UpdateIndex(i int, newValue V) {
 	if !h.isValidIndex(i) {
 		panic("index outside of heap range")
 	}
 	oldValue := h.data[i]
 	h.data[i] = newValue
+	h.modCount++
 	if h.cmp(newValue, oldValue) < 0 {
 		h.bubbleUp(i)
 	} else {
@@ -164,9 +254,7 @@ func (h *Heap[V]) RemoveIndex(i int) V {
 		panic("index outside of heap range")
 	}
 	value := h.data[i]
-	h.data[i] = h.data[h.size-1]
-	h.size--
-	h.bubbleDown(i)
+	h.removeAt(i)
 	return value
 }
 
@@ -179,6 +267,41 @@ func (h *Heap[V]) Remove(value V) bool {
 	return true
 }
 
+// RemoveAll removes all the values in the other collection from the heap.
+//
+// Time Complexity: O(nm)
+func (h *Heap[V]) RemoveAll(other structs.Collection[V]) bool {
+	return h.RemoveIterator(other.Iterator())
+}
+
+// RemoveIterator removes all the values in the iterator from the heap.
+//
+// Time Complexity: O(nm)
+func (h *Heap[V]) RemoveIterator(iter structs.Iterator[V]) bool {
+	changed := false
+	for iter.HasNext() {
+		if h.Remove(iter.Next()) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// RetainAll removes all the values not present in the other collection from the heap.
+//
+// Time Complexity: O(nm)
+func (h *Heap[V]) RetainAll(other structs.Collection[V]) bool {
+	changed := false
+	it := h.Iterator()
+	for it.HasNext() {
+		if !other.Contains(it.Next()) {
+			it.Remove()
+			changed = true
+		}
+	}
+	return changed
+}
+
 func (h *Heap[V]) Values() []V {
 	return h.data[:h.size]
 }
@@ -187,8 +310,16 @@ func (h *Heap[V]) Size() int {
 	return h.size
 }
 
+// Clear removes all the values from the heap. Any references held
+// by the heap are released, so they may be garbage collected.
 func (h *Heap[V]) Clear() {
+	var null V
+	for i := 0; i < h.size; i++ {
+		h.data[i] = null
+	}
 	h.size = 0
+	h.modCount++
+	h.shrink()
 }
 
 // todo: consider keeping heap's Clone()
@@ -210,7 +341,54 @@ func (h *Heap[V]) less(i, j int) bool {
 	return h.cmp(h.data[i], h.data[j]) < 0
 }
 
-func (h *Heap[V]) bubbleUp(i int) {
+// heapify restores the heap property for the entire
+// heap by bubbling down from the last parent to the root.
+//
+// Time Complexity: O(n)
+func (h *Heap[V]) heapify() {
+	for i := h.size/2 - 1; i >= 0; i-- {
+		h.bubbleDown(i)
+	}
+}
+
+// removeAt removes the value at index i by moving the last value
+// into its place and restoring the heap property. It returns the
+// index the moved value ended up at, or -1 if i was the last index,
+// so iterators can keep track of the values they have not yet seen.
+func (h *Heap[V]) removeAt(i int) (movedTo int) {
+	defer h.shrink()
+
+	var null V
+	h.modCount++
+	h.size--
+	if i == h.size {
+		h.data[i] = null
+		return -1
+	}
+	h.data[i] = h.data[h.size]
+	h.data[h.size] = null
+	if j := h.bubbleDown(i); j != i {
+		return j
+	}
+	return h.bubbleUp(i)
+}
+
+// shrink reallocates the heap's data with a smaller
+// capacity if the shrink function permits it.
+func (h *Heap[V]) shrink() {
+	if h.shrinkFn == nil {
+		return
+	}
+	size := h.shrinkFn(len(h.data), h.size)
+	if size < h.size {
+		size = h.size
+	}
+	if size < len(h.data) {
+		h.data = structs.Realloc(size, h.data)
+	}
+}
+
+func (h *Heap[V]) bubbleUp(i int) int {
 	parent := (i - 1) / 2
 	for i > 0 && h.less(i, parent) {
 		h.data[i], h.data[parent] = h.data[parent], h.data[i]
@@ -218,9 +396,10 @@ func (h *Heap[V]) bubbleUp(i int) {
 		i = parent
 		parent = (i - 1) / 2
 	}
+	return i
 }
 
-func (h *Heap[V]) bubbleDown(i int) {
+func (h *Heap[V]) bubbleDown(i int) int {
 	left := 2*i + 1
 	right := 2*i + 2
 	for left < h.size && h.less(left, i) ||
@@ -237,4 +416,5 @@ func (h *Heap[V]) bubbleDown(i int) {
 		left = 2*i + 1
 		right = 2*i + 2
 	}
+	return i
 }
@@ -2,6 +2,8 @@ package heap
 
 import (
 	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/wrap"
+	"golang.org/x/exp/slices"
 	"math/rand"
 	"sort"
 	"testing"
@@ -44,3 +46,145 @@ func TestNewCap(t *testing.T) {
 		t.Errorf("expected capacity of %d but got %d", capacity, len(h.data))
 	}
 }
+
+func TestHeap_Collection(t *testing.T) {
+	var h structs.Collection[int] = NewOrdered[int]()
+
+	h.AddAll(wrap.OrderedValues(5, 3, 8, 1, 9, 2))
+	if h.Size() != 6 {
+		t.Errorf("expected size 6 but got %d", h.Size())
+	}
+	if !h.ContainsAll(wrap.OrderedValues(1, 9)) {
+		t.Error("expected heap to contain 1 and 9")
+	}
+	if h.ContainsIterator(wrap.ValueIterator(1, 4)) {
+		t.Error("expected heap not to contain 4")
+	}
+
+	h.RemoveAll(wrap.OrderedValues(3, 9))
+	h.RetainAll(wrap.OrderedValues(1, 2, 5, 8))
+	h.Add(0)
+
+	expect := []int{0, 1, 2, 5, 8}
+	for i, value := range expect {
+		got := h.(*Heap[int]).Pop()
+		if got != value {
+			t.Errorf("expected %d but got %d at index %d", value, got, i)
+		}
+	}
+}
+
+func TestHeap_RemoveIndex(t *testing.T) {
+	// removing index 4 moves 3 (the last value) into a subtree
+	// where it must bubble up rather than down to stay valid.
+	h := FromOrderedHeapSlice([]int{0, 10, 1, 11, 12, 2, 3})
+	h.RemoveIndex(4)
+
+	expect := []int{0, 1, 2, 3, 10, 11}
+	for i, value := range expect {
+		got := h.Pop()
+		if got != value {
+			t.Errorf("expected %d but got %d at index %d", value, got, i)
+		}
+	}
+}
+
+func TestFrom(t *testing.T) {
+	// bubbling down from the root first would leave 1 below 3
+	h := FromOrdered([]int{5, 4, 3, 2, 1})
+
+	for expect := 1; expect <= 5; expect++ {
+		got := h.Pop()
+		if got != expect {
+			t.Errorf("expected %d but got %d", expect, got)
+		}
+	}
+}
+
+func TestFrom_Random(t *testing.T) {
+	const count = 257
+
+	random := make([]int, count)
+	for i := 0; i < len(random); i++ {
+		random[i] = rand.Intn(count)
+	}
+	h := From(slices.Clone(random), structs.CompareOrdered[int])
+	sort.Ints(random)
+
+	for i := 0; i < len(random); i++ {
+		got := h.Pop()
+		if got != random[i] {
+			t.Errorf("expected %d but got %d at index %d", random[i], got, i)
+		}
+	}
+}
+
+func TestHeap_SetShrinkFunc(t *testing.T) {
+	const count = 64
+
+	h := NewOrdered[int]()
+	h.SetShrinkFunc(structs.HalveCapacity)
+	for i := 0; i < count; i++ {
+		h.Push(i)
+	}
+	if len(h.data) != count {
+		t.Fatalf("expected capacity of %d but got %d", count, len(h.data))
+	}
+
+	for i := 0; i < count-count/4; i++ {
+		h.Pop()
+	}
+	if len(h.data) != count/2 {
+		t.Errorf("expected capacity of %d but got %d", count/2, len(h.data))
+	}
+	for i := count - count/4; i < count; i++ {
+		got := h.Pop()
+		if got != i {
+			t.Errorf("expected %d but got %d", i, got)
+		}
+	}
+	if len(h.data) > 1 {
+		t.Errorf("expected capacity of at most 1 but got %d", len(h.data))
+	}
+}
+
+func TestHeap_SetShrinkFuncClamped(t *testing.T) {
+	h := NewOrdered[int]()
+	// a shrink function returning too little is clamped
+	// to the number of values the heap needs to hold
+	h.SetShrinkFunc(func(before, need int) int { return 0 })
+	for i := 0; i < 8; i++ {
+		h.Push(i)
+	}
+	h.Pop()
+	if len(h.data) != 7 {
+		t.Errorf("expected capacity of 7 but got %d", len(h.data))
+	}
+	for i := 1; i < 8; i++ {
+		if got := h.Pop(); got != i {
+			t.Errorf("expected %d but got %d", i, got)
+		}
+	}
+}
+
+func TestHeap_ReleasesReferences(t *testing.T) {
+	h := New[*int](func(a, b *int) int {
+		return structs.CompareOrdered(*a, *b)
+	})
+	for i := 0; i < 4; i++ {
+		value := i
+		h.Push(&value)
+	}
+
+	h.Pop()
+	if h.data[3] != nil {
+		t.Error("expected popped slot to be released")
+	}
+
+	h.Clear()
+	for i, value := range h.data {
+		if value != nil {
+			t.Errorf("expected slot %d to be released after clear", i)
+		}
+	}
+}
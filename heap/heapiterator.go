@@ -1,51 +1,148 @@
 package heap
 
-import "github.com/zytekaron/structs/list"
-
-// todo: thoroughly test
+import "github.com/zytekaron/structs"
 
+// Iterator is an iterator over the values of a heap in array order.
 type Iterator[V any] struct {
 	heap  *Heap[V]
 	index int
 
-	unforgotten *list.List[V]
+	// unforgotten holds the indices of values which were moved from
+	// after the cursor to before it by Remove, and would otherwise be
+	// skipped. The indices are kept up to date as values are removed,
+	// so duplicates of a value are never mistaken for one another.
+	unforgotten []int
 
-	lastReturned      *V
 	lastReturnedIndex int
+	lastUnforgotten   bool
+
+	expectedModCount int
 }
 
 func (i *Iterator[V]) HasNext() bool {
-	return i.index < i.heap.size ||
-		(i.unforgotten != nil && i.unforgotten.IsEmpty())
+	return i.index < i.heap.size || len(i.unforgotten) > 0
 }
 
 func (i *Iterator[V]) Next() V {
+	i.checkModCount()
 	if i.index < i.heap.size {
 		i.lastReturnedIndex = i.index
+		i.lastUnforgotten = false
 		i.index++
 		return i.heap.data[i.lastReturnedIndex]
 	}
-	if i.unforgotten != nil {
-		i.lastReturnedIndex = -1
-		*i.lastReturned = i.unforgotten.Poll()
-		return *i.lastReturned
+	if len(i.unforgotten) > 0 {
+		i.lastReturnedIndex = i.unforgotten[0]
+		i.lastUnforgotten = true
+		i.unforgotten = i.unforgotten[1:]
+		return i.heap.data[i.lastReturnedIndex]
 	}
-	panic("next called on empty iterator")
+	panic(structs.PanicNoSuchElement)
 }
 
 func (i *Iterator[V]) Remove() {
-	if i.lastReturnedIndex != -1 {
-		moved := i.heap.RemoveIndex(i.lastReturnedIndex)
-		i.lastReturnedIndex = -1
+	i.checkModCount()
+	if i.lastReturnedIndex == -1 {
+		panic(structs.PanicIllegalState)
+	}
+	removed := i.lastReturnedIndex
+	last := i.heap.size - 1
+	movedTo := i.heap.removeAt(removed)
+	i.lastReturnedIndex = -1
+	i.expectedModCount = i.heap.modCount
 
-		if i.unforgotten == nil {
-			i.unforgotten = list.New[V](nil)
+	if movedTo == -1 {
+		// the last value was removed, so nothing else moved
+		if !i.lastUnforgotten {
+			i.index--
 		}
-		i.unforgotten.Add(moved)
 		return
 	}
-	if i.lastReturned != nil {
-		i.heap.Remove(*i.lastReturned)
-		i.lastReturned = nil
+	for k, index := range i.unforgotten {
+		i.unforgotten[k] = relocate(index, removed, last, movedTo)
+	}
+	if i.lastUnforgotten {
+		// every value in the heap was either
+		// already seen, or is held in unforgotten
+		return
+	}
+	if movedTo >= removed {
+		// the value at the removed index has not been seen yet
+		i.index--
+		return
+	}
+	i.unforgotten = append(i.unforgotten, movedTo)
+}
+
+// relocate returns the new index of the value at index after the value
+// at removed was replaced by the value at last, which then moved to
+// movedTo, shifting the values on the path between them by one level.
+func relocate(index, removed, last, movedTo int) int {
+	if index == last {
+		return movedTo
+	}
+	if movedTo > removed {
+		// bubbled down: each value on the path moved up to its parent
+		for c := movedTo; c != removed; c = (c - 1) / 2 {
+			if index == c {
+				return (c - 1) / 2
+			}
+		}
+	} else {
+		// bubbled up: each value on the path moved down to its child
+		for c := removed; c != movedTo; c = (c - 1) / 2 {
+			if index == (c-1)/2 {
+				return c
+			}
+		}
 	}
+	return index
+}
+
+// checkModCount panics if the heap was modified
+// other than through this iterator.
+func (i *Iterator[V]) checkModCount() {
+	if i.heap.modCount != i.expectedModCount {
+		panic(structs.PanicConcurrentModification)
+	}
+}
+
+// OrderedIterator is an iterator over the values
+// of a heap in priority order, without modifying it.
+type OrderedIterator[V any] struct {
+	heap *Heap[V]
+
+	// indices is a heap of indices into the underlying heap's data,
+	// holding the frontier of values which have not yet been returned.
+	indices *Heap[int]
+
+	expectedModCount int
+}
+
+func (i *OrderedIterator[V]) HasNext() bool {
+	return !i.indices.IsEmpty()
+}
+
+func (i *OrderedIterator[V]) Next() V {
+	if i.heap.modCount != i.expectedModCount {
+		panic(structs.PanicConcurrentModification)
+	}
+	if i.indices.IsEmpty() {
+		panic(structs.PanicNoSuchElement)
+	}
+	index := i.indices.Pop()
+	left := 2*index + 1
+	right := 2*index + 2
+	if left < i.heap.size {
+		i.indices.Push(left)
+	}
+	if right < i.heap.size {
+		i.indices.Push(right)
+	}
+	return i.heap.data[index]
+}
+
+// Remove panics when called.
+func (i *OrderedIterator[V]) Remove() {
+	panic(structs.PanicUnsupportedOperation)
 }
@@ -0,0 +1,188 @@
+package heap
+
+import (
+	"github.com/zytekaron/structs"
+	"golang.org/x/exp/slices"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestIterator(t *testing.T) {
+	const count = 256
+
+	h := NewOrdered[int]()
+	expect := make([]int, count)
+	for i := 0; i < count; i++ {
+		expect[i] = rand.Intn(count)
+		h.Push(expect[i])
+	}
+
+	var got []int
+	it := h.Iterator()
+	for it.HasNext() {
+		got = append(got, it.Next())
+	}
+
+	sort.Ints(expect)
+	sort.Ints(got)
+	if !slices.Equal(got, expect) {
+		t.Errorf("expected values %v but got %v", expect, got)
+	}
+}
+
+func TestIterator_Remove(t *testing.T) {
+	const count = 256
+
+	// repeat with many random heaps to exercise values
+	// being moved before the cursor by a removal
+	for round := 0; round < 64; round++ {
+		h := NewOrdered[int]()
+		for i := 0; i < count; i++ {
+			h.Push(rand.Intn(count))
+		}
+
+		var seen, expect []int
+		it := h.Iterator()
+		for it.HasNext() {
+			value := it.Next()
+			seen = append(seen, value)
+			if value%2 == 1 {
+				it.Remove()
+			} else {
+				expect = append(expect, value)
+			}
+		}
+
+		if len(seen) != count {
+			t.Fatalf("expected to see %d values but saw %d", count, len(seen))
+		}
+		got := h.Values()
+		sort.Ints(got)
+		sort.Ints(expect)
+		if !slices.Equal(got, expect) {
+			t.Fatalf("expected remaining values %v but got %v", expect, got)
+		}
+		for i := 0; i < len(expect); i++ {
+			if value := h.Pop(); value != expect[i] {
+				t.Fatalf("expected %d but got %d at index %d", expect[i], value, i)
+			}
+		}
+	}
+}
+
+func TestIterator_RemoveDuplicatePriorities(t *testing.T) {
+	const count = 256
+
+	type item struct {
+		priority int
+		id       int
+	}
+
+	// few distinct priorities, so many values compare equal
+	// to the values moved before the cursor by a removal
+	for round := 0; round < 64; round++ {
+		h := New(func(a, b item) int {
+			return a.priority - b.priority
+		})
+		for i := 0; i < count; i++ {
+			h.Push(item{rand.Intn(4), i})
+		}
+
+		var seen, expect []int
+		it := h.Iterator()
+		for it.HasNext() {
+			value := it.Next()
+			seen = append(seen, value.id)
+			if value.id%3 != 0 {
+				it.Remove()
+			} else {
+				expect = append(expect, value.id)
+			}
+		}
+
+		sort.Ints(seen)
+		for i, id := range seen {
+			if id != i {
+				t.Fatalf("expected to see each of %d values once but saw %v", count, seen)
+			}
+		}
+		var got []int
+		for _, value := range h.Values() {
+			got = append(got, value.id)
+		}
+		sort.Ints(got)
+		sort.Ints(expect)
+		if !slices.Equal(got, expect) {
+			t.Fatalf("expected remaining ids %v but got %v", expect, got)
+		}
+	}
+}
+
+func TestIterator_RemoveIllegalState(t *testing.T) {
+	defer func() {
+		if r := recover(); r != structs.PanicIllegalState {
+			t.Errorf("expected panic %q but got %v", structs.PanicIllegalState, r)
+		}
+	}()
+
+	h := FromOrdered([]int{1, 2, 3})
+	it := h.Iterator()
+	it.Next()
+	it.Remove()
+	it.Remove()
+}
+
+func TestOrderedIterator(t *testing.T) {
+	const count = 256
+
+	h := NewOrdered[int]()
+	expect := make([]int, count)
+	for i := 0; i < count; i++ {
+		expect[i] = rand.Intn(count / 2)
+		h.Push(expect[i])
+	}
+	before := slices.Clone(h.Values())
+
+	var got []int
+	it := h.OrderedIterator()
+	for it.HasNext() {
+		got = append(got, it.Next())
+	}
+
+	sort.Ints(expect)
+	if !slices.Equal(got, expect) {
+		t.Errorf("expected values %v but got %v", expect, got)
+	}
+	if !slices.Equal(h.Values(), before) {
+		t.Error("expected heap to be unmodified by ordered iteration")
+	}
+}
+
+func TestIterator_ConcurrentModification(t *testing.T) {
+	defer func() {
+		if r := recover(); r != structs.PanicConcurrentModification {
+			t.Errorf("expected panic %q but got %v", structs.PanicConcurrentModification, r)
+		}
+	}()
+
+	h := FromOrdered([]int{1, 2, 3})
+	it := h.Iterator()
+	it.Next()
+	h.Push(4)
+	it.Next()
+}
+
+func TestOrderedIterator_ConcurrentModification(t *testing.T) {
+	defer func() {
+		if r := recover(); r != structs.PanicConcurrentModification {
+			t.Errorf("expected panic %q but got %v", structs.PanicConcurrentModification, r)
+		}
+	}()
+
+	h := FromOrdered([]int{1, 2, 3})
+	it := h.OrderedIterator()
+	it.Next()
+	h.Pop()
+	it.Next()
+}
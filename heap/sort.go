@@ -0,0 +1,183 @@
+package heap
+
+import (
+	"github.com/zytekaron/structs"
+	"golang.org/x/exp/constraints"
+)
+
+// Sort sorts the slice in place in ascending order based on a comparator.
+// The sort is not stable.
+//
+// Time Complexity: O(nlogn)
+//
+// Space Complexity: O(1)
+func Sort[V any](s []V, cmp structs.CompareFunc[V]) {
+	// a max-heap repeatedly moves its largest value
+	// to the end of the shrinking unsorted region
+	h := fromSlice(s, structs.Reverse(cmp))
+	h.heapify()
+	h.sortDown()
+}
+
+// SortOrdered sorts the slice in place in ascending order
+// for a type that implements constraints.Ordered.
+//
+// Time Complexity: O(nlogn)
+//
+// Space Complexity: O(1)
+func SortOrdered[V constraints.Ordered](s []V) {
+	Sort(s, structs.CompareOrdered[V])
+}
+
+// PartialSort rearranges the slice in place so that the first k values
+// are the k smallest values in ascending order. The order of the
+// remaining values is unspecified. If k is greater than the length
+// of the slice, the entire slice is sorted.
+//
+// Time Complexity: O(nlogk)
+//
+// Space Complexity: O(1)
+func PartialSort[V any](s []V, k int, cmp structs.CompareFunc[V]) {
+	if k <= 0 {
+		return
+	}
+	if k > len(s) {
+		k = len(s)
+	}
+
+	// keep the k smallest values seen so far in a max-heap at
+	// the front of the slice, replacing its largest when a
+	// smaller value is found further along
+	h := fromSlice(s[:k], structs.Reverse(cmp))
+	h.heapify()
+	for i := k; i < len(s); i++ {
+		if cmp(s[i], s[0]) < 0 {
+			s[i], s[0] = s[0], s[i]
+			h.bubbleDown(0)
+		}
+	}
+	h.sortDown()
+}
+
+// NSmallest returns the k smallest values from the iterator
+// in ascending order, consuming the entire iterator.
+//
+// Time Complexity: O(nlogk)
+//
+// Space Complexity: O(k)
+func NSmallest[V any](iter structs.Iterator[V], k int, cmp structs.CompareFunc[V]) []V {
+	if k <= 0 {
+		return []V{}
+	}
+
+	h := NewCap(k, structs.Reverse(cmp))
+	for iter.HasNext() {
+		value := iter.Next()
+		if h.size < k {
+			h.Push(value)
+		} else if cmp(value, h.data[0]) < 0 {
+			h.data[0] = value
+			h.bubbleDown(0)
+		}
+	}
+
+	values := h.data[:h.size]
+	h.sortDown()
+	return values
+}
+
+// NLargest returns the k largest values from the iterator
+// in descending order, consuming the entire iterator.
+//
+// Time Complexity: O(nlogk)
+//
+// Space Complexity: O(k)
+func NLargest[V any](iter structs.Iterator[V], k int, cmp structs.CompareFunc[V]) []V {
+	return NSmallest(iter, k, structs.Reverse(cmp))
+}
+
+// MergeSorted returns an iterator which lazily merges the values of
+// iterators that are each sorted in ascending order based on the
+// comparator, producing all of their values in ascending order.
+//
+// The returned iterator does not support Remove.
+//
+// Time Complexity: O(logm) per value
+//
+//	m = number of iterators
+func MergeSorted[V any](cmp structs.CompareFunc[V], iters ...structs.Iterator[V]) structs.Iterator[V] {
+	sources := NewCap(len(iters), func(a, b *mergeSource[V]) int {
+		return cmp(a.value, b.value)
+	})
+	for _, iter := range iters {
+		if iter.HasNext() {
+			sources.Push(&mergeSource[V]{
+				value: iter.Next(),
+				iter:  iter,
+			})
+		}
+	}
+	return &MergeIterator[V]{
+		sources: sources,
+	}
+}
+
+// MergeIterator is an iterator which merges several sorted iterators.
+type MergeIterator[V any] struct {
+	sources *Heap[*mergeSource[V]]
+}
+
+// mergeSource is an iterator being merged, along
+// with the next value which it has produced.
+type mergeSource[V any] struct {
+	value V
+	iter  structs.Iterator[V]
+}
+
+func (i *MergeIterator[V]) HasNext() bool {
+	return !i.sources.IsEmpty()
+}
+
+func (i *MergeIterator[V]) Next() V {
+	if i.sources.IsEmpty() {
+		panic(structs.PanicNoSuchElement)
+	}
+
+	source := i.sources.data[0]
+	value := source.value
+	if source.iter.HasNext() {
+		source.value = source.iter.Next()
+		i.sources.bubbleDown(0)
+	} else {
+		i.sources.Pop()
+	}
+	return value
+}
+
+// Remove panics when called.
+func (i *MergeIterator[V]) Remove() {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// fromSlice creates a heap which operates directly on
+// the slice, without checking or restoring its order.
+func fromSlice[V any](s []V, cmp structs.CompareFunc[V]) *Heap[V] {
+	return &Heap[V]{
+		capFn: structs.DoubleCapacity,
+		cmp:   cmp,
+		data:  s,
+		size:  len(s),
+	}
+}
+
+// sortDown repeatedly swaps the top of the heap to the end of
+// its data, leaving the data sorted in reverse heap order and
+// the heap empty.
+func (h *Heap[V]) sortDown() {
+	for h.size > 1 {
+		h.size--
+		h.data[0], h.data[h.size] = h.data[h.size], h.data[0]
+		h.bubbleDown(0)
+	}
+	h.size = 0
+}
@@ -0,0 +1,108 @@
+package heap
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/wrap"
+	"golang.org/x/exp/slices"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func randomInts(count, max int) []int {
+	s := make([]int, count)
+	for i := range s {
+		s[i] = rand.Intn(max)
+	}
+	return s
+}
+
+func TestSort(t *testing.T) {
+	for _, count := range []int{0, 1, 2, 3, 17, 256} {
+		s := randomInts(count, count/2+1)
+		expect := slices.Clone(s)
+		sort.Ints(expect)
+
+		Sort(s, structs.CompareOrdered[int])
+		if !slices.Equal(s, expect) {
+			t.Errorf("expected sorted slice %v but got %v", expect, s)
+		}
+	}
+}
+
+func TestSortOrdered(t *testing.T) {
+	s := []string{"d", "a", "c", "b"}
+	SortOrdered(s)
+
+	expect := []string{"a", "b", "c", "d"}
+	if !slices.Equal(s, expect) {
+		t.Errorf("expected sorted slice %v but got %v", expect, s)
+	}
+}
+
+func TestPartialSort(t *testing.T) {
+	for _, k := range []int{0, 1, 5, 64, 256, 300} {
+		s := randomInts(256, 128)
+		expect := slices.Clone(s)
+		sort.Ints(expect)
+
+		PartialSort(s, k, structs.CompareOrdered[int])
+
+		n := k
+		if n > len(s) {
+			n = len(s)
+		}
+		if !slices.Equal(s[:n], expect[:n]) {
+			t.Errorf("expected first %d values %v but got %v", n, expect[:n], s[:n])
+		}
+		rest := slices.Clone(s[n:])
+		sort.Ints(rest)
+		if !slices.Equal(rest, expect[n:]) {
+			t.Errorf("expected remaining values %v but got %v", expect[n:], rest)
+		}
+	}
+}
+
+func TestNSmallest(t *testing.T) {
+	s := randomInts(256, 128)
+	expect := slices.Clone(s)
+	sort.Ints(expect)
+
+	got := NSmallest[int](wrap.SliceIterator(s), 10, structs.CompareOrdered[int])
+	if !slices.Equal(got, expect[:10]) {
+		t.Errorf("expected smallest values %v but got %v", expect[:10], got)
+	}
+
+	got = NSmallest[int](wrap.ValueIterator(3, 1, 2), 10, structs.CompareOrdered[int])
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("expected smallest values %v but got %v", []int{1, 2, 3}, got)
+	}
+}
+
+func TestNLargest(t *testing.T) {
+	got := NLargest[int](wrap.ValueIterator(5, 1, 9, 3, 7), 3, structs.CompareOrdered[int])
+
+	expect := []int{9, 7, 5}
+	if !slices.Equal(got, expect) {
+		t.Errorf("expected largest values %v but got %v", expect, got)
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	it := MergeSorted[int](structs.CompareOrdered[int],
+		wrap.ValueIterator(1, 4, 7),
+		wrap.ValueIterator[int](),
+		wrap.ValueIterator(2, 2, 8, 9),
+		wrap.ValueIterator(0, 3),
+	)
+
+	var got []int
+	for it.HasNext() {
+		got = append(got, it.Next())
+	}
+
+	expect := []int{0, 1, 2, 2, 3, 4, 7, 8, 9}
+	if !slices.Equal(got, expect) {
+		t.Errorf("expected merged values %v but got %v", expect, got)
+	}
+}
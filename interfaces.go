@@ -7,6 +7,7 @@ type Iterator[V any] interface {
 }
 
 type ListIterator[V any] interface {
+	Add(value V)
 	HasNext() bool
 	HasPrevious() bool
 	NextIndex() int
@@ -14,6 +15,7 @@ type ListIterator[V any] interface {
 	PreviousIndex() int
 	Previous() V
 	Remove()
+	Set(value V)
 }
 
 type Collection[V any] interface {
@@ -44,7 +46,7 @@ type List[V any] interface {
 	RemoveAt(index int) V
 	Set(index int, value V) V
 	Sort(cmp LessFunc[V])
-	//SubList(from, to int) List[V]
+	SubList(from, to int) List[V]
 }
 
 type Set[V any] interface {
@@ -65,6 +67,7 @@ type Queue[V any] interface {
 	Collection[V]
 
 	Element() V
+	Offer(value V) bool
 	Peek() V
 	Poll() V
 	RemoveHead() V
@@ -74,8 +77,8 @@ type Deque[V any] interface {
 	Collection[V]
 	Queue[V]
 
-	AddFirst(value V)
-	AddLast(value V)
+	AddFirst(value V) bool
+	AddLast(value V) bool
 	GetFirst() V
 	GetLast() V
 	Offer(value V) bool
@@ -0,0 +1,225 @@
+// Package dequetest implements a test suite shared by
+// implementations of structs.Deque.
+package dequetest
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/wrap"
+	"golang.org/x/exp/slices"
+	"math/rand"
+	"testing"
+)
+
+// Run runs the test suite against deques created by newDeque,
+// which must return an empty deque using integer equality.
+func Run(t *testing.T, newDeque func() structs.Deque[int]) {
+	t.Run("AddFirstLast", func(t *testing.T) {
+		d := newDeque()
+		d.AddLast(3)
+		d.AddFirst(2)
+		d.Add(4)
+		d.OfferFirst(1)
+		d.OfferLast(5)
+		d.Offer(6)
+
+		expectValues(t, d, 1, 2, 3, 4, 5, 6)
+		if d.GetFirst() != 1 || d.Element() != 1 || d.PeekFirst() != 1 || d.Peek() != 1 {
+			t.Error("expected the first value to be 1")
+		}
+		if d.GetLast() != 6 || d.PeekLast() != 6 {
+			t.Error("expected the last value to be 6")
+		}
+	})
+
+	t.Run("PollRemove", func(t *testing.T) {
+		d := newDeque()
+		d.AddAll(wrap.OrderedValues(1, 2, 3, 4, 5, 6))
+
+		expect := []int{d.Poll(), d.PollFirst(), d.PollLast(), d.RemoveHead(), d.RemoveFirst(), d.RemoveLast()}
+		if !slices.Equal(expect, []int{1, 2, 6, 3, 4, 5}) {
+			t.Errorf("expected removed values %v but got %v", []int{1, 2, 6, 3, 4, 5}, expect)
+		}
+		if !d.IsEmpty() {
+			t.Error("expected deque to be empty, got size", d.Size())
+		}
+	})
+
+	t.Run("PushPop", func(t *testing.T) {
+		d := newDeque()
+		for i := 0; i < 5; i++ {
+			d.Push(i)
+		}
+		for i := 4; i >= 0; i-- {
+			if got := d.Pop(); got != i {
+				t.Errorf("expected %d but got %d", i, got)
+			}
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		d := newDeque()
+		if d.Peek() != 0 || d.PeekFirst() != 0 || d.PeekLast() != 0 {
+			t.Error("expected peeking an empty deque to return the zero value")
+		}
+		if d.Poll() != 0 || d.PollFirst() != 0 || d.PollLast() != 0 {
+			t.Error("expected polling an empty deque to return the zero value")
+		}
+
+		panics := map[string]func(){
+			"Element":     func() { d.Element() },
+			"GetFirst":    func() { d.GetFirst() },
+			"GetLast":     func() { d.GetLast() },
+			"Pop":         func() { d.Pop() },
+			"RemoveHead":  func() { d.RemoveHead() },
+			"RemoveFirst": func() { d.RemoveFirst() },
+			"RemoveLast":  func() { d.RemoveLast() },
+		}
+		for name, f := range panics {
+			t.Run(name, func(t *testing.T) {
+				defer func() {
+					if r := recover(); r != structs.PanicNoSuchElement {
+						t.Errorf("expected panic %q but got %v", structs.PanicNoSuchElement, r)
+					}
+				}()
+				f()
+			})
+		}
+	})
+
+	t.Run("Occurrences", func(t *testing.T) {
+		d := newDeque()
+		d.AddAll(wrap.OrderedValues(1, 2, 3, 1, 2, 3))
+
+		if !d.RemoveFirstOccurrence(2) || !d.RemoveLastOccurrence(3) {
+			t.Fatal("expected occurrences of 2 and 3 to be removed")
+		}
+		if d.RemoveFirstOccurrence(4) || d.RemoveLastOccurrence(4) {
+			t.Error("expected no occurrences of 4 to be removed")
+		}
+		expectValues(t, d, 1, 3, 1, 2)
+
+		if !d.Remove(1) {
+			t.Error("expected 1 to be removed")
+		}
+		expectValues(t, d, 3, 1, 2)
+	})
+
+	t.Run("Collection", func(t *testing.T) {
+		d := newDeque()
+		if !d.AddAll(wrap.OrderedValues(1, 2, 3, 4)) || !d.AddIterator(wrap.ValueIterator(5, 6, 7, 8)) {
+			t.Error("expected adding values to change the deque")
+		}
+		if !d.Contains(5) || d.Contains(9) {
+			t.Error("expected deque to contain 5 but not 9")
+		}
+		if !d.ContainsAll(wrap.OrderedValues(1, 8)) || d.ContainsIterator(wrap.ValueIterator(1, 9)) {
+			t.Error("expected deque to contain all of 1 and 8 but not all of 1 and 9")
+		}
+
+		d.RemoveAll(wrap.OrderedValues(2, 4))
+		d.RemoveIterator(wrap.ValueIterator(6))
+		expectValues(t, d, 1, 3, 5, 7, 8)
+
+		if !d.RetainAll(wrap.OrderedValues(3, 7, 8, 9)) {
+			t.Error("expected retaining values to change the deque")
+		}
+		expectValues(t, d, 3, 7, 8)
+		if d.RetainAll(wrap.OrderedValues(3, 7, 8)) {
+			t.Error("expected retaining all values not to change the deque")
+		}
+
+		d.Clear()
+		if !d.IsEmpty() || d.Size() != 0 {
+			t.Error("expected cleared deque to be empty, got size", d.Size())
+		}
+	})
+
+	t.Run("IteratorRemove", func(t *testing.T) {
+		d := newDeque()
+		for i := 0; i < 10; i++ {
+			d.AddFirst(i)
+		}
+		it := d.Iterator()
+		for it.HasNext() {
+			if it.Next()%3 != 0 {
+				it.Remove()
+			}
+		}
+		expectValues(t, d, 9, 6, 3, 0)
+	})
+
+	t.Run("ConcurrentModification", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != structs.PanicConcurrentModification {
+				t.Errorf("expected panic %q but got %v", structs.PanicConcurrentModification, r)
+			}
+		}()
+
+		d := newDeque()
+		d.AddAll(wrap.OrderedValues(1, 2, 3))
+		it := d.Iterator()
+		it.Next()
+		d.AddFirst(0)
+		it.Next()
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		// compare against a slice after many random operations,
+		// which wrap array-backed deques around many times
+		d := newDeque()
+		var model []int
+		for i := 0; i < 4096; i++ {
+			switch rand.Intn(5) {
+			case 0:
+				d.AddFirst(i)
+				model = append([]int{i}, model...)
+			case 1, 2:
+				d.AddLast(i)
+				model = append(model, i)
+			case 3:
+				got := d.PollFirst()
+				expect := 0
+				if len(model) > 0 {
+					expect, model = model[0], model[1:]
+				}
+				if got != expect {
+					t.Fatalf("expected %d from PollFirst but got %d", expect, got)
+				}
+			case 4:
+				got := d.PollLast()
+				expect := 0
+				if len(model) > 0 {
+					expect, model = model[len(model)-1], model[:len(model)-1]
+				}
+				if got != expect {
+					t.Fatalf("expected %d from PollLast but got %d", expect, got)
+				}
+			}
+		}
+		expectValues(t, d, model...)
+	})
+}
+
+// expectValues checks the values of the deque using Values and Iterator.
+func expectValues(t *testing.T, d structs.Deque[int], expect ...int) {
+	t.Helper()
+
+	if expect == nil {
+		expect = []int{}
+	}
+	if got := d.Values(); !slices.Equal(got, expect) {
+		t.Errorf("expected values %v but got %v", expect, got)
+	}
+	if d.Size() != len(expect) {
+		t.Errorf("expected size %d but got %d", len(expect), d.Size())
+	}
+
+	got := []int{}
+	it := d.Iterator()
+	for it.HasNext() {
+		got = append(got, it.Next())
+	}
+	if !slices.Equal(got, expect) {
+		t.Errorf("expected iterated values %v but got %v", expect, got)
+	}
+}
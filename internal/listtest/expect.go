@@ -0,0 +1,27 @@
+package listtest
+
+import (
+	"golang.org/x/exp/slices"
+	"testing"
+)
+
+// ExpectValues fails the test immediately if the
+// values are not equal to the expected values.
+func ExpectValues(t *testing.T, got, expect []int) {
+	t.Helper()
+	if !slices.Equal(got, expect) {
+		t.Fatalf("expected values %v but got %v", expect, got)
+	}
+}
+
+// ExpectPanic fails the test if f does not panic with the expected value.
+func ExpectPanic(t *testing.T, expect string, f func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		if r := recover(); r != expect {
+			t.Errorf("expected panic %q but got %v", expect, r)
+		}
+	}()
+	f()
+}
@@ -0,0 +1,230 @@
+// Package listtest implements a test suite and helpers
+// shared by the tests of implementations of structs.List.
+package listtest
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/wrap"
+	"golang.org/x/exp/slices"
+	"math/rand"
+	"testing"
+)
+
+// Run runs the test suite against lists created by newList,
+// which must return an empty list using integer equality.
+func Run(t *testing.T, newList func() structs.List[int]) {
+	t.Run("AddGetSet", func(t *testing.T) {
+		l := newList()
+		for i := 0; i < 5; i++ {
+			l.Add(i)
+		}
+		l.AddAt(0, -1)
+		l.AddAt(3, 10)
+		l.AddAt(l.Size(), 5)
+		expectValues(t, l, -1, 0, 1, 10, 2, 3, 4, 5)
+
+		if got := l.Get(3); got != 10 {
+			t.Errorf("expected 10 at index 3 but got %d", got)
+		}
+		if old := l.Set(3, 20); old != 10 {
+			t.Errorf("expected old value 10 but got %d", old)
+		}
+		expectValues(t, l, -1, 0, 1, 20, 2, 3, 4, 5)
+	})
+
+	t.Run("RemoveAt", func(t *testing.T) {
+		l := newList()
+		l.AddAll(wrap.OrderedValues(0, 1, 2, 3, 4, 5))
+
+		removed := []int{l.RemoveAt(0), l.RemoveAt(4), l.RemoveAt(1)}
+		if !slices.Equal(removed, []int{0, 5, 2}) {
+			t.Errorf("expected removed values %v but got %v", []int{0, 5, 2}, removed)
+		}
+		expectValues(t, l, 1, 3, 4)
+	})
+
+	t.Run("IndexOf", func(t *testing.T) {
+		l := newList()
+		l.AddAll(wrap.OrderedValues(1, 2, 3, 1, 2, 3))
+
+		if l.IndexOf(2) != 1 || l.LastIndexOf(2) != 4 {
+			t.Errorf("expected indices 1 and 4 but got %d and %d", l.IndexOf(2), l.LastIndexOf(2))
+		}
+		if l.IndexOf(4) != -1 || l.LastIndexOf(4) != -1 {
+			t.Error("expected index -1 for a missing value")
+		}
+	})
+
+	t.Run("Collection", func(t *testing.T) {
+		l := newList()
+		if !l.AddAll(wrap.OrderedValues(1, 2, 3, 4)) || !l.AddIterator(wrap.ValueIterator(5, 6, 7, 8)) {
+			t.Error("expected adding values to change the list")
+		}
+		if l.AddAll(wrap.OrderedValues[int]()) {
+			t.Error("expected adding no values not to change the list")
+		}
+		if !l.Contains(5) || l.Contains(9) {
+			t.Error("expected list to contain 5 but not 9")
+		}
+		if !l.ContainsAll(wrap.OrderedValues(1, 8)) || l.ContainsIterator(wrap.ValueIterator(1, 9)) {
+			t.Error("expected list to contain all of 1 and 8 but not all of 1 and 9")
+		}
+
+		if !l.Remove(1) || l.Remove(9) {
+			t.Error("unexpected result from Remove")
+		}
+		l.RemoveAll(wrap.OrderedValues(2, 4))
+		l.RemoveIterator(wrap.ValueIterator(6))
+		expectValues(t, l, 3, 5, 7, 8)
+
+		if !l.RetainAll(wrap.OrderedValues(3, 7, 8, 9)) {
+			t.Error("expected retaining values to change the list")
+		}
+		expectValues(t, l, 3, 7, 8)
+		if l.RetainAll(wrap.OrderedValues(3, 7, 8)) {
+			t.Error("expected retaining all values not to change the list")
+		}
+
+		l.Clear()
+		if !l.IsEmpty() || l.Size() != 0 {
+			t.Error("expected cleared list to be empty, got size", l.Size())
+		}
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		l := newList()
+		l.AddAll(wrap.OrderedValues(3, 1, 4, 1, 5, 9, 2, 6))
+		l.Sort(structs.LessOrdered[int])
+		expectValues(t, l, 1, 1, 2, 3, 4, 5, 6, 9)
+	})
+
+	t.Run("SubList", func(t *testing.T) {
+		l := newList()
+		l.AddAll(wrap.OrderedValues(0, 1, 2, 3, 4, 5))
+
+		sub := l.SubList(1, 4)
+		expectValues(t, sub, 1, 2, 3)
+		sub.Set(0, 10)
+		sub.AddAt(1, 15)
+		sub.Add(35)
+		sub.RemoveAt(2)
+		expectValues(t, sub, 10, 15, 3, 35)
+		expectValues(t, l, 0, 10, 15, 3, 35, 4, 5)
+
+		sub.SubList(1, 3).Clear()
+		expectValues(t, sub, 10, 35)
+		sub.Clear()
+		expectValues(t, l, 0, 4, 5)
+
+		sub = l.SubList(1, 2)
+		l.Add(6)
+		ExpectPanic(t, structs.PanicConcurrentModification, func() { sub.Get(0) })
+		ExpectPanic(t, structs.PanicConcurrentModification, func() { sub.Sort(structs.LessOrdered[int]) })
+	})
+
+	t.Run("IteratorRemove", func(t *testing.T) {
+		l := newList()
+		for i := 0; i < 10; i++ {
+			l.Add(i)
+		}
+		it := l.Iterator()
+		for it.HasNext() {
+			if it.Next()%3 != 0 {
+				it.Remove()
+			}
+		}
+		expectValues(t, l, 0, 3, 6, 9)
+	})
+
+	t.Run("ConcurrentModification", func(t *testing.T) {
+		l := newList()
+		l.AddAll(wrap.OrderedValues(1, 2, 3))
+		it := l.Iterator()
+		it.Next()
+		l.AddAt(0, 0)
+		ExpectPanic(t, structs.PanicConcurrentModification, func() { it.Next() })
+	})
+
+	t.Run("Bounds", func(t *testing.T) {
+		l := newList()
+		l.AddAll(wrap.OrderedValues(1, 2, 3))
+
+		panics := map[string]func(){
+			"Get":          func() { l.Get(3) },
+			"GetNegative":  func() { l.Get(-1) },
+			"Set":          func() { l.Set(3, 0) },
+			"AddAt":        func() { l.AddAt(4, 0) },
+			"RemoveAt":     func() { l.RemoveAt(3) },
+			"SubList":      func() { l.SubList(2, 4) },
+			"SubListOrder": func() { l.SubList(2, 1) },
+		}
+		for name, f := range panics {
+			t.Run(name, func(t *testing.T) {
+				ExpectPanic(t, structs.PanicIndexOutOfBounds, f)
+			})
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		// compare against a slice after many random operations
+		l := newList()
+		var model []int
+		for i := 0; i < 2048; i++ {
+			switch rand.Intn(6) {
+			case 0, 1:
+				index := rand.Intn(len(model) + 1)
+				l.AddAt(index, i)
+				model = slices.Insert(model, index, i)
+			case 2:
+				l.Add(i)
+				model = append(model, i)
+			case 3:
+				if len(model) == 0 {
+					continue
+				}
+				index := rand.Intn(len(model))
+				if got := l.RemoveAt(index); got != model[index] {
+					t.Fatalf("expected %d from RemoveAt(%d) but got %d", model[index], index, got)
+				}
+				model = slices.Delete(model, index, index+1)
+			case 4:
+				if len(model) == 0 {
+					continue
+				}
+				index := rand.Intn(len(model))
+				l.Set(index, i)
+				model[index] = i
+			case 5:
+				if len(model) == 0 {
+					continue
+				}
+				index := rand.Intn(len(model))
+				if got := l.Get(index); got != model[index] {
+					t.Fatalf("expected %d from Get(%d) but got %d", model[index], index, got)
+				}
+			}
+		}
+		expectValues(t, l, model...)
+	})
+}
+
+// expectValues checks the values of the list using Values and Iterator.
+func expectValues(t *testing.T, l structs.List[int], expect ...int) {
+	t.Helper()
+
+	if got := l.Values(); !slices.Equal(got, expect) {
+		t.Fatalf("expected values %v but got %v", expect, got)
+	}
+	if l.Size() != len(expect) {
+		t.Fatalf("expected size %d but got %d", len(expect), l.Size())
+	}
+
+	var got []int
+	it := l.Iterator()
+	for it.HasNext() {
+		got = append(got, it.Next())
+	}
+	if !slices.Equal(got, expect) {
+		t.Fatalf("expected iterated values %v but got %v", expect, got)
+	}
+}
@@ -0,0 +1,349 @@
+// Package listview implements live SubList views for implementations
+// of structs.List which support efficient access by index, such as
+// arraylist.ArrayList and unrolledlist.UnrolledList.
+//
+// list.List has its own SubList view rather than using this package,
+// since it accesses values by index in O(n). Its view finds the node
+// at the start of its range once and walks the list's nodes from there,
+// which a View cannot do through a Backing.
+package listview
+
+import (
+	"github.com/zytekaron/structs"
+	"golang.org/x/exp/slices"
+)
+
+// Backing provides a View with access to the list it views.
+type Backing[V any] struct {
+	Equal    structs.EqualFunc[V]
+	Get      func(index int) V
+	Set      func(index int, value V) V
+	AddAt    func(index int, value V)
+	RemoveAt func(index int) V
+	// ModCount returns a count which changes whenever
+	// the list is structurally modified.
+	ModCount func() int
+
+	// RemoveRange optionally removes the values between the from index,
+	// inclusive, and the to index, exclusive, faster than removing
+	// them one at a time. It is used by View.Clear.
+	RemoveRange func(from, to int)
+	// Sort optionally sorts the values between the from index, inclusive,
+	// and the to index, exclusive, in place. It is used by View.Sort.
+	Sort func(from, to int, cmp structs.LessFunc[V])
+}
+
+// View is a view of a range of a list. Changes made through the view
+// are made to the range of the backing list, and changes to values
+// made through the backing list are visible in the view.
+//
+// If the backing list is structurally modified other than through
+// the view, the view becomes invalid and most of its methods panic
+// with structs.PanicConcurrentModification.
+type View[V any] struct {
+	backing *Backing[V]
+	parent  *View[V] // the view this view was created from, or nil
+	offset  int      // index in the backing list of the first value
+	size    int
+
+	expectedModCount int
+}
+
+var _ structs.List[int] = (*View[int])(nil)
+
+// New creates a view of a list of the size provided between the
+// from index, inclusive, and the to index, exclusive.
+//
+// Panics if the range is out of bounds.
+func New[V any](backing *Backing[V], size, from, to int) *View[V] {
+	checkRange(from, to, size)
+
+	return &View[V]{
+		backing:          backing,
+		offset:           from,
+		size:             to - from,
+		expectedModCount: backing.ModCount(),
+	}
+}
+
+func (v *View[V]) Add(value V) bool {
+	v.AddAt(v.size, value)
+	return true
+}
+
+func (v *View[V]) AddAll(other structs.Collection[V]) bool {
+	return v.AddIterator(other.Iterator())
+}
+
+func (v *View[V]) AddAt(index int, value V) {
+	v.checkModCount()
+	v.checkBounds(index, true)
+
+	v.backing.AddAt(v.offset+index, value)
+	v.updateSize(1)
+}
+
+func (v *View[V]) AddIterator(iter structs.Iterator[V]) bool {
+	changed := iter.HasNext()
+	for iter.HasNext() {
+		v.Add(iter.Next())
+	}
+	return changed
+}
+
+func (v *View[V]) Clear() {
+	v.checkModCount()
+
+	if v.backing.RemoveRange != nil {
+		v.backing.RemoveRange(v.offset, v.offset+v.size)
+	} else {
+		for i := v.size - 1; i >= 0; i-- {
+			v.backing.RemoveAt(v.offset + i)
+		}
+	}
+	v.updateSize(-v.size)
+}
+
+func (v *View[V]) Contains(value V) bool {
+	return v.IndexOf(value) >= 0
+}
+
+func (v *View[V]) ContainsAll(other structs.Collection[V]) bool {
+	return v.ContainsIterator(other.Iterator())
+}
+
+func (v *View[V]) ContainsIterator(iter structs.Iterator[V]) bool {
+	for iter.HasNext() {
+		if !v.Contains(iter.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *View[V]) Get(index int) V {
+	v.checkModCount()
+	v.checkBounds(index, false)
+
+	return v.backing.Get(v.offset + index)
+}
+
+func (v *View[V]) IndexOf(value V) int {
+	v.checkModCount()
+
+	for i := 0; i < v.size; i++ {
+		if v.backing.Equal(v.backing.Get(v.offset+i), value) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (v *View[V]) IsEmpty() bool {
+	v.checkModCount()
+
+	return v.size == 0
+}
+
+func (v *View[V]) Iterator() structs.Iterator[V] {
+	v.checkModCount()
+
+	return &Iterator[V]{
+		view:             v,
+		last:             -1,
+		expectedModCount: v.expectedModCount,
+	}
+}
+
+func (v *View[V]) LastIndexOf(value V) int {
+	v.checkModCount()
+
+	for i := v.size - 1; i >= 0; i-- {
+		if v.backing.Equal(v.backing.Get(v.offset+i), value) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (v *View[V]) Remove(value V) bool {
+	i := v.IndexOf(value)
+	if i < 0 {
+		return false
+	}
+	v.backing.RemoveAt(v.offset + i)
+	v.updateSize(-1)
+	return true
+}
+
+func (v *View[V]) RemoveAll(other structs.Collection[V]) bool {
+	return v.RemoveIterator(other.Iterator())
+}
+
+func (v *View[V]) RemoveAt(index int) V {
+	v.checkModCount()
+	v.checkBounds(index, false)
+
+	value := v.backing.RemoveAt(v.offset + index)
+	v.updateSize(-1)
+	return value
+}
+
+func (v *View[V]) RemoveIterator(iter structs.Iterator[V]) bool {
+	changed := false
+	for iter.HasNext() {
+		if v.Remove(iter.Next()) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (v *View[V]) RetainAll(other structs.Collection[V]) bool {
+	v.checkModCount()
+
+	removed := 0
+	for i := v.size - 1; i >= 0; i-- {
+		if !other.Contains(v.backing.Get(v.offset + i)) {
+			v.backing.RemoveAt(v.offset + i)
+			removed++
+		}
+	}
+	if removed == 0 {
+		return false
+	}
+	v.updateSize(-removed)
+	return true
+}
+
+func (v *View[V]) Set(index int, value V) V {
+	v.checkModCount()
+	v.checkBounds(index, false)
+
+	return v.backing.Set(v.offset+index, value)
+}
+
+func (v *View[V]) Size() int {
+	v.checkModCount()
+
+	return v.size
+}
+
+// Sort sorts the values in the view based on a comparator,
+// leaving the rest of the backing list unchanged.
+func (v *View[V]) Sort(cmp structs.LessFunc[V]) {
+	v.checkModCount()
+
+	if v.backing.Sort != nil {
+		v.backing.Sort(v.offset, v.offset+v.size, cmp)
+		return
+	}
+	sorted := v.Values()
+	slices.SortFunc(sorted, cmp)
+	for i, value := range sorted {
+		v.backing.Set(v.offset+i, value)
+	}
+}
+
+func (v *View[V]) SubList(from, to int) structs.List[V] {
+	v.checkModCount()
+	checkRange(from, to, v.size)
+
+	return &View[V]{
+		backing:          v.backing,
+		parent:           v,
+		offset:           v.offset + from,
+		size:             to - from,
+		expectedModCount: v.expectedModCount,
+	}
+}
+
+func (v *View[V]) Values() []V {
+	v.checkModCount()
+
+	values := make([]V, v.size)
+	for i := range values {
+		values[i] = v.backing.Get(v.offset + i)
+	}
+	return values
+}
+
+// updateSize adjusts the size of the view and the views it was created
+// from after it is structurally modified, and records the backing list's
+// new modCount so the modification isn't mistaken for a concurrent one.
+func (v *View[V]) updateSize(delta int) {
+	modCount := v.backing.ModCount()
+	for view := v; view != nil; view = view.parent {
+		view.size += delta
+		view.expectedModCount = modCount
+	}
+}
+
+// checkModCount panics if the backing list was
+// structurally modified other than through the view.
+func (v *View[V]) checkModCount() {
+	if v.backing.ModCount() != v.expectedModCount {
+		panic(structs.PanicConcurrentModification)
+	}
+}
+
+// checkBounds checks whether an index is within the bounds of the view.
+//
+// if allowEnd is true, index == v.size is allowed.
+func (v *View[V]) checkBounds(index int, allowEnd bool) {
+	if index < 0 || index > v.size || (!allowEnd && index == v.size) {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+}
+
+// checkRange panics if the range from:to is not within a list of the size.
+func checkRange(from, to, size int) {
+	if from < 0 || to > size || from > to {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+}
+
+// Iterator is an iterator over the values of a View.
+type Iterator[V any] struct {
+	view   *View[V]
+	cursor int // index in the view of the value Next would return
+	last   int // index in the view of the last value returned, or -1
+
+	expectedModCount int
+}
+
+func (it *Iterator[V]) HasNext() bool {
+	return it.cursor < it.view.size
+}
+
+func (it *Iterator[V]) Next() V {
+	it.checkModCount()
+	if !it.HasNext() {
+		panic(structs.PanicNoSuchElement)
+	}
+
+	it.last = it.cursor
+	it.cursor++
+	return it.view.backing.Get(it.view.offset + it.last)
+}
+
+func (it *Iterator[V]) Remove() {
+	it.checkModCount()
+	if it.last < 0 {
+		panic(structs.PanicIllegalState)
+	}
+
+	it.view.backing.RemoveAt(it.view.offset + it.last)
+	it.view.updateSize(-1)
+	it.cursor = it.last
+	it.last = -1
+	it.expectedModCount = it.view.backing.ModCount()
+}
+
+// checkModCount panics if the backing list was modified
+// other than through this iterator.
+func (it *Iterator[V]) checkModCount() {
+	if it.view.backing.ModCount() != it.expectedModCount {
+		panic(structs.PanicConcurrentModification)
+	}
+}
@@ -0,0 +1,207 @@
+package list
+
+import "github.com/zytekaron/structs"
+
+// Element is a handle to a value in a List, which allows the value
+// to be moved, removed, or have values inserted next to it in O(1),
+// without searching the list using the value equality function.
+//
+// An Element remains valid until it is removed from its list, either
+// with RemoveElement or any other method which removes its value.
+type Element[V any] struct {
+	// Value is the value stored in the element.
+	Value V
+
+	owner *owner // identifies the list the element belongs to, or nil once removed
+	prev  *Element[V]
+	next  *Element[V]
+}
+
+// owner identifies the list which elements belong to. When all the
+// elements of one list are moved to another, the first list's owner
+// is forwarded to the second list's owner by setting its parent, so
+// the elements needn't be updated individually.
+type owner struct {
+	parent *owner
+}
+
+// root returns the owner which this owner has been forwarded to,
+// compressing the path to it so later lookups are faster.
+func (o *owner) root() *owner {
+	for o.parent != nil {
+		if o.parent.parent != nil {
+			o.parent = o.parent.parent
+		}
+		o = o.parent
+	}
+	return o
+}
+
+func newNode[V any](value V) *Element[V] {
+	return &Element[V]{Value: value}
+}
+
+// Next returns the next element in the list, or nil if
+// the element is the last element or has been removed.
+func (e *Element[V]) Next() *Element[V] {
+	return e.next
+}
+
+// Prev returns the previous element in the list, or nil if
+// the element is the first element or has been removed.
+func (e *Element[V]) Prev() *Element[V] {
+	return e.prev
+}
+
+// FirstElement returns the first element in the list, or nil if the list is empty.
+//
+// Time Complexity: O(1)
+func (l *List[V]) FirstElement() *Element[V] {
+	return l.head
+}
+
+// LastElement returns the last element in the list, or nil if the list is empty.
+//
+// Time Complexity: O(1)
+func (l *List[V]) LastElement() *Element[V] {
+	return l.tail
+}
+
+// AddFirstElement adds a value to the front of the list and returns its element.
+//
+// Time Complexity: O(1)
+func (l *List[V]) AddFirstElement(value V) *Element[V] {
+	node := newNode(value)
+	l.addFirstNode(node)
+	return node
+}
+
+// AddLastElement adds a value to the end of the list and returns its element.
+//
+// Time Complexity: O(1)
+func (l *List[V]) AddLastElement(value V) *Element[V] {
+	node := newNode(value)
+	l.addLastNode(node)
+	return node
+}
+
+// InsertAfterElement inserts a value after the mark element and returns its element.
+//
+// Panics if the mark element is not in the list.
+//
+// Time Complexity: O(1)
+func (l *List[V]) InsertAfterElement(value V, mark *Element[V]) *Element[V] {
+	l.checkElement(mark)
+
+	return l.insertAfterNode(mark, value)
+}
+
+// InsertBeforeElement inserts a value before the mark element and returns its element.
+//
+// Panics if the mark element is not in the list.
+//
+// Time Complexity: O(1)
+func (l *List[V]) InsertBeforeElement(value V, mark *Element[V]) *Element[V] {
+	l.checkElement(mark)
+
+	return l.insertBeforeNode(mark, value)
+}
+
+// MoveToFront moves the element to the front of the list.
+//
+// Panics if the element is not in the list.
+//
+// Time Complexity: O(1)
+func (l *List[V]) MoveToFront(e *Element[V]) {
+	l.checkElement(e)
+
+	if l.head == e {
+		return
+	}
+	l.removeNode(e)
+	l.addFirstNode(e)
+}
+
+// MoveToBack moves the element to the end of the list.
+//
+// Panics if the element is not in the list.
+//
+// Time Complexity: O(1)
+func (l *List[V]) MoveToBack(e *Element[V]) {
+	l.checkElement(e)
+
+	if l.tail == e {
+		return
+	}
+	l.removeNode(e)
+	l.addLastNode(e)
+}
+
+// MoveBefore moves the element to just before the mark element.
+// If the element and the mark element are the same, nothing happens.
+//
+// Panics if either element is not in the list.
+//
+// Time Complexity: O(1)
+func (l *List[V]) MoveBefore(e, mark *Element[V]) {
+	l.checkElement(e)
+	l.checkElement(mark)
+
+	if e == mark || e.next == mark {
+		return
+	}
+	l.removeNode(e)
+	l.linkBeforeNode(mark, e)
+}
+
+// MoveAfter moves the element to just after the mark element.
+// If the element and the mark element are the same, nothing happens.
+//
+// Panics if either element is not in the list.
+//
+// Time Complexity: O(1)
+func (l *List[V]) MoveAfter(e, mark *Element[V]) {
+	l.checkElement(e)
+	l.checkElement(mark)
+
+	if e == mark || e.prev == mark {
+		return
+	}
+	l.removeNode(e)
+	l.linkAfterNode(mark, e)
+}
+
+// RemoveElement removes the element from the list and returns its value.
+//
+// Panics if the element is not in the list.
+//
+// Time Complexity: O(1)
+func (l *List[V]) RemoveElement(e *Element[V]) V {
+	l.checkElement(e)
+
+	return l.removeNode(e)
+}
+
+// checkElement panics if the element is not in the list.
+func (l *List[V]) checkElement(e *Element[V]) {
+	if e == nil || e.owner == nil || e.owner.root() != l.token() {
+		panic(structs.PanicNoSuchElement)
+	}
+}
+
+// token returns the owner of the list's elements, creating it if needed.
+func (l *List[V]) token() *owner {
+	if l.owner == nil {
+		l.owner = &owner{}
+	}
+	return l.owner
+}
+
+// adopt makes all the other list's elements belong to this list.
+// The other list creates a new owner when it next needs one.
+func (l *List[V]) adopt(other *List[V]) {
+	if other.owner != nil {
+		other.owner.parent = l.token()
+		other.owner = nil
+	}
+}
@@ -0,0 +1,72 @@
+package list
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/listtest"
+	"testing"
+)
+
+func TestList_Elements(t *testing.T) {
+	l := NewOrdered[int]()
+	two := l.AddLastElement(2)
+	one := l.AddFirstElement(1)
+	four := l.AddLastElement(4)
+	three := l.InsertBeforeElement(3, four)
+	five := l.InsertAfterElement(5, four)
+	listtest.ExpectValues(t, l.Values(), []int{1, 2, 3, 4, 5})
+	listtest.ExpectValues(t, l.ValuesReverse(), []int{5, 4, 3, 2, 1})
+
+	if l.FirstElement() != one || l.LastElement() != five {
+		t.Error("expected first and last elements to be 1 and 5")
+	}
+	if one.Next() != two || two.Prev() != one || one.Prev() != nil || five.Next() != nil {
+		t.Error("unexpected element links")
+	}
+
+	l.MoveToFront(three)
+	listtest.ExpectValues(t, l.Values(), []int{3, 1, 2, 4, 5})
+	l.MoveToBack(one)
+	listtest.ExpectValues(t, l.Values(), []int{3, 2, 4, 5, 1})
+	l.MoveBefore(five, three)
+	listtest.ExpectValues(t, l.Values(), []int{5, 3, 2, 4, 1})
+	l.MoveAfter(three, one)
+	listtest.ExpectValues(t, l.Values(), []int{5, 2, 4, 1, 3})
+	l.MoveAfter(two, two)
+	l.MoveBefore(two, four)
+	listtest.ExpectValues(t, l.Values(), []int{5, 2, 4, 1, 3})
+	listtest.ExpectValues(t, l.ValuesReverse(), []int{3, 1, 4, 2, 5})
+	if l.FirstElement() != five || l.LastElement() != three {
+		t.Error("expected first and last elements to be 5 and 3")
+	}
+
+	if got := l.RemoveElement(four); got != 4 {
+		t.Errorf("expected removed value 4 but got %d", got)
+	}
+	if four.Next() != nil || four.Prev() != nil {
+		t.Error("expected removed element to be unlinked")
+	}
+	listtest.ExpectValues(t, l.Values(), []int{5, 2, 1, 3})
+	if l.Size() != 4 {
+		t.Errorf("expected size 4 but got %d", l.Size())
+	}
+
+	// elements of values removed by other methods are invalidated too
+	l.Remove(2)
+	listtest.ExpectPanic(t, structs.PanicNoSuchElement, func() { l.MoveToFront(two) })
+	listtest.ExpectPanic(t, structs.PanicNoSuchElement, func() { l.RemoveElement(four) })
+
+	other := OfOrdered(1)
+	listtest.ExpectPanic(t, structs.PanicNoSuchElement, func() { other.InsertAfterElement(0, one) })
+	listtest.ExpectPanic(t, structs.PanicNoSuchElement, func() { l.MoveAfter(one, other.FirstElement()) })
+
+	l.Clear()
+	listtest.ExpectPanic(t, structs.PanicNoSuchElement, func() { l.RemoveElement(one) })
+}
+
+func TestList_ElementsConcurrentModification(t *testing.T) {
+	l := OfOrdered(1, 2, 3)
+	it := l.Iterator()
+	it.Next()
+	l.MoveToBack(l.FirstElement())
+	listtest.ExpectPanic(t, structs.PanicConcurrentModification, func() { it.Next() })
+}
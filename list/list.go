@@ -5,7 +5,6 @@ import (
 	"github.com/zytekaron/structs"
 	"github.com/zytekaron/structs/wrap"
 	"golang.org/x/exp/constraints"
-	"golang.org/x/exp/slices"
 	"strings"
 )
 
@@ -15,28 +14,13 @@ import (
 // if no methods are called which use it.
 type List[V any] struct {
 	eq   structs.EqualFunc[V]
-	head *listNode[V]
-	tail *listNode[V]
+	head *Element[V]
+	tail *Element[V]
 	size int
-}
-
-// listNode is a linked list listNode.
-type listNode[V any] struct {
-	Value V
-	Prev  *listNode[V]
-	Next  *listNode[V]
-}
-
-func newNode[V any](value V) *listNode[V] {
-	return &listNode[V]{Value: value}
-}
-
-func newFullNode[V any](value V, prev, next *listNode[V]) *listNode[V] {
-	return &listNode[V]{
-		Value: value,
-		Prev:  prev,
-		Next:  next,
-	}
+	// modCount is incremented whenever the list is structurally
+	// modified, which allows iterators to detect concurrent changes.
+	modCount int
+	owner    *owner // identifies the list's elements, created on first use
 }
 
 // New creates an empty List.
@@ -176,13 +160,23 @@ func (l *List[V]) AddIteratorAt(index int, iter structs.Iterator[V]) bool {
 	return changed
 }
 
-// Clear clears the list.
+// Clear clears the list. The list's elements are unlinked,
+// so elements obtained before clearing are no longer in the list.
 //
-// Time Complexity: O(1)
+// Time Complexity: O(n)
 func (l *List[V]) Clear() {
+	node := l.head
+	for node != nil {
+		next := node.next
+		node.owner = nil
+		node.prev = nil
+		node.next = nil
+		node = next
+	}
 	l.head = nil
 	l.tail = nil
 	l.size = 0
+	l.modCount++
 }
 
 // Contains returns whether the value is present the list.
@@ -217,6 +211,15 @@ func (l *List[V]) ContainsIterator(iter structs.Iterator[V]) bool {
 	return true
 }
 
+// Element returns the value at the front of the list.
+//
+// Panics if the list is empty.
+//
+// Time Complexity: O(1)
+func (l *List[V]) Element() V {
+	return l.GetFirst()
+}
+
 // Get returns the value at the specified index.
 //
 // Panics if the index is out of bounds.
@@ -258,7 +261,7 @@ func (l *List[V]) IndexOf(value V) int {
 			return index
 		}
 		index++
-		node = node.Next
+		node = node.next
 	}
 	return -1
 }
@@ -275,25 +278,33 @@ func (l *List[V]) LastIndexOf(value V) int {
 			return index
 		}
 		index--
-		node = node.Prev
+		node = node.prev
 	}
 	return -1
 }
 
-// InsertAfter inserts a value after the first occurrence of an existing value in the list.
+// InsertAfter inserts a value after the first occurrence of an existing
+// value in the list and returns whether the existing value was present.
 //
 // Time Complexity: O(n)
 func (l *List[V]) InsertAfter(before, value V) bool {
 	target := l.findNode(before)
+	if target == nil {
+		return false
+	}
 	l.insertAfterNode(target, value)
 	return true
 }
 
-// InsertBefore inserts a value before the first occurrence of an existing value in the list.
+// InsertBefore inserts a value before the first occurrence of an existing
+// value in the list and returns whether the existing value was present.
 //
 // Time Complexity: O(n)
 func (l *List[V]) InsertBefore(before, value V) bool {
 	target := l.findNode(before)
+	if target == nil {
+		return false
+	}
 	l.insertBeforeNode(target, value)
 	return true
 }
@@ -306,8 +317,9 @@ func (l *List[V]) IsEmpty() bool {
 // Iterator returns an Iterator for the list.
 func (l *List[V]) Iterator() structs.Iterator[V] {
 	return &Iterator[V]{
-		list: l,
-		next: l.head,
+		list:             l,
+		next:             l.head,
+		expectedModCount: l.modCount,
 	}
 }
 
@@ -315,9 +327,10 @@ func (l *List[V]) Iterator() structs.Iterator[V] {
 func (l *List[V]) DescendingIterator() structs.Iterator[V] {
 	return &DescendingIterator[V]{
 		iter: &Iterator[V]{
-			list:  l,
-			prev:  l.tail,
-			index: l.size,
+			list:             l,
+			prev:             l.tail,
+			index:            l.size,
+			expectedModCount: l.modCount,
 		},
 	}
 }
@@ -540,11 +553,12 @@ func (l *List[V]) RetainAll(other structs.Collection[V]) bool {
 	changed := false
 	node := l.head
 	for node != nil {
+		next := node.next
 		if !other.Contains(node.Value) {
 			l.removeNode(node)
 			changed = true
 		}
-		node = node.Next
+		node = next
 	}
 	return changed
 }
@@ -557,11 +571,12 @@ func (l *List[V]) Reverse() {
 		return
 	}
 	l.head, l.tail = l.tail, l.head
+	l.modCount++
 	node := l.head
 	for node != nil {
-		prev := node.Prev
-		node.Prev = node.Next
-		node.Next = prev
+		prev := node.prev
+		node.prev = node.next
+		node.next = prev
 		node = prev
 	}
 }
@@ -580,22 +595,53 @@ func (l *List[V]) Set(index int, value V) V {
 	return oldValue
 }
 
-// Sort sorts the list based on a comparator.
+// Sort sorts the list based on a comparator. The sort is stable,
+// so equal values keep their relative order.
 //
-// This method extracts an array using Values and reconstructs
-// the entire list from scratch. If the list cannot be fully
-// represented as an array, this method won't work.
+// The list is sorted in place by relinking its existing nodes
+// with a bottom-up merge sort, so no values are copied.
 //
-// Time Complexity: best case O(nlogn), worst case O(n^2).
-// See slices.SortFunc (algorithm: pattern-defeating quicksort).
+// Time Complexity: O(nlogn)
+//
+// Space Complexity: O(1)
 func (l *List[V]) Sort(cmp structs.LessFunc[V]) {
-	sorted := l.Values()
-	slices.SortFunc(sorted, cmp)
+	l.mergeSort(cmp)
+}
+
+// SortStable sorts the list based on a comparator, keeping
+// equal values in their relative order. It is equivalent to Sort.
+//
+// Time Complexity: O(nlogn)
+//
+// Space Complexity: O(1)
+func (l *List[V]) SortStable(cmp structs.LessFunc[V]) {
+	l.mergeSort(cmp)
+}
+
+// SortCompare sorts the list based on a comparison function.
+// The sort is stable, so equal values keep their relative order.
+//
+// Time Complexity: O(nlogn)
+//
+// Space Complexity: O(1)
+func (l *List[V]) SortCompare(cmp structs.CompareFunc[V]) {
+	l.mergeSort(func(a, b V) bool {
+		return cmp(a, b) < 0
+	})
+}
 
-	l.Clear()
-	for _, value := range sorted {
-		l.Add(value)
+// IsSorted returns whether the list is sorted based on a comparator.
+//
+// Time Complexity: O(n)
+func (l *List[V]) IsSorted(cmp structs.LessFunc[V]) bool {
+	node := l.head
+	for node != nil && node.next != nil {
+		if cmp(node.next.Value, node.Value) {
+			return false
+		}
+		node = node.next
 	}
+	return true
 }
 
 // Size returns the length of the list.
@@ -615,7 +661,7 @@ func (l *List[V]) Values() []V {
 	node := l.head
 	for node != nil {
 		values = append(values, node.Value)
-		node = node.Next
+		node = node.next
 	}
 	return values
 }
@@ -630,7 +676,7 @@ func (l *List[V]) ValuesReverse() []V {
 	node := l.tail
 	for node != nil {
 		values = append(values, node.Value)
-		node = node.Prev
+		node = node.prev
 	}
 	return values
 }
@@ -645,7 +691,7 @@ func (l *List[V]) Clone() *List[V] {
 	node := l.head
 	for node != nil {
 		list.Add(node.Value)
-		node = node.Next
+		node = node.next
 	}
 	return list
 }
@@ -660,7 +706,7 @@ func (l *List[V]) CloneReverse() *List[V] {
 	node := l.tail
 	for node != nil {
 		list.Add(node.Value)
-		node = node.Prev
+		node = node.prev
 	}
 	return list
 }
@@ -670,7 +716,7 @@ func (l *List[V]) Each(f func(value V)) {
 	node := l.head
 	for node != nil {
 		f(node.Value)
-		node = node.Next
+		node = node.next
 	}
 }
 
@@ -679,7 +725,7 @@ func (l *List[V]) EachReverse(f func(value V)) {
 	node := l.tail
 	for node != nil {
 		f(node.Value)
-		node = node.Prev
+		node = node.prev
 	}
 }
 
@@ -694,8 +740,8 @@ func (l *List[V]) String() string {
 	buf.WriteString(fmt.Sprint(l.head.Value))
 
 	node := l.head
-	for node.Next != nil {
-		node = node.Next
+	for node.next != nil {
+		node = node.next
 		buf.WriteString(", ")
 		buf.WriteString(fmt.Sprint(node.Value))
 	}
@@ -704,92 +750,185 @@ func (l *List[V]) String() string {
 	return buf.String()
 }
 
-// addFirstNode adds a listNode to the front of the list.
+// addFirstNode adds a node to the front of the list.
 //
 // Time Complexity: O(1)
-func (l *List[V]) addFirstNode(node *listNode[V]) {
-	node.Prev = nil
+func (l *List[V]) addFirstNode(node *Element[V]) {
+	node.owner = l.token()
+	node.prev = nil
 	if l.size == 0 {
-		node.Next = nil
+		node.next = nil
 		l.head = node
 		l.tail = node
 	} else {
-		node.Next = l.head
-		l.head.Prev = node
+		node.next = l.head
+		l.head.prev = node
 		l.head = node
 	}
 	l.size++
+	l.modCount++
 }
 
-// addLastNode adds a listNode to the end of the list.
+// addLastNode adds a node to the end of the list.
 //
 // Time Complexity: O(1)
-func (l *List[V]) addLastNode(node *listNode[V]) {
-	node.Next = nil
+func (l *List[V]) addLastNode(node *Element[V]) {
+	node.owner = l.token()
+	node.next = nil
 	if l.size == 0 {
-		node.Prev = nil
+		node.prev = nil
 		l.head = node
 		l.tail = node
 	} else {
-		node.Prev = l.tail
-		l.tail.Next = node
+		node.prev = l.tail
+		l.tail.next = node
 		l.tail = node
 	}
 	l.size++
+	l.modCount++
+}
+
+// insertAfterNode inserts a value after an existing node in the list.
+//
+// Time Complexity: O(1)
+func (l *List[V]) insertAfterNode(target *Element[V], value V) *Element[V] {
+	node := newNode(value)
+	l.linkAfterNode(target, node)
+	return node
+}
+
+// insertBeforeNode inserts a value before an existing node in the list.
+//
+// Time Complexity: O(1)
+func (l *List[V]) insertBeforeNode(target *Element[V], value V) *Element[V] {
+	node := newNode(value)
+	l.linkBeforeNode(target, node)
+	return node
 }
 
-// insertAfterNode inserts a listNode after an existing listNode in the list.
+// linkAfterNode links a node which is not in a list after an existing node in the list.
 //
 // Time Complexity: O(1)
-func (l *List[V]) insertAfterNode(target *listNode[V], value V) *listNode[V] {
-	node := newFullNode(value, target, target.Next)
-	if target.Next != nil {
-		target.Next.Prev = node
+func (l *List[V]) linkAfterNode(target, node *Element[V]) {
+	node.owner = l.token()
+	node.prev = target
+	node.next = target.next
+	if target.next != nil {
+		target.next.prev = node
 	}
-	target.Next = node
+	target.next = node
 	if target == l.tail {
 		l.tail = node
 	}
 	l.size++
-	return node
+	l.modCount++
 }
 
-// insertBeforeNode inserts a listNode before an existing listNode in the list.
+// linkBeforeNode links a node which is not in a list before an existing node in the list.
 //
 // Time Complexity: O(1)
-func (l *List[V]) insertBeforeNode(target *listNode[V], value V) *listNode[V] {
-	node := newFullNode(value, target.Prev, target)
-	if target.Prev != nil {
-		target.Prev.Next = node
+func (l *List[V]) linkBeforeNode(target, node *Element[V]) {
+	node.owner = l.token()
+	node.prev = target.prev
+	node.next = target
+	if target.prev != nil {
+		target.prev.next = node
 	}
-	target.Prev = node
+	target.prev = node
 	if target == l.head {
 		l.head = node
 	}
 	l.size++
-	return node
+	l.modCount++
 }
 
-// getNodeAt returns the listNode at the specified index.
+// mergeSort sorts the list in place with a stable bottom-up merge sort,
+// merging runs of width 1, 2, 4, ... by relinking nodes until a single
+// run remains.
+//
+// Time Complexity: O(nlogn)
+//
+// Space Complexity: O(1)
+func (l *List[V]) mergeSort(less structs.LessFunc[V]) {
+	if l.size < 2 {
+		return
+	}
+	l.modCount++
+
+	head := l.head
+	for width := 1; ; width *= 2 {
+		var first, last *Element[V]
+		merges := 0
+
+		left := head
+		for left != nil {
+			merges++
+
+			// the right run starts width nodes after the left run
+			right := left
+			leftSize := 0
+			for leftSize < width && right != nil {
+				leftSize++
+				right = right.next
+			}
+			rightSize := width
+
+			for leftSize > 0 || rightSize > 0 && right != nil {
+				// take from the left run unless the right value is strictly
+				// less, so equal values keep their relative order
+				var node *Element[V]
+				if leftSize == 0 || rightSize > 0 && right != nil && less(right.Value, left.Value) {
+					node = right
+					right = right.next
+					rightSize--
+				} else {
+					node = left
+					left = left.next
+					leftSize--
+				}
+
+				if last == nil {
+					first = node
+				} else {
+					last.next = node
+				}
+				node.prev = last
+				last = node
+			}
+
+			left = right
+		}
+		last.next = nil
+		head = first
+
+		if merges == 1 {
+			l.head = first
+			l.tail = last
+			return
+		}
+	}
+}
+
+// getNodeAt returns the node at the specified index.
 //
 // bounds checking should be performed prior to calling.
 //
 // Time Complexity: O(n)
-func (l *List[V]) getNodeAt(index int) *listNode[V] {
+func (l *List[V]) getNodeAt(index int) *Element[V] {
 	l.checkBounds(index, false)
 
 	node := l.head
 	for index > 0 {
-		node = node.Next
+		node = node.next
 		index--
 	}
 	return node
 }
 
-// indexOfNode returns the index of a listNode in the list, otherwise -1.
+// indexOfNode returns the index of a node in the list, otherwise -1.
 //
 // Time Complexity: O(n)
-func (l *List[V]) indexOfNode(node *listNode[V]) int {
+func (l *List[V]) indexOfNode(node *Element[V]) int {
 	index := 0
 	tmp := l.head
 	for tmp != nil {
@@ -797,70 +936,72 @@ func (l *List[V]) indexOfNode(node *listNode[V]) int {
 			return index
 		}
 		index++
-		tmp = tmp.Next
+		tmp = tmp.next
 	}
 	return -1
 }
 
-// findNode attempts to find and return the first listNode with the specified value.
+// findNode attempts to find and return the first node with the specified value.
 //
 // Time Complexity: O(n)
-func (l *List[V]) findNode(value V) *listNode[V] {
+func (l *List[V]) findNode(value V) *Element[V] {
 	node := l.head
 	for node != nil {
 		if l.eq(node.Value, value) {
 			return node
 		}
-		node = node.Next
+		node = node.next
 	}
 	return nil
 }
 
-// findLastNode attempts to find and return the last listNode with the specified value.
+// findLastNode attempts to find and return the last node with the specified value.
 //
 // Time Complexity: O(n)
-func (l *List[V]) findLastNode(value V) *listNode[V] {
+func (l *List[V]) findLastNode(value V) *Element[V] {
 	node := l.tail
 	for node != nil {
 		if l.eq(node.Value, value) {
 			return node
 		}
-		node = node.Prev
+		node = node.prev
 	}
 	return nil
 }
 
-// removeNode removes a listNode from the list.
+// removeNode removes a node from the list. The node's links are
+// cleared, so it no longer refers to the list or its neighbors.
 //
 // Time Complexity: O(1)
-func (l *List[V]) removeNode(node *listNode[V]) V {
+func (l *List[V]) removeNode(node *Element[V]) V {
 	l.size--
-	if l.size == 0 {
+	l.modCount++
+	switch {
+	case l.size == 0:
 		l.head = nil
 		l.tail = nil
-		return node.Value
-	}
-	if l.head == node {
-		l.head = l.head.Next
-		node.Next.Prev = nil
-		return node.Value
-	}
-	if l.tail == node {
-		l.tail = l.tail.Prev
-		node.Prev.Next = nil
-		return node.Value
+	case l.head == node:
+		l.head = node.next
+		node.next.prev = nil
+	case l.tail == node:
+		l.tail = node.prev
+		node.prev.next = nil
+	default:
+		node.next.prev = node.prev
+		node.prev.next = node.next
 	}
-	node.Next.Prev = node.Prev
-	node.Prev.Next = node.Next
+	node.owner = nil
+	node.prev = nil
+	node.next = nil
 	return node.Value
 }
 
-// removeNodeAt removes the listNode at the specified index from the list and returns it.
+// removeNodeAt removes the node at the specified index from the list and returns it.
 //
 // bounds checking should be performed prior to calling.
 //
 // Time Complexity: O(n)
-func (l *List[V]) removeNodeAt(index int) *listNode[V] {
+func (l *List[V]) removeNodeAt(index int) *Element[V] {
 	node := l.getNodeAt(index)
 	l.removeNode(node)
 	return node
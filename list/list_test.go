@@ -2,8 +2,11 @@ package list
 
 import (
 	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/dequetest"
+	"github.com/zytekaron/structs/internal/listtest"
 	"github.com/zytekaron/structs/wrap"
 	"golang.org/x/exp/slices"
+	"math/rand"
 	"testing"
 )
 
@@ -632,6 +635,69 @@ func TestList_Sort(t *testing.T) {
 	}
 }
 
+func TestList_SortStable(t *testing.T) {
+	type pair struct{ key, order int }
+	less := func(a, b pair) bool { return a.key < b.key }
+
+	// sort lists of every size up to a few merge widths
+	rng := rand.New(rand.NewSource(1))
+	for size := 0; size <= 70; size++ {
+		values := make([]pair, size)
+		for i := range values {
+			values[i] = pair{key: rng.Intn(8), order: i}
+		}
+		l := Of(nil, values...)
+		nodes := make(map[*Element[pair]]bool)
+		for node := l.head; node != nil; node = node.next {
+			nodes[node] = true
+		}
+
+		l.SortStable(less)
+		slices.SortStableFunc(values, less)
+		if got := l.Values(); !slices.Equal(got, values) {
+			t.Fatalf("expected list %v but got %v", values, got)
+		}
+		// the backward links and tail must match the forward links
+		reversed := l.ValuesReverse()
+		for i, value := range reversed {
+			if value != values[size-1-i] {
+				t.Fatalf("expected reversed list to mirror %v but got %v", values, reversed)
+			}
+		}
+		// the list is sorted by relinking its nodes, not reallocating them
+		for node := l.head; node != nil; node = node.next {
+			if !nodes[node] {
+				t.Fatal("expected sort to reuse the existing nodes")
+			}
+		}
+		if l.Size() != size || !l.IsSorted(less) {
+			t.Fatalf("expected sorted list of size %d", size)
+		}
+	}
+}
+
+func TestList_SortCompare(t *testing.T) {
+	l := OfOrdered(3, 1, 2)
+	l.SortCompare(structs.Reverse(structs.CompareOrdered[int]))
+	expect := []int{3, 2, 1}
+	got := l.Values()
+	if !slices.Equal(got, expect) {
+		t.Errorf("expected list %v but got %v", expect, got)
+	}
+}
+
+func TestList_IsSorted(t *testing.T) {
+	if !NewOrdered[int]().IsSorted(structs.LessOrdered[int]) {
+		t.Error("expected empty list to be sorted")
+	}
+	if !OfOrdered(1, 2, 2, 3).IsSorted(structs.LessOrdered[int]) {
+		t.Error("expected list with equal values to be sorted")
+	}
+	if OfOrdered(1, 3, 2).IsSorted(structs.LessOrdered[int]) {
+		t.Error("expected unsorted list not to be sorted")
+	}
+}
+
 func TestList_Clone(t *testing.T) {
 	l := OfOrdered(1, 2, 3, 4, 5)
 	c := l.Clone()
@@ -676,3 +742,15 @@ func TestList_String(t *testing.T) {
 		t.Errorf("expected string '%v' but got '%v'", expect, got)
 	}
 }
+
+func TestList_Deque(t *testing.T) {
+	dequetest.Run(t, func() structs.Deque[int] {
+		return NewOrdered[int]()
+	})
+}
+
+func TestList_List(t *testing.T) {
+	listtest.Run(t, func() structs.List[int] {
+		return NewOrdered[int]()
+	})
+}
@@ -2,12 +2,58 @@ package list
 
 import "github.com/zytekaron/structs"
 
+// Iterator is a bidirectional iterator over the values of a List.
+//
+// Like a Java ListIterator, it has no current value: its cursor lies
+// between the value that would be returned by Previous and the value
+// that would be returned by Next. Remove and Set operate on the value
+// last returned by Next or Previous, and Add inserts at the cursor.
 type Iterator[V any] struct {
 	list  *List[V]
-	prev  *listNode[V]
-	next  *listNode[V]
-	last  *listNode[V] // last value returned
+	prev  *Element[V]
+	next  *Element[V]
+	last  *Element[V] // last value returned
 	index int
+
+	expectedModCount int
+}
+
+var _ structs.ListIterator[int] = (*Iterator[int])(nil)
+
+// ListIterator returns a ListIterator over the list whose cursor is
+// before the value at the index provided, so the first call to Next
+// returns that value and the first call to Previous returns the value
+// before it. The index may be the size of the list, to start at the end.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n)
+func (l *List[V]) ListIterator(index int) structs.ListIterator[V] {
+	l.checkBounds(index, true)
+
+	it := &Iterator[V]{
+		list:             l,
+		index:            index,
+		expectedModCount: l.modCount,
+	}
+	if index == l.size {
+		it.prev = l.tail
+	} else {
+		it.next = l.getNodeAt(index)
+		it.prev = it.next.prev
+	}
+	return it
+}
+
+// Add inserts a value into the list at the cursor, so it would be
+// returned by Previous and subsequent calls to Next are unaffected.
+func (it *Iterator[V]) Add(value V) {
+	it.checkModCount()
+
+	it.prev = it.insert(value)
+	it.index++
+	it.last = nil
+	it.expectedModCount = it.list.modCount
 }
 
 func (it *Iterator[V]) HasNext() bool {
@@ -19,14 +65,15 @@ func (it *Iterator[V]) HasPrevious() bool {
 }
 
 func (it *Iterator[V]) Next() V {
+	it.checkModCount()
 	if it.next == nil {
-		panic("next called on exhausted iterator")
+		panic(structs.PanicNoSuchElement)
 	}
 	it.index++
 
 	this := it.next // value to return
 	it.last, it.prev = this, this
-	it.next = this.Next
+	it.next = this.next
 	return this.Value
 }
 
@@ -35,14 +82,15 @@ func (it *Iterator[V]) NextIndex() int {
 }
 
 func (it *Iterator[V]) Previous() V {
+	it.checkModCount()
 	if it.prev == nil {
-		panic(structs.PanicIllegalState)
+		panic(structs.PanicNoSuchElement)
 	}
 	it.index--
 
 	this := it.prev // value to return
 	it.last, it.next = this, this
-	it.prev = this.Prev
+	it.prev = this.prev
 	return this.Value
 }
 
@@ -50,8 +98,12 @@ func (it *Iterator[V]) PreviousIndex() int {
 	return it.index - 1
 }
 
+// Remove removes the value last returned by Next or Previous.
+//
+// Panics if neither has been called since the last call to Remove or Add.
 func (it *Iterator[V]) Remove() {
-	if it.prev == nil {
+	it.checkModCount()
+	if it.last == nil {
 		panic(structs.PanicIllegalState)
 	}
 	// if removing the previous value (by list order),
@@ -61,24 +113,66 @@ func (it *Iterator[V]) Remove() {
 	}
 
 	this := it.last
-	it.next = this.Next
-	it.prev = this.Prev
+	it.next = this.next
+	it.prev = this.prev
 
 	it.list.removeNode(this)
 	it.last = nil
+	it.expectedModCount = it.list.modCount
 }
 
+// Set replaces the value last returned by Next or Previous.
+//
+// Panics if neither has been called since the last call to Remove or Add.
 func (it *Iterator[V]) Set(value V) {
+	it.checkModCount()
 	if it.last == nil {
 		panic(structs.PanicIllegalState)
 	}
 	it.last.Value = value
 }
 
+// insert inserts a value into the list between the
+// previous and next nodes, without moving the cursor.
+func (it *Iterator[V]) insert(value V) *Element[V] {
+	if it.next != nil {
+		return it.list.insertBeforeNode(it.next, value)
+	}
+	node := newNode(value)
+	it.list.addLastNode(node)
+	return node
+}
+
+// checkModCount panics if the list was modified
+// other than through this iterator.
+func (it *Iterator[V]) checkModCount() {
+	if it.list.modCount != it.expectedModCount {
+		panic(structs.PanicConcurrentModification)
+	}
+}
+
+// DescendingIterator is a bidirectional iterator over the values of
+// a List in reverse order. It behaves like an Iterator over a reversed
+// copy of the list, including its indices, except that changes are
+// made to the list itself.
 type DescendingIterator[V any] struct {
 	iter *Iterator[V]
 }
 
+var _ structs.ListIterator[int] = (*DescendingIterator[int])(nil)
+
+// Add inserts a value into the list at the cursor, so it would be
+// returned by Previous and subsequent calls to Next are unaffected.
+// In list order, the value is inserted after the value Next would return.
+func (it *DescendingIterator[V]) Add(value V) {
+	inner := it.iter
+	inner.checkModCount()
+
+	inner.next = inner.insert(value)
+	inner.last = nil
+	inner.expectedModCount = inner.list.modCount
+}
+
 func (it *DescendingIterator[V]) HasNext() bool {
 	return it.iter.HasPrevious()
 }
@@ -92,7 +186,7 @@ func (it *DescendingIterator[V]) Next() V {
 }
 
 func (it *DescendingIterator[V]) NextIndex() int {
-	return it.iter.PreviousIndex()
+	return it.iter.list.size - it.iter.index
 }
 
 func (it *DescendingIterator[V]) Previous() V {
@@ -100,13 +194,19 @@ func (it *DescendingIterator[V]) Previous() V {
 }
 
 func (it *DescendingIterator[V]) PreviousIndex() int {
-	return it.iter.NextIndex()
+	return it.NextIndex() - 1
 }
 
+// Remove removes the value last returned by Next or Previous.
+//
+// Panics if neither has been called since the last call to Remove or Add.
 func (it *DescendingIterator[V]) Remove() {
 	it.iter.Remove()
 }
 
+// Set replaces the value last returned by Next or Previous.
+//
+// Panics if neither has been called since the last call to Remove or Add.
 func (it *DescendingIterator[V]) Set(value V) {
 	it.iter.Set(value)
 }
@@ -1,7 +1,10 @@
 package list
 
 import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/listtest"
 	"golang.org/x/exp/slices"
+	"math/rand"
 	"testing"
 )
 
@@ -38,3 +41,236 @@ func TestListIterator(t *testing.T) {
 		t.Errorf("expected odd values %v but got %v", expectOdds, oddsSlice)
 	}
 }
+
+func TestListIterator_ConcurrentModification(t *testing.T) {
+	tests := map[string]func(l *List[int]){
+		"add":    func(l *List[int]) { l.Add(4) },
+		"remove": func(l *List[int]) { l.Remove(3) },
+		"clear":  func(l *List[int]) { l.Clear() },
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != structs.PanicConcurrentModification {
+					t.Errorf("expected panic %q but got %v", structs.PanicConcurrentModification, r)
+				}
+			}()
+
+			l := OfOrdered(1, 2, 3)
+			it := l.Iterator()
+			it.Next()
+			modify(l)
+			it.Next()
+		})
+	}
+}
+
+func TestListIterator_RemoveThenNext(t *testing.T) {
+	l := OfOrdered(1, 2, 3)
+	it := l.Iterator()
+	it.Next()
+	it.Remove()
+	l.Set(0, 20) // not a structural modification
+	if got := it.Next(); got != 20 {
+		t.Errorf("expected 20 but got %d", got)
+	}
+}
+
+func TestList_ListIterator(t *testing.T) {
+	l := OfOrdered(0, 1, 2, 3)
+
+	it := l.ListIterator(2)
+	if it.NextIndex() != 2 || it.PreviousIndex() != 1 {
+		t.Errorf("expected indices 2 and 1 but got %d and %d", it.NextIndex(), it.PreviousIndex())
+	}
+	if got := it.Next(); got != 2 {
+		t.Errorf("expected next 2 but got %d", got)
+	}
+	if got := it.Previous(); got != 2 {
+		t.Errorf("expected previous 2 but got %d", got)
+	}
+	if got := it.Previous(); got != 1 {
+		t.Errorf("expected previous 1 but got %d", got)
+	}
+
+	// add inserts before the cursor, and doesn't affect Next
+	it.Add(10)
+	if got := it.Next(); got != 1 {
+		t.Errorf("expected next 1 but got %d", got)
+	}
+	it.Set(11)
+	listtest.ExpectValues(t, l.Values(), []int{0, 10, 11, 2, 3})
+
+	it = l.ListIterator(l.Size())
+	if it.HasNext() || !it.HasPrevious() {
+		t.Error("expected iterator at the end to have only a previous value")
+	}
+	it.Add(4)
+	if got := it.Previous(); got != 4 {
+		t.Errorf("expected previous 4 but got %d", got)
+	}
+	listtest.ExpectValues(t, l.Values(), []int{0, 10, 11, 2, 3, 4})
+
+	empty := NewOrdered[int]()
+	it = empty.ListIterator(0)
+	it.Add(1)
+	it.Add(2)
+	listtest.ExpectValues(t, empty.Values(), []int{1, 2})
+	listtest.ExpectValues(t, empty.ValuesReverse(), []int{2, 1})
+
+	listtest.ExpectPanic(t, structs.PanicIndexOutOfBounds, func() { l.ListIterator(-1) })
+	listtest.ExpectPanic(t, structs.PanicIndexOutOfBounds, func() { l.ListIterator(l.Size() + 1) })
+}
+
+func TestList_ListIteratorIllegalState(t *testing.T) {
+	tests := map[string]func(it structs.ListIterator[int]){
+		"remove before next": func(it structs.ListIterator[int]) { it.Remove() },
+		"set before next":    func(it structs.ListIterator[int]) { it.Set(0) },
+		"remove twice":       func(it structs.ListIterator[int]) { it.Next(); it.Remove(); it.Remove() },
+		"set after remove":   func(it structs.ListIterator[int]) { it.Next(); it.Remove(); it.Set(0) },
+		"remove after add":   func(it structs.ListIterator[int]) { it.Next(); it.Add(0); it.Remove() },
+		"set after add":      func(it structs.ListIterator[int]) { it.Previous(); it.Add(0); it.Set(0) },
+	}
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			it := OfOrdered(1, 2, 3).ListIterator(1)
+			listtest.ExpectPanic(t, structs.PanicIllegalState, func() { f(it) })
+		})
+	}
+
+	it := OfOrdered(1).ListIterator(1)
+	listtest.ExpectPanic(t, structs.PanicNoSuchElement, func() { it.Next() })
+	it = OfOrdered(1).ListIterator(0)
+	listtest.ExpectPanic(t, structs.PanicNoSuchElement, func() { it.Previous() })
+}
+
+// listIteratorModel is a reference implementation of
+// a ListIterator's semantics over a slice.
+type listIteratorModel struct {
+	values []int
+	cursor int
+	last   int // index of the last value returned, or -1
+}
+
+func TestList_ListIteratorModel(t *testing.T) {
+	testListIteratorModel(t, false)
+}
+
+func TestList_DescendingIteratorModel(t *testing.T) {
+	testListIteratorModel(t, true)
+}
+
+// testListIteratorModel performs random sequences of operations on a
+// ListIterator, mixing Next, Previous, Remove, Add and Set, and checks
+// every result and the list's contents against listIteratorModel.
+func testListIteratorModel(t *testing.T, descending bool) {
+	rng := rand.New(rand.NewSource(1))
+	for run := 0; run < 200; run++ {
+		size := rng.Intn(6)
+		values := make([]int, size)
+		for i := range values {
+			values[i] = i
+		}
+		l := OfOrdered(values...)
+
+		var it structs.ListIterator[int]
+		model := &listIteratorModel{last: -1}
+		if descending {
+			it = l.DescendingIterator().(structs.ListIterator[int])
+			model.values = l.ValuesReverse()
+		} else {
+			model.cursor = rng.Intn(size + 1)
+			it = l.ListIterator(model.cursor)
+			model.values = l.Values()
+		}
+
+		next := 100
+		for step := 0; step < 30; step++ {
+			var op string
+			var panicked interface{}
+			func() {
+				defer func() { panicked = recover() }()
+				switch rng.Intn(5) {
+				case 0:
+					op = "next"
+					got := it.Next()
+					if model.cursor == len(model.values) {
+						t.Fatalf("run %d: expected next to panic", run)
+					}
+					if got != model.values[model.cursor] {
+						t.Fatalf("run %d: expected next %d but got %d", run, model.values[model.cursor], got)
+					}
+					model.last = model.cursor
+					model.cursor++
+				case 1:
+					op = "previous"
+					got := it.Previous()
+					if model.cursor == 0 {
+						t.Fatalf("run %d: expected previous to panic", run)
+					}
+					model.cursor--
+					if got != model.values[model.cursor] {
+						t.Fatalf("run %d: expected previous %d but got %d", run, model.values[model.cursor], got)
+					}
+					model.last = model.cursor
+				case 2:
+					op = "remove"
+					it.Remove()
+					if model.last < 0 {
+						t.Fatalf("run %d: expected remove to panic", run)
+					}
+					model.values = slices.Delete(model.values, model.last, model.last+1)
+					if model.last < model.cursor {
+						model.cursor--
+					}
+					model.last = -1
+				case 3:
+					op = "add"
+					it.Add(next)
+					model.values = slices.Insert(model.values, model.cursor, next)
+					model.cursor++
+					model.last = -1
+					next++
+				case 4:
+					op = "set"
+					it.Set(next)
+					if model.last < 0 {
+						t.Fatalf("run %d: expected set to panic", run)
+					}
+					model.values[model.last] = next
+					next++
+				}
+			}()
+
+			switch {
+			case panicked == nil:
+			case op == "next" && model.cursor == len(model.values),
+				op == "previous" && model.cursor == 0:
+				if panicked != structs.PanicNoSuchElement {
+					t.Fatalf("run %d: unexpected panic from %s: %v", run, op, panicked)
+				}
+			case (op == "remove" || op == "set") && model.last < 0:
+				if panicked != structs.PanicIllegalState {
+					t.Fatalf("run %d: unexpected panic from %s: %v", run, op, panicked)
+				}
+			default:
+				panic(panicked)
+			}
+
+			got := l.Values()
+			if descending {
+				got = l.ValuesReverse()
+			}
+			if !slices.Equal(got, model.values) {
+				t.Fatalf("run %d: after %s expected values %v but got %v", run, op, model.values, got)
+			}
+			if it.NextIndex() != model.cursor || it.PreviousIndex() != model.cursor-1 {
+				t.Fatalf("run %d: after %s expected indices %d and %d but got %d and %d",
+					run, op, model.cursor, model.cursor-1, it.NextIndex(), it.PreviousIndex())
+			}
+			if it.HasNext() != (model.cursor < len(model.values)) || it.HasPrevious() != (model.cursor > 0) {
+				t.Fatalf("run %d: after %s unexpected HasNext or HasPrevious", run, op)
+			}
+		}
+	}
+}
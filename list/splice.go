@@ -0,0 +1,157 @@
+package list
+
+import "github.com/zytekaron/structs"
+
+// Concat moves all the values in the other list to the end of this
+// list, leaving the other list empty. Elements of the other list's
+// values remain valid, and now belong to this list.
+//
+// Panics if the other list is this list.
+//
+// Time Complexity: O(1)
+func (l *List[V]) Concat(other *List[V]) {
+	l.Splice(l.size, other)
+}
+
+// Splice moves all the values in the other list into this list, starting
+// at the index provided and shifting existing values right, leaving the
+// other list empty. Elements of the other list's values remain valid,
+// and now belong to this list.
+//
+// Panics if the index is out of bounds, or if the other list is this list.
+//
+// Time Complexity: O(n), or O(1) at either end of the list
+func (l *List[V]) Splice(index int, other *List[V]) {
+	l.checkBounds(index, true)
+	if other == l {
+		panic(structs.PanicIllegalState)
+	}
+	if other.size == 0 {
+		return
+	}
+
+	first, last := other.head, other.tail
+	switch {
+	case l.size == 0:
+		l.head = first
+		l.tail = last
+	case index == 0:
+		last.next = l.head
+		l.head.prev = last
+		l.head = first
+	case index == l.size:
+		first.prev = l.tail
+		l.tail.next = first
+		l.tail = last
+	default:
+		target := l.getNodeAt(index)
+		first.prev = target.prev
+		last.next = target
+		target.prev.next = first
+		target.prev = last
+	}
+	l.adopt(other)
+	l.size += other.size
+	l.modCount++
+
+	other.head = nil
+	other.tail = nil
+	other.size = 0
+	other.modCount++
+}
+
+// SplitAt splits the list at the index provided, leaving the values
+// before the index in this list and returning a new list containing
+// the values from the index onward. Elements of the moved values
+// remain valid, and now belong to the new list.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n)
+func (l *List[V]) SplitAt(index int) *List[V] {
+	l.checkBounds(index, true)
+
+	split := New[V](l.eq)
+	if index == l.size {
+		return split
+	}
+
+	first := l.getNodeAt(index)
+	split.head = first
+	split.tail = l.tail
+	split.size = l.size - index
+	for node := first; node != nil; node = node.next {
+		node.owner = split.token()
+	}
+
+	l.tail = first.prev
+	if l.tail == nil {
+		l.head = nil
+	} else {
+		l.tail.next = nil
+	}
+	first.prev = nil
+	l.size = index
+	l.modCount++
+	return split
+}
+
+// Rotate rotates the list k places toward the end, so the last k values
+// move to the front. If k is negative, the list is rotated toward the
+// front instead, so the first -k values move to the end.
+//
+// Time Complexity: O(n)
+func (l *List[V]) Rotate(k int) {
+	if l.size < 2 {
+		return
+	}
+	k %= l.size
+	if k < 0 {
+		k += l.size
+	}
+	if k == 0 {
+		return
+	}
+
+	// join the ends into a ring, then break it before the new head
+	head := l.getNodeAt(l.size - k)
+	l.tail.next = l.head
+	l.head.prev = l.tail
+	l.tail = head.prev
+	l.tail.next = nil
+	head.prev = nil
+	l.head = head
+	l.modCount++
+}
+
+// Swap swaps the positions of the values at the indices provided.
+// The values' nodes are relinked, so their elements remain valid.
+//
+// Panics if either index is out of bounds.
+//
+// Time Complexity: O(n)
+func (l *List[V]) Swap(i, j int) {
+	l.checkBounds(i, false)
+	l.checkBounds(j, false)
+	if i == j {
+		return
+	}
+	if i > j {
+		i, j = j, i
+	}
+
+	a := l.getNodeAt(i)
+	b := a
+	for k := i; k < j; k++ {
+		b = b.next
+	}
+
+	// move b to before a, then a to where b was
+	bPrev := b.prev
+	l.removeNode(b)
+	l.linkBeforeNode(a, b)
+	if bPrev != a {
+		l.removeNode(a)
+		l.linkAfterNode(bPrev, a)
+	}
+}
@@ -0,0 +1,149 @@
+package list
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/listtest"
+	"testing"
+)
+
+func TestList_Concat(t *testing.T) {
+	l := OfOrdered(1, 2)
+	other := NewOrdered[int]()
+	three := other.AddLastElement(3)
+	other.Add(4)
+
+	l.Concat(other)
+	listtest.ExpectValues(t, l.Values(), []int{1, 2, 3, 4})
+	listtest.ExpectValues(t, l.ValuesReverse(), []int{4, 3, 2, 1})
+	if l.Size() != 4 || other.Size() != 0 || !other.IsEmpty() {
+		t.Errorf("expected sizes 4 and 0 but got %d and %d", l.Size(), other.Size())
+	}
+
+	// the moved elements now belong to the list they were moved to
+	l.MoveToFront(three)
+	listtest.ExpectValues(t, l.Values(), []int{3, 1, 2, 4})
+	listtest.ExpectPanic(t, structs.PanicNoSuchElement, func() { other.RemoveElement(three) })
+
+	// the emptied list can be reused
+	five := other.AddLastElement(5)
+	other.RemoveElement(five)
+	l.Concat(NewOrdered[int]())
+	NewOrdered[int]().Concat(l)
+	listtest.ExpectValues(t, l.Values(), []int{})
+
+	listtest.ExpectPanic(t, structs.PanicIllegalState, func() { l.Concat(l) })
+}
+
+func TestList_Splice(t *testing.T) {
+	tests := []struct {
+		index  int
+		expect []int
+	}{
+		{0, []int{7, 8, 1, 2, 3}},
+		{1, []int{1, 7, 8, 2, 3}},
+		{2, []int{1, 2, 7, 8, 3}},
+		{3, []int{1, 2, 3, 7, 8}},
+	}
+	for _, test := range tests {
+		l := OfOrdered(1, 2, 3)
+		other := OfOrdered(7, 8)
+		l.Splice(test.index, other)
+
+		listtest.ExpectValues(t, l.Values(), test.expect)
+		reversed := make([]int, len(test.expect))
+		for i, value := range test.expect {
+			reversed[len(reversed)-1-i] = value
+		}
+		listtest.ExpectValues(t, l.ValuesReverse(), reversed)
+		if other.Size() != 0 {
+			t.Errorf("expected other list to be empty but got size %d", other.Size())
+		}
+	}
+
+	empty := NewOrdered[int]()
+	empty.Splice(0, OfOrdered(1, 2))
+	listtest.ExpectValues(t, empty.Values(), []int{1, 2})
+	listtest.ExpectPanic(t, structs.PanicIndexOutOfBounds, func() { empty.Splice(3, OfOrdered(1)) })
+}
+
+func TestList_SplitAt(t *testing.T) {
+	for index := 0; index <= 4; index++ {
+		l := OfOrdered(0, 1, 2, 3)
+		elements := []*Element[int]{}
+		for e := l.FirstElement(); e != nil; e = e.Next() {
+			elements = append(elements, e)
+		}
+
+		split := l.SplitAt(index)
+		if l.Size() != index || split.Size() != 4-index {
+			t.Errorf("expected sizes %d and %d but got %d and %d", index, 4-index, l.Size(), split.Size())
+		}
+		listtest.ExpectValues(t, append(l.Values(), split.Values()...), []int{0, 1, 2, 3})
+		listtest.ExpectValues(t, append(split.ValuesReverse(), l.ValuesReverse()...), []int{3, 2, 1, 0})
+
+		// each element belongs to the list its value ended up in
+		for i, e := range elements {
+			if i < index {
+				l.MoveToBack(e)
+			} else {
+				split.MoveToBack(e)
+			}
+		}
+	}
+
+	listtest.ExpectPanic(t, structs.PanicIndexOutOfBounds, func() { OfOrdered(1).SplitAt(2) })
+}
+
+func TestList_Rotate(t *testing.T) {
+	tests := []struct {
+		k      int
+		expect []int
+	}{
+		{0, []int{0, 1, 2, 3, 4}},
+		{1, []int{4, 0, 1, 2, 3}},
+		{2, []int{3, 4, 0, 1, 2}},
+		{5, []int{0, 1, 2, 3, 4}},
+		{7, []int{3, 4, 0, 1, 2}},
+		{-1, []int{1, 2, 3, 4, 0}},
+		{-8, []int{3, 4, 0, 1, 2}},
+	}
+	for _, test := range tests {
+		l := OfOrdered(0, 1, 2, 3, 4)
+		l.Rotate(test.k)
+		listtest.ExpectValues(t, l.Values(), test.expect)
+		if l.GetLast() != test.expect[4] || l.LastElement().Next() != nil || l.FirstElement().Prev() != nil {
+			t.Errorf("expected list ends to be relinked after rotating by %d", test.k)
+		}
+	}
+
+	l := NewOrdered[int]()
+	l.Rotate(3)
+	listtest.ExpectValues(t, l.Values(), []int{})
+}
+
+func TestList_Swap(t *testing.T) {
+	tests := []struct {
+		i, j   int
+		expect []int
+	}{
+		{0, 0, []int{0, 1, 2, 3}},
+		{0, 1, []int{1, 0, 2, 3}},
+		{1, 0, []int{1, 0, 2, 3}},
+		{0, 3, []int{3, 1, 2, 0}},
+		{1, 2, []int{0, 2, 1, 3}},
+		{2, 3, []int{0, 1, 3, 2}},
+		{1, 3, []int{0, 3, 2, 1}},
+	}
+	for _, test := range tests {
+		l := OfOrdered(0, 1, 2, 3)
+		first := l.FirstElement()
+		l.Swap(test.i, test.j)
+		listtest.ExpectValues(t, l.Values(), test.expect)
+		listtest.ExpectValues(t, l.ValuesReverse(), []int{test.expect[3], test.expect[2], test.expect[1], test.expect[0]})
+		if first.Value != 0 {
+			t.Error("expected nodes to be relinked rather than values swapped")
+		}
+	}
+
+	listtest.ExpectPanic(t, structs.PanicIndexOutOfBounds, func() { OfOrdered(1).Swap(0, 1) })
+}
@@ -0,0 +1,442 @@
+package list
+
+import (
+	"github.com/zytekaron/structs"
+	"golang.org/x/exp/slices"
+)
+
+// SubList is a view of a range of a List. Changes made through
+// the view are made to the range of the backing list, and changes
+// to values made through the backing list are visible in the view.
+//
+// If the backing list is structurally modified other than through
+// the view, for example by adding a value to it directly, the view
+// becomes invalid and most of its methods panic with
+// structs.PanicConcurrentModification.
+type SubList[V any] struct {
+	list   *List[V]
+	parent *SubList[V] // the view this view was created from, or nil
+	offset int         // index in the backing list of the first value
+	size   int
+
+	expectedModCount int
+}
+
+var _ structs.List[int] = (*SubList[int])(nil)
+
+// SubList returns a view of the list between the from index, inclusive,
+// and the to index, exclusive. The view is backed by the list.
+//
+// Panics if the range is out of bounds.
+//
+// Time Complexity: O(1)
+func (l *List[V]) SubList(from, to int) structs.List[V] {
+	checkRange(from, to, l.size)
+
+	return &SubList[V]{
+		list:             l,
+		offset:           from,
+		size:             to - from,
+		expectedModCount: l.modCount,
+	}
+}
+
+// Add adds a value to the end of the view.
+//
+// Time Complexity: O(n)
+func (s *SubList[V]) Add(value V) bool {
+	s.AddAt(s.size, value)
+	return true
+}
+
+// AddAll adds all the values in the other collection to the end of the view.
+//
+// Time Complexity: O(n+m)
+func (s *SubList[V]) AddAll(other structs.Collection[V]) bool {
+	return s.AddIterator(other.Iterator())
+}
+
+// AddAt adds a value at the specified index in the view.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n)
+func (s *SubList[V]) AddAt(index int, value V) {
+	s.checkModCount()
+	s.checkBounds(index, true)
+
+	s.list.AddAt(s.offset+index, value)
+	s.updateSize(1)
+}
+
+// AddIterator adds all the values in the iterator to the end of the view.
+//
+// Time Complexity: O(n+m)
+func (s *SubList[V]) AddIterator(iter structs.Iterator[V]) bool {
+	s.checkModCount()
+
+	if !iter.HasNext() {
+		return false
+	}
+	// insert each value after the previous one, rather than
+	// walking to the end of the view for every value
+	var node *Element[V]
+	if s.offset+s.size > 0 {
+		node = s.list.getNodeAt(s.offset + s.size - 1)
+	} else {
+		node = newNode(iter.Next())
+		s.list.addFirstNode(node)
+		s.updateSize(1)
+	}
+	for iter.HasNext() {
+		node = s.list.insertAfterNode(node, iter.Next())
+		s.updateSize(1)
+	}
+	return true
+}
+
+// Clear removes all the values in the view from the backing list.
+//
+// Time Complexity: O(n)
+func (s *SubList[V]) Clear() {
+	s.checkModCount()
+
+	node := s.firstNode()
+	for i := 0; i < s.size; i++ {
+		next := node.next
+		s.list.removeNode(node)
+		node = next
+	}
+	s.updateSize(-s.size)
+}
+
+// Contains returns whether the value is present in the view.
+//
+// Time Complexity: O(n)
+func (s *SubList[V]) Contains(value V) bool {
+	return s.IndexOf(value) >= 0
+}
+
+// ContainsAll returns whether all the values in the other collection are present in the view.
+//
+// Time Complexity: O(nm)
+func (s *SubList[V]) ContainsAll(other structs.Collection[V]) bool {
+	return s.ContainsIterator(other.Iterator())
+}
+
+// ContainsIterator returns whether all the values in the iterator are present in the view.
+//
+// Time Complexity: O(nm)
+func (s *SubList[V]) ContainsIterator(iter structs.Iterator[V]) bool {
+	for iter.HasNext() {
+		if !s.Contains(iter.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Get returns the value at the specified index in the view.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n)
+func (s *SubList[V]) Get(index int) V {
+	s.checkModCount()
+	s.checkBounds(index, false)
+
+	return s.list.getNodeAt(s.offset + index).Value
+}
+
+// IndexOf returns the first index of a value in the view,
+// or -1 if the value is not present in the view.
+//
+// Time Complexity: O(n)
+func (s *SubList[V]) IndexOf(value V) int {
+	s.checkModCount()
+
+	node := s.firstNode()
+	for i := 0; i < s.size; i++ {
+		if s.list.eq(node.Value, value) {
+			return i
+		}
+		node = node.next
+	}
+	return -1
+}
+
+// IsEmpty returns whether the view is empty.
+func (s *SubList[V]) IsEmpty() bool {
+	s.checkModCount()
+
+	return s.size == 0
+}
+
+// Iterator returns an Iterator over the view.
+func (s *SubList[V]) Iterator() structs.Iterator[V] {
+	s.checkModCount()
+
+	return &SubListIterator[V]{
+		view:             s,
+		next:             s.firstNode(),
+		expectedModCount: s.list.modCount,
+	}
+}
+
+// LastIndexOf returns the last index of a value in the view,
+// or -1 if the value is not present in the view.
+//
+// Time Complexity: O(n)
+func (s *SubList[V]) LastIndexOf(value V) int {
+	s.checkModCount()
+
+	index := -1
+	node := s.firstNode()
+	for i := 0; i < s.size; i++ {
+		if s.list.eq(node.Value, value) {
+			index = i
+		}
+		node = node.next
+	}
+	return index
+}
+
+// Remove removes the first occurrence of a value from the
+// view and returns whether the value was present.
+//
+// Time Complexity: O(n)
+func (s *SubList[V]) Remove(value V) bool {
+	s.checkModCount()
+
+	node := s.firstNode()
+	for i := 0; i < s.size; i++ {
+		if s.list.eq(node.Value, value) {
+			s.list.removeNode(node)
+			s.updateSize(-1)
+			return true
+		}
+		node = node.next
+	}
+	return false
+}
+
+// RemoveAll removes all the values in the other collection from the view.
+//
+// Time Complexity: O(nm)
+func (s *SubList[V]) RemoveAll(other structs.Collection[V]) bool {
+	return s.RemoveIterator(other.Iterator())
+}
+
+// RemoveAt removes the value at the specified index from the view and returns it.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n)
+func (s *SubList[V]) RemoveAt(index int) V {
+	s.checkModCount()
+	s.checkBounds(index, false)
+
+	value := s.list.RemoveAt(s.offset + index)
+	s.updateSize(-1)
+	return value
+}
+
+// RemoveIterator removes all the values in the iterator from the view.
+//
+// Time Complexity: O(nm)
+func (s *SubList[V]) RemoveIterator(iter structs.Iterator[V]) bool {
+	changed := false
+	for iter.HasNext() {
+		if s.Remove(iter.Next()) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// RetainAll removes all the values not present in the other collection from the view.
+//
+// Time Complexity: O(nm)
+//
+//	n = size of the view
+//	m = time complexity of Contains on the other collection
+func (s *SubList[V]) RetainAll(other structs.Collection[V]) bool {
+	s.checkModCount()
+
+	removed := 0
+	node := s.firstNode()
+	for i := 0; i < s.size; i++ {
+		next := node.next
+		if !other.Contains(node.Value) {
+			s.list.removeNode(node)
+			removed++
+		}
+		node = next
+	}
+	if removed == 0 {
+		return false
+	}
+	s.updateSize(-removed)
+	return true
+}
+
+// Set sets the value at the specified index in the view, returning the old value.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n)
+func (s *SubList[V]) Set(index int, value V) V {
+	s.checkModCount()
+	s.checkBounds(index, false)
+
+	return s.list.Set(s.offset+index, value)
+}
+
+// Size returns the number of values in the view.
+//
+// Time Complexity: O(1)
+func (s *SubList[V]) Size() int {
+	s.checkModCount()
+
+	return s.size
+}
+
+// Sort sorts the values in the view based on a comparator,
+// leaving the rest of the backing list unchanged.
+//
+// Time Complexity: O(nlogn)
+func (s *SubList[V]) Sort(cmp structs.LessFunc[V]) {
+	sorted := s.Values()
+	slices.SortFunc(sorted, cmp)
+
+	node := s.firstNode()
+	for _, value := range sorted {
+		node.Value = value
+		node = node.next
+	}
+}
+
+// SubList returns a view of this view between the from index, inclusive,
+// and the to index, exclusive. The view is backed by the same list.
+//
+// Panics if the range is out of bounds.
+//
+// Time Complexity: O(1)
+func (s *SubList[V]) SubList(from, to int) structs.List[V] {
+	s.checkModCount()
+	checkRange(from, to, s.size)
+
+	return &SubList[V]{
+		list:             s.list,
+		parent:           s,
+		offset:           s.offset + from,
+		size:             to - from,
+		expectedModCount: s.list.modCount,
+	}
+}
+
+// Values returns a slice of the values in the view.
+//
+// Time Complexity: O(n)
+//
+// Space Complexity: O(n)
+func (s *SubList[V]) Values() []V {
+	s.checkModCount()
+
+	values := make([]V, s.size)
+	node := s.firstNode()
+	for i := range values {
+		values[i] = node.Value
+		node = node.next
+	}
+	return values
+}
+
+// firstNode returns the first node in the view, or nil if it is empty.
+func (s *SubList[V]) firstNode() *Element[V] {
+	if s.size == 0 {
+		return nil
+	}
+	return s.list.getNodeAt(s.offset)
+}
+
+// updateSize adjusts the size of the view and the views it was created
+// from after it is structurally modified, and records the backing list's
+// new modCount so the modification isn't mistaken for a concurrent one.
+func (s *SubList[V]) updateSize(delta int) {
+	for view := s; view != nil; view = view.parent {
+		view.size += delta
+		view.expectedModCount = s.list.modCount
+	}
+}
+
+// checkModCount panics if the backing list was
+// structurally modified other than through the view.
+func (s *SubList[V]) checkModCount() {
+	if s.list.modCount != s.expectedModCount {
+		panic(structs.PanicConcurrentModification)
+	}
+}
+
+// checkBounds checks whether an index is within the bounds of the view.
+//
+// if allowEnd is true, index == s.size is allowed.
+func (s *SubList[V]) checkBounds(index int, allowEnd bool) {
+	if index < 0 || index > s.size || (!allowEnd && index == s.size) {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+}
+
+// checkRange panics if the range from:to is not within a list of the size.
+func checkRange(from, to, size int) {
+	if from < 0 || to > size || from > to {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+}
+
+// SubListIterator is an iterator over the values of a SubList.
+type SubListIterator[V any] struct {
+	view  *SubList[V]
+	next  *Element[V]
+	last  *Element[V] // last value returned
+	index int
+
+	expectedModCount int
+}
+
+func (it *SubListIterator[V]) HasNext() bool {
+	return it.index < it.view.size
+}
+
+func (it *SubListIterator[V]) Next() V {
+	it.checkModCount()
+	if !it.HasNext() {
+		panic(structs.PanicNoSuchElement)
+	}
+	it.index++
+
+	it.last = it.next
+	it.next = it.next.next
+	return it.last.Value
+}
+
+func (it *SubListIterator[V]) Remove() {
+	it.checkModCount()
+	if it.last == nil {
+		panic(structs.PanicIllegalState)
+	}
+
+	it.view.list.removeNode(it.last)
+	it.view.updateSize(-1)
+	it.index--
+	it.last = nil
+	it.expectedModCount = it.view.list.modCount
+}
+
+// checkModCount panics if the backing list was modified
+// other than through this iterator.
+func (it *SubListIterator[V]) checkModCount() {
+	if it.view.list.modCount != it.expectedModCount {
+		panic(structs.PanicConcurrentModification)
+	}
+}
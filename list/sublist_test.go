@@ -0,0 +1,168 @@
+package list
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/listtest"
+	"testing"
+)
+
+func TestList_SubList(t *testing.T) {
+	l := OfOrdered(0, 1, 2, 3, 4, 5, 6, 7)
+	sub := l.SubList(2, 6)
+
+	listtest.ExpectValues(t, sub.Values(), []int{2, 3, 4, 5})
+	if sub.Size() != 4 {
+		t.Errorf("expected size 4 but got %d", sub.Size())
+	}
+	if got := sub.Get(1); got != 3 {
+		t.Errorf("expected value 3 at index 1 but got %d", got)
+	}
+	if sub.IndexOf(5) != 3 || sub.IndexOf(1) != -1 || sub.LastIndexOf(2) != 0 {
+		t.Error("unexpected result from IndexOf or LastIndexOf")
+	}
+	if !sub.Contains(4) || sub.Contains(6) {
+		t.Error("expected view to contain 4 and not 6")
+	}
+
+	// writes through the view are made to the backing list
+	if old := sub.Set(0, 20); old != 2 {
+		t.Errorf("expected old value 2 but got %d", old)
+	}
+	sub.AddAt(1, 10)
+	sub.Add(50)
+	listtest.ExpectValues(t, sub.Values(), []int{20, 10, 3, 4, 5, 50})
+	listtest.ExpectValues(t, l.Values(), []int{0, 1, 20, 10, 3, 4, 5, 50, 6, 7})
+
+	if got := sub.RemoveAt(2); got != 3 {
+		t.Errorf("expected removed value 3 but got %d", got)
+	}
+	if !sub.Remove(50) || sub.Remove(6) {
+		t.Error("unexpected result from Remove")
+	}
+	listtest.ExpectValues(t, sub.Values(), []int{20, 10, 4, 5})
+	listtest.ExpectValues(t, l.Values(), []int{0, 1, 20, 10, 4, 5, 6, 7})
+
+	// values set through the backing list are visible in the view
+	l.Set(3, 11)
+	if got := sub.Get(1); got != 11 {
+		t.Errorf("expected value 11 at index 1 but got %d", got)
+	}
+
+	sub.Clear()
+	if !sub.IsEmpty() {
+		t.Error("expected view to be empty after Clear")
+	}
+	listtest.ExpectValues(t, l.Values(), []int{0, 1, 6, 7})
+
+	sub.AddAll(OfOrdered(2, 3))
+	listtest.ExpectValues(t, l.Values(), []int{0, 1, 2, 3, 6, 7})
+}
+
+func TestSubList_List(t *testing.T) {
+	listtest.Run(t, func() structs.List[int] {
+		// an empty view in the middle of a list
+		return OfOrdered(-1, -2).SubList(1, 1)
+	})
+}
+
+func TestList_SubListBulk(t *testing.T) {
+	l := OfOrdered(5, 4, 3, 2, 1, 0)
+	sub := l.SubList(1, 5)
+
+	sub.Sort(func(a, b int) bool { return a < b })
+	listtest.ExpectValues(t, l.Values(), []int{5, 1, 2, 3, 4, 0})
+
+	if !sub.RetainAll(OfOrdered(1, 3, 5)) {
+		t.Error("expected RetainAll to change the view")
+	}
+	listtest.ExpectValues(t, l.Values(), []int{5, 1, 3, 0})
+
+	if !sub.RemoveAll(OfOrdered(0, 3)) {
+		t.Error("expected RemoveAll to change the view")
+	}
+	// the 0 outside the view is not removed
+	listtest.ExpectValues(t, l.Values(), []int{5, 1, 0})
+
+	empty := OfOrdered[int]()
+	empty.SubList(0, 0).AddAll(OfOrdered(1, 2))
+	listtest.ExpectValues(t, empty.Values(), []int{1, 2})
+}
+
+func TestList_SubListNested(t *testing.T) {
+	l := OfOrdered(0, 1, 2, 3, 4, 5, 6, 7)
+	outer := l.SubList(1, 7)
+	inner := outer.SubList(2, 4)
+
+	listtest.ExpectValues(t, inner.Values(), []int{3, 4})
+	inner.Add(40)
+	inner.RemoveAt(0)
+
+	// the sizes of the views it was created from are kept in sync
+	if outer.Size() != 6 || inner.Size() != 2 {
+		t.Errorf("expected sizes 6 and 2 but got %d and %d", outer.Size(), inner.Size())
+	}
+	listtest.ExpectValues(t, outer.Values(), []int{1, 2, 4, 40, 5, 6})
+	listtest.ExpectValues(t, l.Values(), []int{0, 1, 2, 4, 40, 5, 6, 7})
+
+	// modifying the outer view invalidates the inner view
+	outer.Add(8)
+	listtest.ExpectPanic(t, structs.PanicConcurrentModification, func() { inner.Size() })
+}
+
+func TestList_SubListIterator(t *testing.T) {
+	l := OfOrdered(0, 1, 2, 3, 4, 5, 6, 7)
+	sub := l.SubList(2, 7)
+
+	var got []int
+	it := sub.Iterator()
+	for it.HasNext() {
+		value := it.Next()
+		got = append(got, value)
+		if value%2 == 1 {
+			it.Remove()
+		}
+	}
+	listtest.ExpectValues(t, got, []int{2, 3, 4, 5, 6})
+	listtest.ExpectValues(t, sub.Values(), []int{2, 4, 6})
+	listtest.ExpectValues(t, l.Values(), []int{0, 1, 2, 4, 6, 7})
+
+	it = sub.Iterator()
+	it.Next()
+	sub.Add(8)
+	listtest.ExpectPanic(t, structs.PanicConcurrentModification, func() { it.Next() })
+}
+
+func TestList_SubListConcurrentModification(t *testing.T) {
+	tests := map[string]func(l *List[int]){
+		"add":     func(l *List[int]) { l.Add(4) },
+		"remove":  func(l *List[int]) { l.Remove(0) },
+		"clear":   func(l *List[int]) { l.Clear() },
+		"reverse": func(l *List[int]) { l.Reverse() },
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := OfOrdered(0, 1, 2, 3)
+			sub := l.SubList(1, 3)
+			modify(l)
+			listtest.ExpectPanic(t, structs.PanicConcurrentModification, func() { sub.Get(0) })
+		})
+	}
+
+	// setting values is not a structural modification
+	l := OfOrdered(0, 1, 2, 3)
+	sub := l.SubList(1, 3)
+	l.Set(1, 10)
+	listtest.ExpectValues(t, sub.Values(), []int{10, 2})
+}
+
+func TestList_SubListBounds(t *testing.T) {
+	l := OfOrdered(0, 1, 2, 3)
+	listtest.ExpectPanic(t, structs.PanicIndexOutOfBounds, func() { l.SubList(-1, 2) })
+	listtest.ExpectPanic(t, structs.PanicIndexOutOfBounds, func() { l.SubList(0, 5) })
+	listtest.ExpectPanic(t, structs.PanicIndexOutOfBounds, func() { l.SubList(3, 2) })
+
+	sub := l.SubList(1, 3)
+	listtest.ExpectPanic(t, structs.PanicIndexOutOfBounds, func() { sub.Get(2) })
+	listtest.ExpectPanic(t, structs.PanicIndexOutOfBounds, func() { sub.AddAt(3, 0) })
+	listtest.ExpectPanic(t, structs.PanicIndexOutOfBounds, func() { sub.SubList(0, 3) })
+}
@@ -0,0 +1,421 @@
+// Package persistent implements immutable collections which share
+// structure between versions, so keeping old versions is cheap.
+package persistent
+
+import (
+	"fmt"
+	"github.com/zytekaron/structs"
+	"golang.org/x/exp/constraints"
+	"strings"
+)
+
+// List is an immutable singly linked list, also known as a cons list.
+//
+// Methods which change the list return a new version, which shares
+// every cell after the changed position with the old version. This
+// makes operations at the front of the list O(1), and operations at
+// an index O(i), where i is the index.
+//
+// The value equality function may be omitted (nil)
+// if no methods are called which use it.
+type List[V any] struct {
+	eq   structs.EqualFunc[V]
+	head *cell[V]
+}
+
+// cell is a cell of a List, which is never modified once shared.
+type cell[V any] struct {
+	value V
+	next  *cell[V]
+	size  int // number of cells from this cell to the end
+}
+
+// NewList creates an empty List.
+func NewList[V any](eq structs.EqualFunc[V]) *List[V] {
+	return &List[V]{
+		eq: eq,
+	}
+}
+
+// NewOrderedList creates an empty List from a type that implements constraints.Ordered.
+func NewOrderedList[V constraints.Ordered]() *List[V] {
+	return NewList(structs.EqualOrdered[V])
+}
+
+// ListOf creates a List from an existing slice.
+func ListOf[V any](eq structs.EqualFunc[V], values ...V) *List[V] {
+	return &List[V]{
+		eq:   eq,
+		head: prepend(nil, values),
+	}
+}
+
+// OrderedListOf creates a List from an existing slice of a type that implements constraints.Ordered.
+func OrderedListOf[V constraints.Ordered](values ...V) *List[V] {
+	return ListOf(structs.EqualOrdered[V], values...)
+}
+
+// Add returns a new version of the list with a value added to the end.
+// It does not share any cells with the list. See Prepend.
+//
+// Time Complexity: O(n)
+func (l *List[V]) Add(value V) *List[V] {
+	return l.AddAt(l.Size(), value)
+}
+
+// AddAt returns a new version of the list with a value added at the specified index.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(i)
+func (l *List[V]) AddAt(index int, value V) *List[V] {
+	l.checkBounds(index, true)
+
+	prefix, rest := l.splitAt(index)
+	return l.with(prepend(&cell[V]{value: value, next: rest, size: size(rest) + 1}, prefix))
+}
+
+// AsList returns a read-only adapter of the list which implements
+// structs.List. Its methods which would modify the list panic.
+func (l *List[V]) AsList() *ReadOnlyList[V] {
+	return newReadOnly[V](l.eq, l)
+}
+
+// Contains returns whether the value is present in the list.
+//
+// Time Complexity: O(n)
+func (l *List[V]) Contains(value V) bool {
+	return l.IndexOf(value) >= 0
+}
+
+// Each calls a function for each value in the list, starting at the front of the list.
+func (l *List[V]) Each(f func(value V)) {
+	for c := l.head; c != nil; c = c.next {
+		f(c.value)
+	}
+}
+
+// First returns the value at the front of the list.
+//
+// Panics if the list is empty.
+//
+// Time Complexity: O(1)
+func (l *List[V]) First() V {
+	l.checkEmpty()
+
+	return l.head.value
+}
+
+// Get returns the value at the specified index.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(i)
+func (l *List[V]) Get(index int) V {
+	l.checkBounds(index, false)
+
+	return l.cellAt(index).value
+}
+
+// IndexOf returns the first index of a value in the list,
+// or -1 if the value is not present in the list.
+//
+// Time Complexity: O(n)
+func (l *List[V]) IndexOf(value V) int {
+	index := 0
+	for c := l.head; c != nil; c = c.next {
+		if l.eq(c.value, value) {
+			return index
+		}
+		index++
+	}
+	return -1
+}
+
+// IsEmpty returns whether the list is empty.
+func (l *List[V]) IsEmpty() bool {
+	return l.head == nil
+}
+
+// Iterator returns an Iterator for the list. Its Remove method panics.
+func (l *List[V]) Iterator() structs.Iterator[V] {
+	return l.iteratorFrom(0)
+}
+
+// LastIndexOf returns the last index of a value in the list,
+// or -1 if the value is not present in the list.
+//
+// Time Complexity: O(n)
+func (l *List[V]) LastIndexOf(value V) int {
+	last, index := -1, 0
+	for c := l.head; c != nil; c = c.next {
+		if l.eq(c.value, value) {
+			last = index
+		}
+		index++
+	}
+	return last
+}
+
+// Prepend returns a new version of the list with a value added
+// to the front, which shares every cell with the list.
+//
+// Time Complexity: O(1)
+func (l *List[V]) Prepend(value V) *List[V] {
+	return l.with(&cell[V]{value: value, next: l.head, size: l.Size() + 1})
+}
+
+// RemoveAt returns a new version of the list with the value at the specified index removed.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(i)
+func (l *List[V]) RemoveAt(index int) *List[V] {
+	l.checkBounds(index, false)
+
+	prefix, rest := l.splitAt(index)
+	return l.with(prepend(rest.next, prefix))
+}
+
+// Rest returns the list without its first value,
+// which shares every cell with the list.
+//
+// Panics if the list is empty.
+//
+// Time Complexity: O(1)
+func (l *List[V]) Rest() *List[V] {
+	l.checkEmpty()
+
+	return l.with(l.head.next)
+}
+
+// Reverse returns a new version of the list with its values in reverse order.
+//
+// Time Complexity: O(n)
+func (l *List[V]) Reverse() *List[V] {
+	var head *cell[V]
+	for c := l.head; c != nil; c = c.next {
+		head = &cell[V]{value: c.value, next: head, size: size(head) + 1}
+	}
+	return l.with(head)
+}
+
+// Set returns a new version of the list with the value at the specified index replaced.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(i)
+func (l *List[V]) Set(index int, value V) *List[V] {
+	l.checkBounds(index, false)
+
+	prefix, rest := l.splitAt(index)
+	return l.with(prepend(&cell[V]{value: value, next: rest.next, size: rest.size}, prefix))
+}
+
+// Size returns the number of values in the list.
+//
+// Time Complexity: O(1)
+func (l *List[V]) Size() int {
+	return size(l.head)
+}
+
+// String returns a string representation of the list, with brackets and comma separated.
+func (l *List[V]) String() string {
+	var buf strings.Builder
+	buf.WriteRune('[')
+	for c := l.head; c != nil; c = c.next {
+		if c != l.head {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(fmt.Sprint(c.value))
+	}
+	buf.WriteRune(']')
+	return buf.String()
+}
+
+// Values returns a slice of the values in the list, starting at the front of the list.
+//
+// Time Complexity: O(n)
+//
+// Space Complexity: O(n)
+func (l *List[V]) Values() []V {
+	values := make([]V, 0, l.Size())
+	for c := l.head; c != nil; c = c.next {
+		values = append(values, c.value)
+	}
+	return values
+}
+
+// with returns a list with the same equality function and the head provided.
+func (l *List[V]) with(head *cell[V]) *List[V] {
+	return &List[V]{
+		eq:   l.eq,
+		head: head,
+	}
+}
+
+// cellAt returns the cell at the index.
+func (l *List[V]) cellAt(index int) *cell[V] {
+	c := l.head
+	for i := 0; i < index; i++ {
+		c = c.next
+	}
+	return c
+}
+
+// splitAt returns the values before the index, and the cell at the index.
+func (l *List[V]) splitAt(index int) ([]V, *cell[V]) {
+	prefix := make([]V, index)
+	c := l.head
+	for i := range prefix {
+		prefix[i] = c.value
+		c = c.next
+	}
+	return prefix, c
+}
+
+func (l *List[V]) iteratorFrom(index int) structs.Iterator[V] {
+	return &listIterator[V]{
+		next: l.cellAt(index),
+	}
+}
+
+// checkEmpty panics if the list is empty.
+func (l *List[V]) checkEmpty() {
+	if l.IsEmpty() {
+		panic(structs.PanicNoSuchElement)
+	}
+}
+
+// checkBounds checks whether an index is within the bounds of the list.
+//
+// if allowEnd is true, index == l.Size() is allowed. this is useful
+// for operations which may insert after the list's end.
+func (l *List[V]) checkBounds(index int, allowEnd bool) {
+	size := l.Size()
+	if index < 0 || index > size || (!allowEnd && index == size) {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+}
+
+// prepend returns new cells holding the values, followed by the rest.
+func prepend[V any](rest *cell[V], values []V) *cell[V] {
+	for i := len(values) - 1; i >= 0; i-- {
+		rest = &cell[V]{value: values[i], next: rest, size: size(rest) + 1}
+	}
+	return rest
+}
+
+// size returns the number of cells from a cell to the end.
+func size[V any](c *cell[V]) int {
+	if c == nil {
+		return 0
+	}
+	return c.size
+}
+
+// listIterator is an iterator over the cells of a List.
+type listIterator[V any] struct {
+	next *cell[V]
+}
+
+func (it *listIterator[V]) HasNext() bool {
+	return it.next != nil
+}
+
+func (it *listIterator[V]) Next() V {
+	if it.next == nil {
+		panic(structs.PanicNoSuchElement)
+	}
+	value := it.next.value
+	it.next = it.next.next
+	return value
+}
+
+// Remove panics when called.
+func (it *listIterator[V]) Remove() {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// ListBuilder builds a List by adding values to its end in O(1),
+// which a List cannot do without copying itself.
+//
+// Once Build has been called, the builder may not be used again.
+type ListBuilder[V any] struct {
+	eq    structs.EqualFunc[V]
+	head  *cell[V]
+	last  *cell[V]
+	built bool
+}
+
+// NewListBuilder creates a ListBuilder for an empty List.
+func NewListBuilder[V any](eq structs.EqualFunc[V]) *ListBuilder[V] {
+	return &ListBuilder[V]{
+		eq: eq,
+	}
+}
+
+// NewOrderedListBuilder creates a ListBuilder for an empty List
+// of a type that implements constraints.Ordered.
+func NewOrderedListBuilder[V constraints.Ordered]() *ListBuilder[V] {
+	return NewListBuilder(structs.EqualOrdered[V])
+}
+
+// Add adds a value to the end of the list being built.
+//
+// Panics if Build has been called.
+//
+// Time Complexity: O(1)
+func (b *ListBuilder[V]) Add(value V) {
+	b.checkBuilt()
+
+	c := &cell[V]{value: value}
+	if b.last == nil {
+		b.head = c
+	} else {
+		b.last.next = c
+	}
+	b.last = c
+}
+
+// AddIterator adds all the values in the iterator to the end of the list being built.
+//
+// Panics if Build has been called.
+//
+// Time Complexity: O(m)
+func (b *ListBuilder[V]) AddIterator(iter structs.Iterator[V]) {
+	for iter.HasNext() {
+		b.Add(iter.Next())
+	}
+}
+
+// Build returns the List which was built.
+//
+// Panics if Build has already been called.
+//
+// Time Complexity: O(n)
+func (b *ListBuilder[V]) Build() *List[V] {
+	b.checkBuilt()
+	b.built = true
+
+	// the cells' sizes are only known once the end is
+	n := 0
+	for c := b.head; c != nil; c = c.next {
+		n++
+	}
+	for c := b.head; c != nil; c = c.next {
+		c.size = n
+		n--
+	}
+	return &List[V]{
+		eq:   b.eq,
+		head: b.head,
+	}
+}
+
+// checkBuilt panics if Build has been called.
+func (b *ListBuilder[V]) checkBuilt() {
+	if b.built {
+		panic(structs.PanicIllegalState)
+	}
+}
@@ -0,0 +1,103 @@
+package persistent
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/listtest"
+	"golang.org/x/exp/slices"
+	"math/rand"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	empty := NewOrderedList[int]()
+	one := empty.Prepend(1)
+	two := one.Add(2)
+	three := two.AddAt(0, 0)
+
+	listtest.ExpectValues(t, empty.Values(), nil)
+	listtest.ExpectValues(t, one.Values(), []int{1})
+	listtest.ExpectValues(t, two.Values(), []int{1, 2})
+	listtest.ExpectValues(t, three.Values(), []int{0, 1, 2})
+
+	if three.Rest().head != two.head {
+		t.Error("expected Rest to share cells")
+	}
+	if three.First() != 0 || three.Get(2) != 2 || three.IndexOf(2) != 2 {
+		t.Errorf("expected first value 0 and value 2 at index 2 but got %s", three)
+	}
+	listtest.ExpectValues(t, three.Reverse().Values(), []int{2, 1, 0})
+	if three.String() != "[0, 1, 2]" {
+		t.Errorf("expected string [0, 1, 2] but got %s", three)
+	}
+}
+
+func TestList_Sharing(t *testing.T) {
+	base := OrderedListOf(1, 2, 3, 4)
+	set := base.Set(1, 20)
+	removed := base.RemoveAt(1)
+
+	listtest.ExpectValues(t, base.Values(), []int{1, 2, 3, 4})
+	listtest.ExpectValues(t, set.Values(), []int{1, 20, 3, 4})
+	listtest.ExpectValues(t, removed.Values(), []int{1, 3, 4})
+	// the cells after the changed index are shared
+	if set.head.next.next != base.head.next.next || removed.head.next != base.head.next.next {
+		t.Error("expected the cells after the changed index to be shared")
+	}
+}
+
+func TestList_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	list := NewOrderedList[int]()
+	var versions []*List[int]
+	var models [][]int
+	var model []int
+	for i := 0; i < 500; i++ {
+		switch op := r.Intn(4); {
+		case op == 0 || len(model) == 0:
+			index := r.Intn(len(model) + 1)
+			list = list.AddAt(index, i)
+			model = slices.Insert(slices.Clone(model), index, i)
+		case op == 1:
+			index := r.Intn(len(model))
+			list = list.RemoveAt(index)
+			model = slices.Delete(slices.Clone(model), index, index+1)
+		case op == 2:
+			index := r.Intn(len(model))
+			list = list.Set(index, -i)
+			model = slices.Clone(model)
+			model[index] = -i
+		default:
+			list = list.Prepend(i)
+			model = append([]int{i}, model...)
+		}
+		versions = append(versions, list)
+		models = append(models, model)
+	}
+
+	// every version is unchanged by the versions after it
+	for i, version := range versions {
+		listtest.ExpectValues(t, version.Values(), models[i])
+		if version.Size() != len(models[i]) {
+			t.Fatalf("expected size %d but got %d", len(models[i]), version.Size())
+		}
+	}
+}
+
+func TestListBuilder(t *testing.T) {
+	b := NewOrderedListBuilder[int]()
+	for i := 0; i < 5; i++ {
+		b.Add(i)
+	}
+	list := b.Build()
+	listtest.ExpectValues(t, list.Values(), []int{0, 1, 2, 3, 4})
+	if list.Size() != 5 || list.Rest().Size() != 4 {
+		t.Errorf("expected sizes 5 and 4 but got %d and %d", list.Size(), list.Rest().Size())
+	}
+
+	defer func() {
+		if r := recover(); r != structs.PanicIllegalState {
+			t.Errorf("expected panic %q but got %v", structs.PanicIllegalState, r)
+		}
+	}()
+	b.Add(5)
+}
@@ -0,0 +1,253 @@
+package persistent
+
+import (
+	"fmt"
+	"github.com/zytekaron/structs"
+	"strings"
+)
+
+// source is a persistent collection which can be adapted to structs.List.
+type source[V any] interface {
+	Get(index int) V
+	Size() int
+	iteratorFrom(index int) structs.Iterator[V]
+}
+
+// ReadOnlyList is a read-only adapter of a List or Vector, or of
+// a range of one, which implements structs.List. Attempting to
+// mutate the list will result in a panic.
+//
+// Since the underlying collection is immutable,
+// the adapter never changes and is never invalidated.
+type ReadOnlyList[V any] struct {
+	eq     structs.EqualFunc[V]
+	src    source[V]
+	offset int
+	size   int
+}
+
+var _ structs.List[int] = (*ReadOnlyList[int])(nil)
+
+func newReadOnly[V any](eq structs.EqualFunc[V], src source[V]) *ReadOnlyList[V] {
+	return &ReadOnlyList[V]{
+		eq:   eq,
+		src:  src,
+		size: src.Size(),
+	}
+}
+
+// Add panics when called.
+func (r *ReadOnlyList[V]) Add(V) bool {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// AddAt panics when called.
+func (r *ReadOnlyList[V]) AddAt(int, V) {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// AddAll panics when called.
+func (r *ReadOnlyList[V]) AddAll(structs.Collection[V]) bool {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// AddIterator panics when called.
+func (r *ReadOnlyList[V]) AddIterator(structs.Iterator[V]) bool {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// Clear panics when called.
+func (r *ReadOnlyList[V]) Clear() {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// Contains returns whether the value is present in the list.
+//
+// Time Complexity: O(n)
+func (r *ReadOnlyList[V]) Contains(value V) bool {
+	return r.IndexOf(value) >= 0
+}
+
+// ContainsAll returns whether all the values in the other collection are present in the list.
+//
+// Time Complexity: O(nm)
+func (r *ReadOnlyList[V]) ContainsAll(other structs.Collection[V]) bool {
+	return r.ContainsIterator(other.Iterator())
+}
+
+// ContainsIterator returns whether all the values in the iterator are present in the list.
+//
+// Time Complexity: O(nm)
+func (r *ReadOnlyList[V]) ContainsIterator(iter structs.Iterator[V]) bool {
+	for iter.HasNext() {
+		if !r.Contains(iter.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Get returns the value at the specified index.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: that of Get on the underlying collection.
+func (r *ReadOnlyList[V]) Get(index int) V {
+	if index < 0 || index >= r.size {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+	return r.src.Get(r.offset + index)
+}
+
+// IndexOf returns the first index of a value in the list,
+// or -1 if the value is not present in the list.
+//
+// Time Complexity: O(n)
+func (r *ReadOnlyList[V]) IndexOf(value V) int {
+	index := 0
+	for it := r.Iterator(); it.HasNext(); {
+		if r.eq(it.Next(), value) {
+			return index
+		}
+		index++
+	}
+	return -1
+}
+
+// IsEmpty returns whether the list is empty.
+func (r *ReadOnlyList[V]) IsEmpty() bool {
+	return r.size == 0
+}
+
+// Iterator returns an Iterator for the list. Its Remove method panics.
+func (r *ReadOnlyList[V]) Iterator() structs.Iterator[V] {
+	return &limitIterator[V]{
+		iter:      r.src.iteratorFrom(r.offset),
+		remaining: r.size,
+	}
+}
+
+// LastIndexOf returns the last index of a value in the list,
+// or -1 if the value is not present in the list.
+//
+// Time Complexity: O(n)
+func (r *ReadOnlyList[V]) LastIndexOf(value V) int {
+	last, index := -1, 0
+	for it := r.Iterator(); it.HasNext(); {
+		if r.eq(it.Next(), value) {
+			last = index
+		}
+		index++
+	}
+	return last
+}
+
+// Remove panics when called.
+func (r *ReadOnlyList[V]) Remove(V) bool {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// RemoveAll panics when called.
+func (r *ReadOnlyList[V]) RemoveAll(structs.Collection[V]) bool {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// RemoveAt panics when called.
+func (r *ReadOnlyList[V]) RemoveAt(int) V {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// RemoveIterator panics when called.
+func (r *ReadOnlyList[V]) RemoveIterator(structs.Iterator[V]) bool {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// RetainAll panics when called.
+func (r *ReadOnlyList[V]) RetainAll(structs.Collection[V]) bool {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// Set panics when called.
+func (r *ReadOnlyList[V]) Set(int, V) V {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// Size returns the number of values in the list.
+//
+// Time Complexity: O(1)
+func (r *ReadOnlyList[V]) Size() int {
+	return r.size
+}
+
+// Sort panics when called.
+func (r *ReadOnlyList[V]) Sort(structs.LessFunc[V]) {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// String returns a string representation of the list, with brackets and comma separated.
+func (r *ReadOnlyList[V]) String() string {
+	var buf strings.Builder
+	buf.WriteRune('[')
+	for it := r.Iterator(); it.HasNext(); {
+		if buf.Len() > 1 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(fmt.Sprint(it.Next()))
+	}
+	buf.WriteRune(']')
+	return buf.String()
+}
+
+// SubList returns a read-only adapter of the list between the
+// from index, inclusive, and the to index, exclusive.
+//
+// Panics if the range is out of bounds.
+//
+// Time Complexity: O(1)
+func (r *ReadOnlyList[V]) SubList(from, to int) structs.List[V] {
+	if from < 0 || to > r.size || from > to {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+	return &ReadOnlyList[V]{
+		eq:     r.eq,
+		src:    r.src,
+		offset: r.offset + from,
+		size:   to - from,
+	}
+}
+
+// Values returns a slice of the values in the list.
+//
+// Time Complexity: O(n)
+//
+// Space Complexity: O(n)
+func (r *ReadOnlyList[V]) Values() []V {
+	values := make([]V, 0, r.size)
+	for it := r.Iterator(); it.HasNext(); {
+		values = append(values, it.Next())
+	}
+	return values
+}
+
+// limitIterator is an iterator over at most a number of values of another iterator.
+type limitIterator[V any] struct {
+	iter      structs.Iterator[V]
+	remaining int
+}
+
+func (it *limitIterator[V]) HasNext() bool {
+	return it.remaining > 0 && it.iter.HasNext()
+}
+
+func (it *limitIterator[V]) Next() V {
+	if it.remaining <= 0 {
+		panic(structs.PanicNoSuchElement)
+	}
+	it.remaining--
+	return it.iter.Next()
+}
+
+// Remove panics when called.
+func (it *limitIterator[V]) Remove() {
+	panic(structs.PanicUnsupportedOperation)
+}
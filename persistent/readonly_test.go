@@ -0,0 +1,46 @@
+package persistent
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/listtest"
+	"testing"
+)
+
+func TestReadOnlyList(t *testing.T) {
+	adapters := map[string]structs.List[int]{
+		"List":   OrderedListOf(0, 1, 2, 3, 4).AsList(),
+		"Vector": OrderedVectorOf(0, 1, 2, 3, 4).AsList(),
+	}
+	for name, l := range adapters {
+		t.Run(name, func(t *testing.T) {
+			listtest.ExpectValues(t, l.Values(), []int{0, 1, 2, 3, 4})
+			if l.Get(3) != 3 || l.IndexOf(4) != 4 || !l.Contains(0) {
+				t.Errorf("expected value 3 at index 3 but got %d", l.Get(3))
+			}
+
+			sub := l.SubList(1, 4).SubList(1, 3)
+			listtest.ExpectValues(t, sub.Values(), []int{2, 3})
+			if sub.Get(0) != 2 || sub.LastIndexOf(3) != 1 || sub.Contains(4) {
+				t.Errorf("expected values [2 3] but got %v", sub.Values())
+			}
+
+			mutators := map[string]func(){
+				"Add":       func() { l.Add(5) },
+				"AddAt":     func() { l.AddAt(0, 5) },
+				"Clear":     func() { l.Clear() },
+				"Remove":    func() { l.Remove(0) },
+				"RemoveAt":  func() { l.RemoveAt(0) },
+				"Set":       func() { l.Set(0, 5) },
+				"Sort":      func() { l.Sort(structs.LessOrdered[int]) },
+				"SubList":   func() { sub.Add(5) },
+				"Iterator":  func() { it := l.Iterator(); it.Next(); it.Remove() },
+				"RetainAll": func() { l.RetainAll(l) },
+			}
+			for method, f := range mutators {
+				t.Run(method, func(t *testing.T) {
+					listtest.ExpectPanic(t, structs.PanicUnsupportedOperation, f)
+				})
+			}
+		})
+	}
+}
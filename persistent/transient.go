@@ -0,0 +1,200 @@
+package persistent
+
+import "github.com/zytekaron/structs"
+
+// edit is a token identifying the TransientVector which owns a node.
+// It is not empty, since pointers to distinct zero-size values may
+// be equal.
+type edit struct {
+	_ byte
+}
+
+// TransientVector is a mutable version of a Vector for making many
+// changes at once. It copies each node it shares with a Vector the
+// first time it changes the node, and changes its own nodes in place,
+// so a batch of changes copies each node at most once.
+//
+// Calling Persistent returns a Vector with the changes, after which
+// the transient vector may not be used again.
+type TransientVector[V any] struct {
+	eq    structs.EqualFunc[V]
+	edit  *edit // nil once Persistent has been called
+	root  *vnode[V]
+	tail  []V // always width values
+	size  int
+	shift int
+}
+
+// NewTransientVector creates an empty TransientVector.
+func NewTransientVector[V any](eq structs.EqualFunc[V]) *TransientVector[V] {
+	return NewVector(eq).Transient()
+}
+
+// Add adds a value to the end of the vector.
+//
+// Panics if Persistent has been called.
+//
+// Time Complexity: O(1) amortized
+func (t *TransientVector[V]) Add(value V) {
+	t.checkEditable()
+
+	if t.size-tailOffset(t.size) < width {
+		t.tail[t.size&mask] = value
+		t.size++
+		return
+	}
+
+	// the tail is full, so it becomes a leaf of the trie
+	leaf := &vnode[V]{edit: t.edit, values: t.tail}
+	t.tail = make([]V, width)
+	t.tail[0] = value
+	if t.size>>bits > 1<<t.shift {
+		// the trie is full, so it becomes the first child of a new root
+		root := newBranch[V](t.edit)
+		root.children[0] = t.root
+		root.children[1] = newPath(t.edit, t.shift, leaf)
+		t.root = root
+		t.shift += bits
+	} else {
+		t.root = pushTail(t.edit, t.size, t.shift, t.root, leaf)
+	}
+	t.size++
+}
+
+// Get returns the value at the specified index.
+//
+// Panics if the index is out of bounds or Persistent has been called.
+//
+// Time Complexity: O(log32 n)
+func (t *TransientVector[V]) Get(index int) V {
+	t.checkEditable()
+	t.checkBounds(index)
+
+	if index >= tailOffset(t.size) {
+		return t.tail[index&mask]
+	}
+	return leafFor(t.root, t.shift, index)[index&mask]
+}
+
+// Persistent returns a Vector with the values of the transient vector.
+//
+// Panics if Persistent has already been called.
+//
+// Time Complexity: O(1)
+func (t *TransientVector[V]) Persistent() *Vector[V] {
+	t.checkEditable()
+	t.edit = nil
+
+	n := t.size - tailOffset(t.size)
+	return &Vector[V]{
+		eq:    t.eq,
+		root:  t.root,
+		tail:  t.tail[:n:n],
+		size:  t.size,
+		shift: t.shift,
+	}
+}
+
+// RemoveLast removes the last value from the vector.
+//
+// Panics if the vector is empty or Persistent has been called.
+//
+// Time Complexity: O(1) amortized
+func (t *TransientVector[V]) RemoveLast() {
+	t.checkEditable()
+	if t.size == 0 {
+		panic(structs.PanicNoSuchElement)
+	}
+
+	var null V
+	last := t.size - 1
+	if t.size == 1 || last&mask > 0 {
+		// the tail holds other values
+		t.tail[last&mask] = null
+		t.size--
+		return
+	}
+
+	// the tail is emptied, so the last leaf of the trie becomes the tail
+	tail := make([]V, width)
+	copy(tail, leafFor(t.root, t.shift, t.size-2))
+	root := popTail(t.edit, t.size, t.shift, t.root)
+	if root == nil {
+		root = newBranch[V](t.edit)
+	}
+	if t.shift > bits && root.children[1] == nil {
+		root = editable(t.edit, root.children[0])
+		t.shift -= bits
+	}
+	t.root = root
+	t.tail = tail
+	t.size--
+}
+
+// Set sets the value at the specified index.
+//
+// Panics if the index is out of bounds or Persistent has been called.
+//
+// Time Complexity: O(log32 n)
+func (t *TransientVector[V]) Set(index int, value V) {
+	t.checkEditable()
+	t.checkBounds(index)
+
+	if index >= tailOffset(t.size) {
+		t.tail[index&mask] = value
+		return
+	}
+	t.root = assoc(t.edit, t.shift, t.root, index, value)
+}
+
+// Size returns the number of values in the vector.
+//
+// Panics if Persistent has been called.
+func (t *TransientVector[V]) Size() int {
+	t.checkEditable()
+
+	return t.size
+}
+
+// truncate removes the values from the index onward.
+func (t *TransientVector[V]) truncate(index int) {
+	for t.size > index {
+		t.RemoveLast()
+	}
+}
+
+// checkEditable panics if Persistent has been called.
+func (t *TransientVector[V]) checkEditable() {
+	if t.edit == nil {
+		panic(structs.PanicIllegalState)
+	}
+}
+
+// checkBounds checks whether an index is within the bounds of the vector.
+func (t *TransientVector[V]) checkBounds(index int) {
+	if index < 0 || index >= t.size {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+}
+
+// popTail returns the node, made editable, with its last leaf
+// removed for a vector of the size provided, or nil if the node
+// would be left without any leaves.
+func popTail[V any](e *edit, size, level int, n *vnode[V]) *vnode[V] {
+	sub := ((size - 2) >> level) & mask
+	if level > bits {
+		child := popTail(e, size, level-bits, n.children[sub])
+		if child == nil && sub == 0 {
+			return nil
+		}
+		n = editable(e, n)
+		n.children[sub] = child
+		return n
+	}
+	if sub == 0 {
+		return nil
+	}
+	n = editable(e, n)
+	n.children[sub] = nil
+	return n
+}
@@ -0,0 +1,448 @@
+package persistent
+
+import (
+	"fmt"
+	"github.com/zytekaron/structs"
+	"golang.org/x/exp/constraints"
+	"strings"
+)
+
+const (
+	bits  = 5
+	width = 1 << bits
+	mask  = width - 1
+)
+
+// Vector is an immutable indexed sequence implemented as a 32-way
+// trie, whose last values are kept in a separate tail so that adding
+// to the end usually copies only the tail.
+//
+// Methods which change the vector return a new version, which shares
+// every node not on the path to the changed index with the old version.
+// Get, Set, Add and RemoveLast take O(log32 n), which is effectively
+// constant. AddAt and RemoveAt share the nodes before the index, and
+// take O(n-i), where i is the index.
+//
+// For many changes at once, use a TransientVector, which changes
+// the nodes it creates in place rather than copying them.
+//
+// The value equality function may be omitted (nil)
+// if no methods are called which use it.
+type Vector[V any] struct {
+	eq    structs.EqualFunc[V]
+	root  *vnode[V]
+	tail  []V
+	size  int
+	shift int // bits of the index consumed by the root's level
+}
+
+// vnode is a node of the trie backing a Vector. It is never modified
+// once shared, unless its edit token is that of a live TransientVector.
+type vnode[V any] struct {
+	edit     *edit
+	children []*vnode[V] // width children for branches, nil for leaves
+	values   []V         // width values for leaves, nil for branches
+}
+
+// NewVector creates an empty Vector.
+func NewVector[V any](eq structs.EqualFunc[V]) *Vector[V] {
+	return &Vector[V]{
+		eq:    eq,
+		root:  newBranch[V](nil),
+		shift: bits,
+	}
+}
+
+// NewOrderedVector creates an empty Vector from a type that implements constraints.Ordered.
+func NewOrderedVector[V constraints.Ordered]() *Vector[V] {
+	return NewVector(structs.EqualOrdered[V])
+}
+
+// VectorOf creates a Vector from an existing slice.
+func VectorOf[V any](eq structs.EqualFunc[V], values ...V) *Vector[V] {
+	t := NewVector(eq).Transient()
+	for _, value := range values {
+		t.Add(value)
+	}
+	return t.Persistent()
+}
+
+// OrderedVectorOf creates a Vector from an existing slice of a type that implements constraints.Ordered.
+func OrderedVectorOf[V constraints.Ordered](values ...V) *Vector[V] {
+	return VectorOf(structs.EqualOrdered[V], values...)
+}
+
+// Add returns a new version of the vector with a value added to the end.
+//
+// Time Complexity: O(log32 n)
+func (v *Vector[V]) Add(value V) *Vector[V] {
+	if v.size-v.tailOffset() < width {
+		tail := make([]V, len(v.tail)+1)
+		copy(tail, v.tail)
+		tail[len(v.tail)] = value
+		return &Vector[V]{eq: v.eq, root: v.root, tail: tail, size: v.size + 1, shift: v.shift}
+	}
+
+	// the tail is full, so it becomes a leaf of the trie
+	root, shift := v.root, v.shift
+	leaf := &vnode[V]{values: v.tail}
+	if v.size>>bits > 1<<v.shift {
+		// the trie is full, so it becomes the first child of a new root
+		root = newBranch[V](nil)
+		root.children[0] = v.root
+		root.children[1] = newPath(nil, v.shift, leaf)
+		shift += bits
+	} else {
+		root = pushTail(nil, v.size, v.shift, v.root, leaf)
+	}
+	return &Vector[V]{eq: v.eq, root: root, tail: []V{value}, size: v.size + 1, shift: shift}
+}
+
+// AddAt returns a new version of the vector with a value added at the specified index.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n-i)
+func (v *Vector[V]) AddAt(index int, value V) *Vector[V] {
+	v.checkBounds(index, true)
+	if index == v.size {
+		return v.Add(value)
+	}
+
+	rest := v.valuesFrom(index)
+	t := v.Transient()
+	t.truncate(index)
+	t.Add(value)
+	for _, val := range rest {
+		t.Add(val)
+	}
+	return t.Persistent()
+}
+
+// AsList returns a read-only adapter of the vector which implements
+// structs.List. Its methods which would modify the vector panic.
+func (v *Vector[V]) AsList() *ReadOnlyList[V] {
+	return newReadOnly[V](v.eq, v)
+}
+
+// Contains returns whether the value is present in the vector.
+//
+// Time Complexity: O(n)
+func (v *Vector[V]) Contains(value V) bool {
+	return v.IndexOf(value) >= 0
+}
+
+// Each calls a function for each value in the vector, starting at the front of the vector.
+func (v *Vector[V]) Each(f func(value V)) {
+	for i := 0; i < v.size; i += width {
+		for _, value := range v.leafFor(i)[:v.leafLen(i)] {
+			f(value)
+		}
+	}
+}
+
+// Get returns the value at the specified index.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(log32 n)
+func (v *Vector[V]) Get(index int) V {
+	v.checkBounds(index, false)
+
+	return v.leafFor(index)[index&mask]
+}
+
+// IndexOf returns the first index of a value in the vector,
+// or -1 if the value is not present in the vector.
+//
+// Time Complexity: O(n)
+func (v *Vector[V]) IndexOf(value V) int {
+	for i := 0; i < v.size; i += width {
+		for j, val := range v.leafFor(i)[:v.leafLen(i)] {
+			if v.eq(val, value) {
+				return i + j
+			}
+		}
+	}
+	return -1
+}
+
+// IsEmpty returns whether the vector is empty.
+func (v *Vector[V]) IsEmpty() bool {
+	return v.size == 0
+}
+
+// Iterator returns an Iterator for the vector. Its Remove method panics.
+func (v *Vector[V]) Iterator() structs.Iterator[V] {
+	return v.iteratorFrom(0)
+}
+
+// LastIndexOf returns the last index of a value in the vector,
+// or -1 if the value is not present in the vector.
+//
+// Time Complexity: O(n)
+func (v *Vector[V]) LastIndexOf(value V) int {
+	for i := v.size - 1; i >= 0; i-- {
+		if v.eq(v.leafFor(i)[i&mask], value) {
+			return i
+		}
+	}
+	return -1
+}
+
+// RemoveAt returns a new version of the vector with the value at the specified index removed.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n-i)
+func (v *Vector[V]) RemoveAt(index int) *Vector[V] {
+	v.checkBounds(index, false)
+	if index == v.size-1 {
+		return v.RemoveLast()
+	}
+
+	rest := v.valuesFrom(index + 1)
+	t := v.Transient()
+	t.truncate(index)
+	for _, val := range rest {
+		t.Add(val)
+	}
+	return t.Persistent()
+}
+
+// RemoveLast returns a new version of the vector with its last value removed.
+//
+// Panics if the vector is empty.
+//
+// Time Complexity: O(log32 n)
+func (v *Vector[V]) RemoveLast() *Vector[V] {
+	if v.size == 0 {
+		panic(structs.PanicNoSuchElement)
+	}
+	t := v.Transient()
+	t.RemoveLast()
+	return t.Persistent()
+}
+
+// Set returns a new version of the vector with the value at the specified index replaced.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(log32 n)
+func (v *Vector[V]) Set(index int, value V) *Vector[V] {
+	v.checkBounds(index, false)
+
+	if index >= v.tailOffset() {
+		tail := make([]V, len(v.tail))
+		copy(tail, v.tail)
+		tail[index&mask] = value
+		return &Vector[V]{eq: v.eq, root: v.root, tail: tail, size: v.size, shift: v.shift}
+	}
+	root := assoc(nil, v.shift, v.root, index, value)
+	return &Vector[V]{eq: v.eq, root: root, tail: v.tail, size: v.size, shift: v.shift}
+}
+
+// Size returns the number of values in the vector.
+//
+// Time Complexity: O(1)
+func (v *Vector[V]) Size() int {
+	return v.size
+}
+
+// String returns a string representation of the vector, with brackets and comma separated.
+func (v *Vector[V]) String() string {
+	var buf strings.Builder
+	buf.WriteRune('[')
+	v.Each(func(value V) {
+		if buf.Len() > 1 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(fmt.Sprint(value))
+	})
+	buf.WriteRune(']')
+	return buf.String()
+}
+
+// Transient returns a TransientVector with the same values as the vector,
+// which can be changed in place and then made persistent again.
+//
+// Time Complexity: O(1)
+func (v *Vector[V]) Transient() *TransientVector[V] {
+	e := &edit{}
+	root := &vnode[V]{edit: e, children: make([]*vnode[V], width)}
+	copy(root.children, v.root.children)
+	tail := make([]V, width)
+	copy(tail, v.tail)
+	return &TransientVector[V]{
+		eq:    v.eq,
+		edit:  e,
+		root:  root,
+		tail:  tail,
+		size:  v.size,
+		shift: v.shift,
+	}
+}
+
+// Values returns a slice of the values in the vector, starting at the front of the vector.
+//
+// Time Complexity: O(n)
+//
+// Space Complexity: O(n)
+func (v *Vector[V]) Values() []V {
+	return v.valuesFrom(0)
+}
+
+// tailOffset returns the index of the first value in the tail.
+func (v *Vector[V]) tailOffset() int {
+	return tailOffset(v.size)
+}
+
+// leafFor returns the values of the leaf or tail holding the index.
+func (v *Vector[V]) leafFor(index int) []V {
+	if index >= v.tailOffset() {
+		return v.tail
+	}
+	return leafFor(v.root, v.shift, index)
+}
+
+// leafLen returns the number of values in the leaf or tail holding the index.
+func (v *Vector[V]) leafLen(index int) int {
+	if index >= v.tailOffset() {
+		return v.size - v.tailOffset()
+	}
+	return width
+}
+
+// valuesFrom returns a slice of the values from the index onward.
+func (v *Vector[V]) valuesFrom(index int) []V {
+	values := make([]V, 0, v.size-index)
+	for it := v.iteratorFrom(index); it.HasNext(); {
+		values = append(values, it.Next())
+	}
+	return values
+}
+
+func (v *Vector[V]) iteratorFrom(index int) structs.Iterator[V] {
+	return &vectorIterator[V]{
+		vector: v,
+		index:  index,
+	}
+}
+
+// checkBounds checks whether an index is within the bounds of the vector.
+//
+// if allowEnd is true, index == v.size is allowed. this is useful
+// for operations which may insert after the vector's end.
+func (v *Vector[V]) checkBounds(index int, allowEnd bool) {
+	if index < 0 || index > v.size || (!allowEnd && index == v.size) {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+}
+
+// newBranch creates a branch node owned by the edit token.
+func newBranch[V any](e *edit) *vnode[V] {
+	return &vnode[V]{
+		edit:     e,
+		children: make([]*vnode[V], width),
+	}
+}
+
+// newPath creates a chain of branches down from the level to the leaf.
+func newPath[V any](e *edit, level int, leaf *vnode[V]) *vnode[V] {
+	if level == 0 {
+		return leaf
+	}
+	n := newBranch[V](e)
+	n.children[0] = newPath(e, level-bits, leaf)
+	return n
+}
+
+// tailOffset returns the index of the first value
+// in the tail of a vector of the size provided.
+func tailOffset(size int) int {
+	if size < width {
+		return 0
+	}
+	return (size - 1) >> bits << bits
+}
+
+// leafFor returns the values of the leaf of the trie holding the index.
+func leafFor[V any](n *vnode[V], shift, index int) []V {
+	for level := shift; level > 0; level -= bits {
+		n = n.children[(index>>level)&mask]
+	}
+	return n.values
+}
+
+// editable returns the node if it is owned by the edit
+// token, or a copy of it owned by the edit token otherwise.
+// A nil edit token never owns a node.
+func editable[V any](e *edit, n *vnode[V]) *vnode[V] {
+	if e != nil && n.edit == e {
+		return n
+	}
+	c := &vnode[V]{edit: e}
+	if n.children != nil {
+		c.children = make([]*vnode[V], width)
+		copy(c.children, n.children)
+	} else {
+		c.values = make([]V, width)
+		copy(c.values, n.values)
+	}
+	return c
+}
+
+// pushTail returns the node, made editable, with a full leaf added after
+// its last leaf, for a vector of the size provided whose tail is the leaf.
+func pushTail[V any](e *edit, size, level int, parent, leaf *vnode[V]) *vnode[V] {
+	n := editable(e, parent)
+	sub := ((size - 1) >> level) & mask
+	if level == bits {
+		n.children[sub] = leaf
+	} else if child := parent.children[sub]; child != nil {
+		n.children[sub] = pushTail(e, size, level-bits, child, leaf)
+	} else {
+		n.children[sub] = newPath(e, level-bits, leaf)
+	}
+	return n
+}
+
+// assoc returns the node, made editable, with the value at the index replaced.
+func assoc[V any](e *edit, level int, n *vnode[V], index int, value V) *vnode[V] {
+	c := editable(e, n)
+	if level == 0 {
+		c.values[index&mask] = value
+		return c
+	}
+	sub := (index >> level) & mask
+	c.children[sub] = assoc(e, level-bits, n.children[sub], index, value)
+	return c
+}
+
+// vectorIterator is an iterator over a Vector, which finds each leaf only once.
+type vectorIterator[V any] struct {
+	vector *Vector[V]
+	leaf   []V
+	index  int
+}
+
+func (it *vectorIterator[V]) HasNext() bool {
+	return it.index < it.vector.size
+}
+
+func (it *vectorIterator[V]) Next() V {
+	if it.index >= it.vector.size {
+		panic(structs.PanicNoSuchElement)
+	}
+	if it.leaf == nil || it.index&mask == 0 {
+		it.leaf = it.vector.leafFor(it.index)
+	}
+	value := it.leaf[it.index&mask]
+	it.index++
+	return value
+}
+
+// Remove panics when called.
+func (it *vectorIterator[V]) Remove() {
+	panic(structs.PanicUnsupportedOperation)
+}
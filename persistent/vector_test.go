@@ -0,0 +1,117 @@
+package persistent
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/listtest"
+	"golang.org/x/exp/slices"
+	"math/rand"
+	"testing"
+)
+
+func TestVector(t *testing.T) {
+	v := NewOrderedVector[int]()
+	var versions []*Vector[int]
+	for i := 0; i < 5000; i++ {
+		versions = append(versions, v)
+		v = v.Add(i)
+	}
+
+	for i, version := range versions {
+		if version.Size() != i {
+			t.Fatalf("expected size %d but got %d", i, version.Size())
+		}
+	}
+	for i := 0; i < v.Size(); i++ {
+		if v.Get(i) != i {
+			t.Fatalf("expected value %d at index %d but got %d", i, i, v.Get(i))
+		}
+	}
+
+	// remove every value from the end, checking each version
+	for i := v.Size() - 1; i >= 0; i-- {
+		v = v.RemoveLast()
+		expect := versions[i]
+		if v.Size() != expect.Size() || (i > 0 && v.Get(i-1) != i-1) {
+			t.Fatalf("expected size %d but got %d", expect.Size(), v.Size())
+		}
+	}
+	if !v.IsEmpty() {
+		t.Errorf("expected an empty vector but got %d values", v.Size())
+	}
+}
+
+func TestVector_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	v := NewOrderedVector[int]()
+	var versions []*Vector[int]
+	var models [][]int
+	var model []int
+	for i := 0; i < 6000; i++ {
+		switch op := r.Intn(8); {
+		case op < 3 || len(model) == 0:
+			v = v.Add(i)
+			model = append(slices.Clone(model), i)
+		case op < 4:
+			index := r.Intn(len(model) + 1)
+			v = v.AddAt(index, i)
+			model = slices.Insert(slices.Clone(model), index, i)
+		case op < 5:
+			index := r.Intn(len(model))
+			v = v.RemoveAt(index)
+			model = slices.Delete(slices.Clone(model), index, index+1)
+		case op < 6:
+			v = v.RemoveLast()
+			model = slices.Clone(model[:len(model)-1])
+		default:
+			index := r.Intn(len(model))
+			v = v.Set(index, -i)
+			model = slices.Clone(model)
+			model[index] = -i
+		}
+		versions = append(versions, v)
+		models = append(models, model)
+	}
+
+	// every version is unchanged by the versions after it
+	for i, version := range versions {
+		listtest.ExpectValues(t, version.Values(), models[i])
+	}
+	if i := len(model) / 2; v.IndexOf(model[i]) != slices.Index(model, model[i]) {
+		t.Errorf("expected index of %d to be %d but got %d", model[i], slices.Index(model, model[i]), v.IndexOf(model[i]))
+	}
+}
+
+func TestTransientVector(t *testing.T) {
+	base := OrderedVectorOf(0, 1, 2)
+
+	tv := base.Transient()
+	for i := 3; i < 2000; i++ {
+		tv.Add(i)
+	}
+	tv.Set(0, -1)
+	tv.Set(1999, -2)
+	for i := 0; i < 100; i++ {
+		tv.RemoveLast()
+	}
+	v := tv.Persistent()
+
+	listtest.ExpectValues(t, base.Values(), []int{0, 1, 2})
+	if v.Size() != 1900 || v.Get(0) != -1 || v.Get(1899) != 1899 {
+		t.Errorf("expected 1900 values from -1 to 1899 but got %d values", v.Size())
+	}
+
+	// changing another transient of the result leaves it unchanged
+	other := v.Transient()
+	other.Set(500, -3)
+	other.Add(1900)
+	if v.Get(500) != 500 || v.Size() != 1900 {
+		t.Errorf("expected the vector to be unchanged but got %d at index 500", v.Get(500))
+	}
+
+	defer func() {
+		if r := recover(); r != structs.PanicIllegalState {
+			t.Errorf("expected panic %q but got %v", structs.PanicIllegalState, r)
+		}
+	}()
+	tv.Add(0)
+}
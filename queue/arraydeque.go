@@ -0,0 +1,667 @@
+package queue
+
+import (
+	"github.com/zytekaron/structs"
+	"golang.org/x/exp/constraints"
+)
+
+// ArrayDeque is an implementation of a double-ended queue
+// backed by a circular buffer which grows as needed.
+//
+// The value equality function may be omitted (nil)
+// if no methods are called which use it.
+type ArrayDeque[V any] struct {
+	capFn    structs.CapacityFunc
+	shrinkFn structs.CapacityFunc // nil to never shrink
+	eq       structs.EqualFunc[V]
+	data     []V
+	head     int // physical index of the first value
+	size     int
+	// modCount is incremented whenever the deque is structurally
+	// modified, which allows iterators to detect concurrent changes.
+	modCount int
+}
+
+var _ structs.Deque[int] = (*ArrayDeque[int])(nil)
+
+// NewArrayDeque creates an empty ArrayDeque.
+func NewArrayDeque[V any](eq structs.EqualFunc[V]) *ArrayDeque[V] {
+	return NewArrayDequeCap(0, eq)
+}
+
+// NewOrderedArrayDeque creates an empty ArrayDeque from a type that implements constraints.Ordered.
+func NewOrderedArrayDeque[V constraints.Ordered]() *ArrayDeque[V] {
+	return NewArrayDequeCap(0, structs.EqualOrdered[V])
+}
+
+// NewArrayDequeCap creates an empty ArrayDeque with an initial capacity.
+func NewArrayDequeCap[V any](capacity int, eq structs.EqualFunc[V]) *ArrayDeque[V] {
+	return &ArrayDeque[V]{
+		capFn: structs.DoubleCapacity,
+		eq:    eq,
+		data:  make([]V, capacity),
+	}
+}
+
+// NewOrderedArrayDequeCap creates an empty ArrayDeque with an initial
+// capacity from a type that implements constraints.Ordered.
+func NewOrderedArrayDequeCap[V constraints.Ordered](capacity int) *ArrayDeque[V] {
+	return NewArrayDequeCap(capacity, structs.EqualOrdered[V])
+}
+
+// SetCapFunc sets the function used to increase the capacity of the deque.
+func (d *ArrayDeque[V]) SetCapFunc(capFn structs.CapacityFunc) {
+	d.capFn = capFn
+}
+
+// SetShrinkFunc sets the function used to decrease the capacity of
+// the deque after values are removed, for example structs.HalveCapacity.
+// If it is nil, which is the default, the deque never shrinks.
+func (d *ArrayDeque[V]) SetShrinkFunc(shrinkFn structs.CapacityFunc) {
+	d.shrinkFn = shrinkFn
+}
+
+// Add adds a value to the end of the deque.
+//
+// Time Complexity: amortized O(1)
+func (d *ArrayDeque[V]) Add(value V) bool {
+	return d.AddLast(value)
+}
+
+// AddAll adds all the values in the other collection to the end of the deque.
+//
+// Time Complexity: O(m)
+func (d *ArrayDeque[V]) AddAll(other structs.Collection[V]) bool {
+	d.grow(d.size + other.Size())
+	return d.AddIterator(other.Iterator())
+}
+
+// AddIterator adds all the values in the iterator to the end of the deque.
+//
+// Time Complexity: O(m)
+func (d *ArrayDeque[V]) AddIterator(iter structs.Iterator[V]) bool {
+	changed := iter.HasNext()
+	for iter.HasNext() {
+		d.AddLast(iter.Next())
+	}
+	return changed
+}
+
+// AddFirst adds a value to the front of the deque.
+//
+// Time Complexity: amortized O(1)
+func (d *ArrayDeque[V]) AddFirst(value V) bool {
+	d.grow(d.size + 1)
+	d.head = d.physical(-1)
+	d.data[d.head] = value
+	d.size++
+	d.modCount++
+	return true
+}
+
+// AddLast adds a value to the end of the deque.
+//
+// Time Complexity: amortized O(1)
+func (d *ArrayDeque[V]) AddLast(value V) bool {
+	d.grow(d.size + 1)
+	d.data[d.physical(d.size)] = value
+	d.size++
+	d.modCount++
+	return true
+}
+
+// Cap returns the capacity of the deque.
+func (d *ArrayDeque[V]) Cap() int {
+	return len(d.data)
+}
+
+// Clear clears the deque. Any references held by
+// the deque are released, so they may be garbage collected.
+//
+// Time Complexity: O(n)
+func (d *ArrayDeque[V]) Clear() {
+	var null V
+	for i := 0; i < d.size; i++ {
+		d.data[d.physical(i)] = null
+	}
+	d.head = 0
+	d.size = 0
+	d.modCount++
+	d.shrink()
+}
+
+// Contains returns whether the value is present in the deque.
+//
+// Time Complexity: O(n)
+func (d *ArrayDeque[V]) Contains(value V) bool {
+	return d.IndexOf(value) >= 0
+}
+
+// ContainsAll returns whether all the values in the other collection are present in the deque.
+//
+// Time Complexity: O(nm)
+func (d *ArrayDeque[V]) ContainsAll(other structs.Collection[V]) bool {
+	return d.ContainsIterator(other.Iterator())
+}
+
+// ContainsIterator returns whether all the values in the iterator are present in the deque.
+//
+// Time Complexity: O(nm)
+func (d *ArrayDeque[V]) ContainsIterator(iter structs.Iterator[V]) bool {
+	for iter.HasNext() {
+		if !d.Contains(iter.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Element returns the value at the front of the deque.
+//
+// Panics if the deque is empty.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) Element() V {
+	return d.GetFirst()
+}
+
+// Get returns the value at the specified index.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) Get(index int) V {
+	d.checkBounds(index)
+
+	return d.data[d.physical(index)]
+}
+
+// GetFirst returns the value at the front of the deque.
+//
+// Panics if the deque is empty.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) GetFirst() V {
+	d.checkEmpty()
+
+	return d.data[d.head]
+}
+
+// GetLast returns the value at the end of the deque.
+//
+// Panics if the deque is empty.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) GetLast() V {
+	d.checkEmpty()
+
+	return d.data[d.physical(d.size-1)]
+}
+
+// IndexOf returns the first index of a value in the deque,
+// or -1 if the value is not present in the deque.
+//
+// Time Complexity: O(n)
+func (d *ArrayDeque[V]) IndexOf(value V) int {
+	for i := 0; i < d.size; i++ {
+		if d.eq(d.data[d.physical(i)], value) {
+			return i
+		}
+	}
+	return -1
+}
+
+// LastIndexOf returns the last index of a value in the deque,
+// or -1 if the value is not present in the deque.
+//
+// Time Complexity: O(n)
+func (d *ArrayDeque[V]) LastIndexOf(value V) int {
+	for i := d.size - 1; i >= 0; i-- {
+		if d.eq(d.data[d.physical(i)], value) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsEmpty returns whether the deque is empty.
+func (d *ArrayDeque[V]) IsEmpty() bool {
+	return d.size == 0
+}
+
+// Iterator returns an Iterator over the deque, starting at the front.
+func (d *ArrayDeque[V]) Iterator() structs.Iterator[V] {
+	return &ArrayDequeIterator[V]{
+		deque:            d,
+		next:             0,
+		last:             -1,
+		expectedModCount: d.modCount,
+	}
+}
+
+// DescendingIterator returns an Iterator over the deque, starting at the end.
+func (d *ArrayDeque[V]) DescendingIterator() structs.Iterator[V] {
+	return &ArrayDequeIterator[V]{
+		deque:            d,
+		next:             d.size - 1,
+		last:             -1,
+		descending:       true,
+		expectedModCount: d.modCount,
+	}
+}
+
+// Offer adds a value to the end of the deque.
+//
+// Time Complexity: amortized O(1)
+func (d *ArrayDeque[V]) Offer(value V) bool {
+	return d.AddLast(value)
+}
+
+// OfferFirst adds a value to the front of the deque.
+//
+// Time Complexity: amortized O(1)
+func (d *ArrayDeque[V]) OfferFirst(value V) bool {
+	return d.AddFirst(value)
+}
+
+// OfferLast adds a value to the end of the deque.
+//
+// Time Complexity: amortized O(1)
+func (d *ArrayDeque[V]) OfferLast(value V) bool {
+	return d.AddLast(value)
+}
+
+// Peek returns the first value in the deque, or
+// the zero value of the type if the deque is empty.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) Peek() V {
+	return d.PeekFirst()
+}
+
+// PeekFirst returns the first value in the deque, or
+// the zero value of the type if the deque is empty.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) PeekFirst() V {
+	if d.IsEmpty() {
+		var null V
+		return null
+	}
+	return d.data[d.head]
+}
+
+// PeekLast returns the last value in the deque, or
+// the zero value of the type if the deque is empty.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) PeekLast() V {
+	if d.IsEmpty() {
+		var null V
+		return null
+	}
+	return d.data[d.physical(d.size-1)]
+}
+
+// Poll removes and returns the first value in the deque,
+// or returns the zero value of the type if the deque is empty.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) Poll() V {
+	return d.PollFirst()
+}
+
+// PollFirst removes and returns the first value in the deque,
+// or returns the zero value of the type if the deque is empty.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) PollFirst() V {
+	if d.IsEmpty() {
+		var null V
+		return null
+	}
+	return d.removeAt(0)
+}
+
+// PollLast removes and returns the last value in the deque,
+// or returns the zero value of the type if the deque is empty.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) PollLast() V {
+	if d.IsEmpty() {
+		var null V
+		return null
+	}
+	return d.removeAt(d.size - 1)
+}
+
+// Pop removes and returns the first value in the deque.
+//
+// Panics if the deque is empty.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) Pop() V {
+	return d.RemoveFirst()
+}
+
+// Push adds a value to the front of the deque.
+//
+// Time Complexity: amortized O(1)
+func (d *ArrayDeque[V]) Push(value V) {
+	d.AddFirst(value)
+}
+
+// Remove removes the first occurrence of a value from
+// the deque and returns whether the value was present.
+//
+// Time Complexity: O(n)
+func (d *ArrayDeque[V]) Remove(value V) bool {
+	return d.RemoveFirstOccurrence(value)
+}
+
+// RemoveAll removes all the values in the other collection from the deque.
+//
+// Time Complexity: O(nm)
+func (d *ArrayDeque[V]) RemoveAll(other structs.Collection[V]) bool {
+	return d.RemoveIterator(other.Iterator())
+}
+
+// RemoveAt removes the value at the specified index from the deque and returns it.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(min(i, n-i))
+func (d *ArrayDeque[V]) RemoveAt(index int) V {
+	d.checkBounds(index)
+
+	return d.removeAt(index)
+}
+
+// RemoveHead removes and returns the first value in the deque.
+//
+// Panics if the deque is empty.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) RemoveHead() V {
+	return d.RemoveFirst()
+}
+
+// RemoveFirst removes and returns the first value in the deque.
+//
+// Panics if the deque is empty.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) RemoveFirst() V {
+	d.checkEmpty()
+
+	return d.removeAt(0)
+}
+
+// RemoveFirstOccurrence removes the first occurrence of a value
+// from the deque and returns whether the value was present.
+//
+// Time Complexity: O(n)
+func (d *ArrayDeque[V]) RemoveFirstOccurrence(value V) bool {
+	i := d.IndexOf(value)
+	if i < 0 {
+		return false
+	}
+	d.removeAt(i)
+	return true
+}
+
+// RemoveIterator removes all the values in the iterator from the deque.
+//
+// Time Complexity: O(nm)
+func (d *ArrayDeque[V]) RemoveIterator(iter structs.Iterator[V]) bool {
+	changed := false
+	for iter.HasNext() {
+		if d.Remove(iter.Next()) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// RemoveLast removes and returns the last value in the deque.
+//
+// Panics if the deque is empty.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) RemoveLast() V {
+	d.checkEmpty()
+
+	return d.removeAt(d.size - 1)
+}
+
+// RemoveLastOccurrence removes the last occurrence of a value
+// from the deque and returns whether the value was present.
+//
+// Time Complexity: O(n)
+func (d *ArrayDeque[V]) RemoveLastOccurrence(value V) bool {
+	i := d.LastIndexOf(value)
+	if i < 0 {
+		return false
+	}
+	d.removeAt(i)
+	return true
+}
+
+// RetainAll removes all the values not present in the other collection from the deque.
+//
+// Time Complexity: O(nm)
+//
+//	n = size of the deque
+//	m = time complexity of Contains on the other collection
+func (d *ArrayDeque[V]) RetainAll(other structs.Collection[V]) bool {
+	// compact the kept values toward the front in a single pass
+	kept := 0
+	for i := 0; i < d.size; i++ {
+		value := d.data[d.physical(i)]
+		if other.Contains(value) {
+			d.data[d.physical(kept)] = value
+			kept++
+		}
+	}
+	if kept == d.size {
+		return false
+	}
+
+	var null V
+	for i := kept; i < d.size; i++ {
+		d.data[d.physical(i)] = null
+	}
+	d.size = kept
+	d.modCount++
+	d.shrink()
+	return true
+}
+
+// Set sets the value at the specified index, returning the old value.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) Set(index int, value V) V {
+	d.checkBounds(index)
+
+	i := d.physical(index)
+	oldValue := d.data[i]
+	d.data[i] = value
+	return oldValue
+}
+
+// Size returns the number of values in the deque.
+//
+// Time Complexity: O(1)
+func (d *ArrayDeque[V]) Size() int {
+	return d.size
+}
+
+// Values returns a slice of the values in the deque, starting at the front.
+//
+// Time Complexity: O(n)
+//
+// Space Complexity: O(n)
+func (d *ArrayDeque[V]) Values() []V {
+	values := make([]V, d.size)
+	d.copyTo(values)
+	return values
+}
+
+// physical returns the index in the data of the value at the logical index,
+// which may be -1 to refer to the slot before the head.
+func (d *ArrayDeque[V]) physical(index int) int {
+	i := d.head + index
+	if i < 0 {
+		return i + len(d.data)
+	}
+	if i >= len(d.data) {
+		return i - len(d.data)
+	}
+	return i
+}
+
+// removeAt removes the value at the logical index, shifting whichever
+// side of the deque is shorter to close the gap. Values after the
+// index always move one index toward the front.
+func (d *ArrayDeque[V]) removeAt(index int) V {
+	value := d.data[d.physical(index)]
+	if index < d.size/2 {
+		// shift the front values back by one
+		for i := index; i > 0; i-- {
+			d.data[d.physical(i)] = d.data[d.physical(i-1)]
+		}
+		var null V
+		d.data[d.head] = null
+		d.head = d.physical(1)
+	} else {
+		// shift the end values forward by one
+		for i := index; i < d.size-1; i++ {
+			d.data[d.physical(i)] = d.data[d.physical(i+1)]
+		}
+		var null V
+		d.data[d.physical(d.size-1)] = null
+	}
+	d.size--
+	if d.size == 0 {
+		d.head = 0
+	}
+	d.modCount++
+	d.shrink()
+	return value
+}
+
+// grow reallocates the data if it cannot hold need values.
+func (d *ArrayDeque[V]) grow(need int) {
+	if need > len(d.data) {
+		capacity := d.capFn(len(d.data), need)
+		if capacity < need {
+			capacity = need
+		}
+		d.realloc(capacity)
+	}
+}
+
+// shrink reallocates the data with a smaller
+// capacity if the shrink function permits it.
+func (d *ArrayDeque[V]) shrink() {
+	if d.shrinkFn == nil {
+		return
+	}
+	size := d.shrinkFn(len(d.data), d.size)
+	if size < d.size {
+		size = d.size
+	}
+	if size < len(d.data) {
+		d.realloc(size)
+	}
+}
+
+// realloc reallocates the data with the new capacity,
+// moving the values so the head is at index 0.
+func (d *ArrayDeque[V]) realloc(capacity int) {
+	data := make([]V, capacity)
+	d.copyTo(data)
+	d.data = data
+	d.head = 0
+}
+
+// copyTo copies the values in the deque, in order, to the slice.
+func (d *ArrayDeque[V]) copyTo(s []V) {
+	end := d.head + d.size
+	if end > len(d.data) {
+		end = len(d.data)
+	}
+	n := copy(s, d.data[d.head:end])
+	copy(s[n:], d.data[:d.size-n])
+}
+
+// checkEmpty panics if the deque is empty.
+func (d *ArrayDeque[V]) checkEmpty() {
+	if d.IsEmpty() {
+		panic(structs.PanicNoSuchElement)
+	}
+}
+
+// checkBounds panics if the index is not within the bounds of the deque.
+func (d *ArrayDeque[V]) checkBounds(index int) {
+	if index < 0 || index >= d.size {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+}
+
+// ArrayDequeIterator is an iterator over the values of an ArrayDeque.
+type ArrayDequeIterator[V any] struct {
+	deque      *ArrayDeque[V]
+	next       int // logical index of the next value
+	last       int // logical index of the last value returned, or -1
+	descending bool
+
+	expectedModCount int
+}
+
+func (it *ArrayDequeIterator[V]) HasNext() bool {
+	if it.descending {
+		return it.next >= 0
+	}
+	return it.next < it.deque.size
+}
+
+func (it *ArrayDequeIterator[V]) Next() V {
+	it.checkModCount()
+	if !it.HasNext() {
+		panic(structs.PanicNoSuchElement)
+	}
+
+	it.last = it.next
+	if it.descending {
+		it.next--
+	} else {
+		it.next++
+	}
+	return it.deque.data[it.deque.physical(it.last)]
+}
+
+func (it *ArrayDequeIterator[V]) Remove() {
+	it.checkModCount()
+	if it.last < 0 {
+		panic(structs.PanicIllegalState)
+	}
+
+	it.deque.removeAt(it.last)
+	// values after the removed value move one index toward the front
+	if !it.descending {
+		it.next = it.last
+	}
+	it.last = -1
+	it.expectedModCount = it.deque.modCount
+}
+
+// checkModCount panics if the deque was modified
+// other than through this iterator.
+func (it *ArrayDequeIterator[V]) checkModCount() {
+	if it.deque.modCount != it.expectedModCount {
+		panic(structs.PanicConcurrentModification)
+	}
+}
@@ -0,0 +1,119 @@
+package queue
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/dequetest"
+	"golang.org/x/exp/slices"
+	"testing"
+)
+
+func TestArrayDeque(t *testing.T) {
+	dequetest.Run(t, func() structs.Deque[int] {
+		return NewOrderedArrayDeque[int]()
+	})
+}
+
+func TestArrayDeque_Shrink(t *testing.T) {
+	dequetest.Run(t, func() structs.Deque[int] {
+		d := NewOrderedArrayDequeCap[int](4)
+		d.SetShrinkFunc(structs.HalveCapacity)
+		return d
+	})
+}
+
+func TestArrayDeque_Get(t *testing.T) {
+	d := NewOrderedArrayDequeCap[int](4)
+	// wrap around the end of the buffer
+	d.AddLast(2)
+	d.AddLast(3)
+	d.AddFirst(1)
+	d.AddFirst(0)
+
+	for i := 0; i < 4; i++ {
+		if got := d.Get(i); got != i {
+			t.Errorf("expected %d at index %d but got %d", i, i, got)
+		}
+	}
+	if old := d.Set(2, 20); old != 2 {
+		t.Errorf("expected old value 2 but got %d", old)
+	}
+	if got := d.RemoveAt(1); got != 1 {
+		t.Errorf("expected removed value 1 but got %d", got)
+	}
+
+	expect := []int{0, 20, 3}
+	if !slices.Equal(d.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, d.Values())
+	}
+
+	defer func() {
+		if r := recover(); r != structs.PanicIndexOutOfBounds {
+			t.Errorf("expected panic %q but got %v", structs.PanicIndexOutOfBounds, r)
+		}
+	}()
+	d.Get(3)
+}
+
+func TestArrayDeque_Capacity(t *testing.T) {
+	d := NewOrderedArrayDeque[int]()
+	d.SetShrinkFunc(structs.HalveCapacity)
+	for i := 0; i < 64; i++ {
+		d.AddLast(i)
+	}
+	if d.Cap() != 64 {
+		t.Errorf("expected capacity 64 but got %d", d.Cap())
+	}
+	for i := 0; i < 60; i++ {
+		d.PollFirst()
+	}
+	if d.Cap() >= 64 {
+		t.Errorf("expected capacity to shrink below 64 but got %d", d.Cap())
+	}
+	expect := []int{60, 61, 62, 63}
+	if !slices.Equal(d.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, d.Values())
+	}
+}
+
+func TestArrayDeque_CapacityClamped(t *testing.T) {
+	d := NewOrderedArrayDeque[int]()
+	// capacity functions returning too little are clamped
+	// to the number of values the deque needs to hold
+	d.SetCapFunc(func(before, need int) int { return 0 })
+	d.SetShrinkFunc(func(before, need int) int { return 0 })
+	for i := 0; i < 8; i++ {
+		d.AddLast(i)
+	}
+	d.PollFirst()
+	expect := []int{1, 2, 3, 4, 5, 6, 7}
+	if !slices.Equal(d.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, d.Values())
+	}
+	if d.Cap() != 7 {
+		t.Errorf("expected capacity 7 but got %d", d.Cap())
+	}
+}
+
+func TestArrayDeque_DescendingIterator(t *testing.T) {
+	d := NewOrderedArrayDeque[int]()
+	for i := 0; i < 6; i++ {
+		d.AddLast(i)
+	}
+
+	var got []int
+	it := d.DescendingIterator()
+	for it.HasNext() {
+		value := it.Next()
+		got = append(got, value)
+		if value%2 == 0 {
+			it.Remove()
+		}
+	}
+
+	if expect := []int{5, 4, 3, 2, 1, 0}; !slices.Equal(got, expect) {
+		t.Errorf("expected iterated values %v but got %v", expect, got)
+	}
+	if expect := []int{1, 3, 5}; !slices.Equal(d.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, d.Values())
+	}
+}
@@ -0,0 +1,269 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/list"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned when adding a value to a closed queue,
+// or when taking a value from a closed queue which is empty.
+var ErrClosed = errors.New("queue closed")
+
+// BlockingQueue is an implementation of a FIFO queue which is safe
+// for concurrent use, backed by *list.List. Taking a value blocks
+// until one is available, and if the queue has a capacity, putting
+// a value blocks until there is space for it.
+//
+// Once closed, no more values may be added, but the values which
+// remain in the queue may still be taken until it is empty.
+type BlockingQueue[V any] struct {
+	mu       sync.Mutex
+	data     *list.List[V]
+	capacity int
+	closed   bool
+	// notEmpty and notFull wake the callers waiting in Take and Put.
+	// They share mu, so each wakes only the callers which can proceed.
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+}
+
+// NewBlocking creates an empty BlockingQueue which holds at most
+// capacity values, or an unbounded number if capacity is 0 or less.
+func NewBlocking[V any](capacity int) *BlockingQueue[V] {
+	if capacity < 0 {
+		capacity = 0
+	}
+	q := &BlockingQueue[V]{
+		data:     list.New[V](nil),
+		capacity: capacity,
+	}
+	q.notEmpty = sync.NewCond(&q.mu)
+	q.notFull = sync.NewCond(&q.mu)
+	return q
+}
+
+// Put adds a value to the end of the queue, waiting
+// for space to become available if the queue is full.
+//
+// Returns ErrClosed if the queue is closed, or the context's
+// error if the context is done before the value is added.
+func (q *BlockingQueue[V]) Put(ctx context.Context, value V) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.isFull() && !q.closed {
+		defer q.watch(ctx, q.notFull)()
+		for q.isFull() && !q.closed {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			q.notFull.Wait()
+		}
+	}
+	if q.closed {
+		return ErrClosed
+	}
+	q.data.AddLast(value)
+	q.notEmpty.Signal()
+	return nil
+}
+
+// Take removes and returns the value at the head of the
+// queue, waiting for a value to be added if it is empty.
+//
+// Returns ErrClosed if the queue is closed and empty, or the context's
+// error if the context is done before a value is available.
+func (q *BlockingQueue[V]) Take(ctx context.Context) (V, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.data.IsEmpty() && !q.closed {
+		defer q.watch(ctx, q.notEmpty)()
+		for q.data.IsEmpty() && !q.closed {
+			if err := ctx.Err(); err != nil {
+				var null V
+				return null, err
+			}
+			q.notEmpty.Wait()
+		}
+	}
+	if q.data.IsEmpty() {
+		var null V
+		return null, ErrClosed
+	}
+	value := q.data.RemoveFirst()
+	q.notFull.Signal()
+	return value, nil
+}
+
+// Offer adds a value to the end of the queue without waiting,
+// returning false if the queue is full or closed.
+func (q *BlockingQueue[V]) Offer(value V) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || q.isFull() {
+		return false
+	}
+	q.data.AddLast(value)
+	q.notEmpty.Signal()
+	return true
+}
+
+// OfferTimeout adds a value to the end of the queue, waiting up to the
+// timeout for space to become available if the queue is full. It returns
+// false if the value could not be added before the timeout, or if the
+// queue is closed.
+func (q *BlockingQueue[V]) OfferTimeout(value V, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return q.Put(ctx, value) == nil
+}
+
+// TryPoll removes and returns the value at the head of the queue and true
+// without waiting, or returns the zero value and false if the queue is empty.
+func (q *BlockingQueue[V]) TryPoll() (V, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.data.IsEmpty() {
+		var null V
+		return null, false
+	}
+	value := q.data.RemoveFirst()
+	q.notFull.Signal()
+	return value, true
+}
+
+// PollTimeout removes and returns the value at the head of the queue and
+// true, waiting up to the timeout for a value to be added if it is empty.
+// It returns the zero value and false if no value became available before
+// the timeout, or if the queue is closed and empty.
+func (q *BlockingQueue[V]) PollTimeout(timeout time.Duration) (V, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	value, err := q.Take(ctx)
+	return value, err == nil
+}
+
+// TryPeek returns the value at the head of the queue and true,
+// or the zero value and false if the queue is empty.
+func (q *BlockingQueue[V]) TryPeek() (V, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.data.IsEmpty() {
+		var null V
+		return null, false
+	}
+	return q.data.GetFirst(), true
+}
+
+// DrainTo removes up to max values from the head of the queue without
+// waiting and adds them to the collection in order, returning the number
+// of values which were moved. If max is less than 0, all values are moved.
+//
+// The values are added to the collection after they have been removed and
+// the queue's lock has been released, so the collection may use the queue.
+func (q *BlockingQueue[V]) DrainTo(collection structs.Collection[V], max int) int {
+	q.mu.Lock()
+	var values []V
+	for !q.data.IsEmpty() && (max < 0 || len(values) < max) {
+		values = append(values, q.data.RemoveFirst())
+	}
+	if len(values) > 0 {
+		q.notFull.Broadcast()
+	}
+	q.mu.Unlock()
+
+	for _, value := range values {
+		collection.Add(value)
+	}
+	return len(values)
+}
+
+// Close closes the queue, so no more values may be added. Values which
+// remain in the queue may still be taken, after which Take returns
+// ErrClosed. All callers blocked in Put are woken and return ErrClosed.
+//
+// Closing a queue which is already closed has no effect.
+func (q *BlockingQueue[V]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		q.notEmpty.Broadcast()
+		q.notFull.Broadcast()
+	}
+}
+
+// IsClosed returns whether the queue has been closed.
+func (q *BlockingQueue[V]) IsClosed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.closed
+}
+
+// Capacity returns the maximum number of values
+// in the queue, or 0 if the queue is unbounded.
+func (q *BlockingQueue[V]) Capacity() int {
+	return q.capacity
+}
+
+// IsEmpty returns whether the queue is empty.
+func (q *BlockingQueue[V]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// Size returns the number of values in the queue.
+func (q *BlockingQueue[V]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.data.Size()
+}
+
+// Values returns a snapshot of the values in the
+// queue, starting at the head of the queue.
+func (q *BlockingQueue[V]) Values() []V {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.data.Values()
+}
+
+// isFull returns whether the queue is at its capacity.
+// The lock must be held by the caller.
+func (q *BlockingQueue[V]) isFull() bool {
+	return q.capacity > 0 && q.data.Size() >= q.capacity
+}
+
+// watch wakes the callers waiting on cond when the context is done,
+// so they can return its error, until the returned function is called.
+// The lock must be held by the caller.
+func (q *BlockingQueue[V]) watch(ctx context.Context, cond *sync.Cond) (stop func()) {
+	if ctx.Done() == nil {
+		return func() {}
+	}
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			// the waiter releases the lock while it waits, so
+			// the broadcast cannot happen before it is waiting
+			q.mu.Lock()
+			cond.Broadcast()
+			q.mu.Unlock()
+		case <-stopped:
+		}
+	}()
+	return func() { close(stopped) }
+}
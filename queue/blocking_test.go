@@ -0,0 +1,245 @@
+package queue
+
+import (
+	"context"
+	"github.com/zytekaron/structs/list"
+	"golang.org/x/exp/slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueue(t *testing.T) {
+	const producers = 4
+	const count = 256
+
+	q := NewBlocking[int](8)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < count; i++ {
+				if err := q.Put(ctx, p*count+i); err != nil {
+					t.Error("unexpected error from put:", err)
+				}
+			}
+		}(p)
+	}
+	go func() {
+		wg.Wait()
+		q.Close()
+	}()
+
+	var got []int
+	for {
+		value, err := q.Take(ctx)
+		if err == ErrClosed {
+			break
+		}
+		if err != nil {
+			t.Fatal("unexpected error from take:", err)
+		}
+		got = append(got, value)
+	}
+
+	if len(got) != producers*count {
+		t.Fatalf("expected %d values but got %d", producers*count, len(got))
+	}
+	sort.Ints(got)
+	for i, value := range got {
+		if value != i {
+			t.Fatalf("expected %d but got %d", i, value)
+		}
+	}
+}
+
+func TestBlockingQueue_Order(t *testing.T) {
+	q := NewBlocking[int](0)
+	for i := 0; i < 10; i++ {
+		q.Offer(i)
+	}
+	for i := 0; i < 10; i++ {
+		value, ok := q.TryPoll()
+		if !ok || value != i {
+			t.Errorf("expected (%d, true) but got (%d, %t)", i, value, ok)
+		}
+	}
+}
+
+func TestBlockingQueue_Capacity(t *testing.T) {
+	q := NewBlocking[int](2)
+	if !q.Offer(1) || !q.Offer(2) {
+		t.Fatal("expected offers within capacity to succeed")
+	}
+	if q.Offer(3) {
+		t.Error("expected offer to a full queue to fail")
+	}
+	if q.OfferTimeout(3, 10*time.Millisecond) {
+		t.Error("expected offer with timeout to a full queue to fail")
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.TryPoll()
+	}()
+	if !q.OfferTimeout(3, time.Second) {
+		t.Error("expected offer with timeout to succeed once space is available")
+	}
+}
+
+func TestBlockingQueue_Cancel(t *testing.T) {
+	q := NewBlocking[int](1)
+	q.Offer(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	if err := q.Put(ctx, 2); err != context.Canceled {
+		t.Errorf("expected %v from put but got %v", context.Canceled, err)
+	}
+
+	q.TryPoll()
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Take(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected %v from take but got %v", context.DeadlineExceeded, err)
+	}
+	if _, ok := q.PollTimeout(10 * time.Millisecond); ok {
+		t.Error("expected poll with timeout from an empty queue to fail")
+	}
+}
+
+func TestBlockingQueue_Close(t *testing.T) {
+	q := NewBlocking[int](0)
+	ctx := context.Background()
+	q.Offer(1)
+	q.Offer(2)
+
+	// a blocked taker is woken by close once the queue is drained
+	q2 := NewBlocking[int](0)
+	done := make(chan error)
+	go func() {
+		_, err := q2.Take(ctx)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q2.Close()
+	if err := <-done; err != ErrClosed {
+		t.Errorf("expected %v from blocked take but got %v", ErrClosed, err)
+	}
+
+	q.Close()
+	if !q.IsClosed() {
+		t.Error("expected queue to be closed")
+	}
+	if err := q.Put(ctx, 3); err != ErrClosed {
+		t.Errorf("expected %v from put but got %v", ErrClosed, err)
+	}
+	if q.Offer(3) {
+		t.Error("expected offer to a closed queue to fail")
+	}
+	for expect := 1; expect <= 2; expect++ {
+		value, err := q.Take(ctx)
+		if err != nil || value != expect {
+			t.Errorf("expected (%d, nil) but got (%d, %v)", expect, value, err)
+		}
+	}
+	if _, err := q.Take(ctx); err != ErrClosed {
+		t.Errorf("expected %v from take but got %v", ErrClosed, err)
+	}
+}
+
+func TestBlockingQueue_DrainTo(t *testing.T) {
+	q := NewBlocking[int](0)
+	for i := 0; i < 5; i++ {
+		q.Offer(i)
+	}
+
+	l := list.NewOrdered[int]()
+	if n := q.DrainTo(l, 3); n != 3 {
+		t.Errorf("expected 3 values to be drained but got %d", n)
+	}
+	if n := q.DrainTo(l, -1); n != 2 {
+		t.Errorf("expected 2 values to be drained but got %d", n)
+	}
+
+	expect := []int{0, 1, 2, 3, 4}
+	if !slices.Equal(l.Values(), expect) {
+		t.Errorf("expected drained values %v but got %v", expect, l.Values())
+	}
+	if !q.IsEmpty() {
+		t.Error("expected queue to be empty, got size", q.Size())
+	}
+}
+
+// sizeRecorder records the size of a queue as each value is added to it.
+type sizeRecorder struct {
+	*list.List[int]
+	q     *BlockingQueue[int]
+	sizes []int
+}
+
+func (r *sizeRecorder) Add(value int) bool {
+	r.sizes = append(r.sizes, r.q.Size())
+	return r.List.Add(value)
+}
+
+func TestBlockingQueue_DrainToReentrant(t *testing.T) {
+	q := NewBlocking[int](0)
+	for i := 0; i < 3; i++ {
+		q.Offer(i)
+	}
+
+	// the collection may use the queue, since the
+	// values are added after the lock is released
+	r := &sizeRecorder{List: list.NewOrdered[int](), q: q}
+	if n := q.DrainTo(r, -1); n != 3 {
+		t.Errorf("expected 3 values to be drained but got %d", n)
+	}
+	if expect := []int{0, 0, 0}; !slices.Equal(r.sizes, expect) {
+		t.Errorf("expected sizes %v while adding but got %v", expect, r.sizes)
+	}
+	if expect := []int{0, 1, 2}; !slices.Equal(r.Values(), expect) {
+		t.Errorf("expected drained values %v but got %v", expect, r.Values())
+	}
+}
+
+func TestBlockingQueue_ManyWaiters(t *testing.T) {
+	const count = 64
+	q := NewBlocking[int](1)
+
+	// each value wakes one taker, and each take wakes one putter
+	var wg sync.WaitGroup
+	got := make([]int, count)
+	for i := 0; i < count; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			if err := q.Put(context.Background(), i); err != nil {
+				t.Errorf("unexpected error from put: %v", err)
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			value, err := q.Take(context.Background())
+			if err != nil {
+				t.Errorf("unexpected error from take: %v", err)
+			}
+			got[i] = value
+		}(i)
+	}
+	wg.Wait()
+
+	sort.Ints(got)
+	for i := 0; i < count; i++ {
+		if got[i] != i {
+			t.Fatalf("expected each value to be taken once but got %v", got)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package queue
+
+import "encoding/json"
+
+// Codec encodes and decodes values for storage, for example in a DurableQueue.
+type Codec[V any] interface {
+	Encode(value V) ([]byte, error)
+	Decode(data []byte) (V, error)
+}
+
+// JSONCodec is a Codec which encodes values as JSON.
+type JSONCodec[V any] struct{}
+
+func (JSONCodec[V]) Encode(value V) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (JSONCodec[V]) Decode(data []byte) (V, error) {
+	var value V
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// BytesCodec is a Codec which stores byte slices as-is.
+type BytesCodec struct{}
+
+func (BytesCodec) Encode(value []byte) ([]byte, error) {
+	return value, nil
+}
+
+func (BytesCodec) Decode(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+// StringCodec is a Codec which stores strings as their bytes.
+type StringCodec struct{}
+
+func (StringCodec) Encode(value string) ([]byte, error) {
+	return []byte(value), nil
+}
+
+func (StringCodec) Decode(data []byte) (string, error) {
+	return string(data), nil
+}
@@ -0,0 +1,200 @@
+package queue
+
+import (
+	"context"
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/heap"
+	"sync"
+	"time"
+)
+
+// DelayQueue is an implementation of a queue in which each value
+// carries a ready time, and can only be taken once that time has
+// passed. Values are taken in order of their ready time, and values
+// with equal ready times are taken in the order they were added.
+//
+// DelayQueue is safe for concurrent use.
+type DelayQueue[V any] struct {
+	mu    sync.Mutex
+	clock Clock
+	heap  *heap.Heap[delayEntry[V]]
+	seq   uint64 // sequence number of the next value
+	// changed is closed and replaced whenever a value is added
+	// or removed, waking all blocked callers.
+	changed chan struct{}
+}
+
+// delayEntry is a value in a delay queue along with its ready time.
+type delayEntry[V any] struct {
+	value V
+	ready time.Time
+	seq   uint64
+}
+
+// NewDelay creates an empty DelayQueue which uses the system clock.
+func NewDelay[V any]() *DelayQueue[V] {
+	return NewDelayClock[V](SystemClock)
+}
+
+// NewDelayClock creates an empty DelayQueue which uses the given clock.
+func NewDelayClock[V any](clock Clock) *DelayQueue[V] {
+	return &DelayQueue[V]{
+		clock: clock,
+		heap: heap.New(func(a, b delayEntry[V]) int {
+			if a.ready.Before(b.ready) {
+				return -1
+			}
+			if a.ready.After(b.ready) {
+				return 1
+			}
+			return structs.CompareOrdered(a.seq, b.seq)
+		}),
+		changed: make(chan struct{}),
+	}
+}
+
+// Put adds a value to the queue which becomes ready at the given time.
+//
+// Time Complexity: O(logn)
+func (q *DelayQueue[V]) Put(value V, ready time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.heap.Push(delayEntry[V]{
+		value: value,
+		ready: ready,
+		seq:   q.seq,
+	})
+	q.seq++
+	q.signal()
+}
+
+// PutAfter adds a value to the queue which becomes ready after the delay.
+//
+// Time Complexity: O(logn)
+func (q *DelayQueue[V]) PutAfter(value V, delay time.Duration) {
+	q.Put(value, q.clock.Now().Add(delay))
+}
+
+// Take removes and returns the value at the head of the queue,
+// waiting until a value is added and its ready time has passed.
+//
+// Returns the context's error if the context is
+// done before a value is ready to be taken.
+func (q *DelayQueue[V]) Take(ctx context.Context) (V, error) {
+	for {
+		q.mu.Lock()
+		if value, ok := q.pollReady(); ok {
+			q.mu.Unlock()
+			return value, nil
+		}
+		var timer Timer
+		var due <-chan time.Time
+		if !q.heap.IsEmpty() {
+			timer = q.clock.NewTimer(q.heap.Peek().ready.Sub(q.clock.Now()))
+			due = timer.C()
+		}
+		changed := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-due:
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			var null V
+			return null, ctx.Err()
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// TryPoll removes and returns the value at the head of the queue
+// and true if its ready time has passed, otherwise it returns
+// the zero value and false without waiting.
+func (q *DelayQueue[V]) TryPoll() (V, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.pollReady()
+}
+
+// DrainTo removes up to max values whose ready time has passed from the
+// head of the queue without waiting and adds them to the collection in
+// order, returning the number of values which were moved. If max is
+// less than 0, all ready values are moved.
+func (q *DelayQueue[V]) DrainTo(collection structs.Collection[V], max int) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	count := 0
+	for max < 0 || count < max {
+		value, ok := q.pollReady()
+		if !ok {
+			break
+		}
+		collection.Add(value)
+		count++
+	}
+	return count
+}
+
+// NextReady returns the ready time of the value at the
+// head of the queue and true, or false if it is empty.
+func (q *DelayQueue[V]) NextReady() (time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.heap.IsEmpty() {
+		return time.Time{}, false
+	}
+	return q.heap.Peek().ready, true
+}
+
+// Clear removes all the values from the queue.
+func (q *DelayQueue[V]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.heap.Clear()
+	q.signal()
+}
+
+// IsEmpty returns whether the queue is empty,
+// including values which are not yet ready.
+func (q *DelayQueue[V]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// Size returns the number of values in the queue,
+// including values which are not yet ready.
+func (q *DelayQueue[V]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.heap.Size()
+}
+
+// pollReady removes and returns the value at the
+// head of the queue if its ready time has passed.
+// The lock must be held by the caller.
+func (q *DelayQueue[V]) pollReady() (V, bool) {
+	if q.heap.IsEmpty() || q.heap.Peek().ready.After(q.clock.Now()) {
+		var null V
+		return null, false
+	}
+	value := q.heap.Pop().value
+	q.signal()
+	return value, true
+}
+
+// signal wakes all callers waiting for the queue to change.
+// The lock must be held by the caller.
+func (q *DelayQueue[V]) signal() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
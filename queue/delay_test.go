@@ -0,0 +1,105 @@
+package queue
+
+import (
+	"context"
+	"github.com/zytekaron/structs/list"
+	"golang.org/x/exp/slices"
+	"testing"
+	"time"
+)
+
+func TestDelayQueue(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	q := NewDelayClock[string](clock)
+
+	q.PutAfter("c", 3*time.Second)
+	q.PutAfter("a", time.Second)
+	q.PutAfter("b", 2*time.Second)
+	q.PutAfter("a2", time.Second)
+
+	if _, ok := q.TryPoll(); ok {
+		t.Fatal("expected no value to be ready")
+	}
+	if ready, ok := q.NextReady(); !ok || !ready.Equal(time.Unix(1, 0)) {
+		t.Errorf("expected next ready time %v but got %v", time.Unix(1, 0), ready)
+	}
+
+	clock.Advance(time.Second)
+	for _, expect := range []string{"a", "a2"} {
+		if value, ok := q.TryPoll(); !ok || value != expect {
+			t.Errorf("expected (%s, true) but got (%s, %t)", expect, value, ok)
+		}
+	}
+	if _, ok := q.TryPoll(); ok {
+		t.Error("expected no more values to be ready")
+	}
+
+	clock.Advance(2 * time.Second)
+	l := list.NewOrdered[string]()
+	if n := q.DrainTo(l, -1); n != 2 {
+		t.Errorf("expected 2 values to be drained but got %d", n)
+	}
+	if expect := []string{"b", "c"}; !slices.Equal(l.Values(), expect) {
+		t.Errorf("expected drained values %v but got %v", expect, l.Values())
+	}
+	if !q.IsEmpty() {
+		t.Error("expected queue to be empty, got size", q.Size())
+	}
+}
+
+func TestDelayQueue_Take(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	q := NewDelayClock[int](clock)
+
+	result := make(chan int)
+	go func() {
+		value, err := q.Take(context.Background())
+		if err != nil {
+			t.Error("unexpected error from take:", err)
+		}
+		result <- value
+	}()
+
+	// the taker waits for a value, then for its ready time
+	q.PutAfter(1, time.Minute)
+	for clock.Timers() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case value := <-result:
+		t.Fatalf("expected take to block but got %d", value)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// an earlier value wakes the taker to wait for the new head
+	q.PutAfter(2, time.Second)
+	clock.Advance(time.Second)
+	if value := <-result; value != 2 {
+		t.Errorf("expected 2 but got %d", value)
+	}
+}
+
+func TestDelayQueue_TakeCancel(t *testing.T) {
+	q := NewDelay[int]()
+	q.PutAfter(1, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Take(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected %v but got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestDelayQueue_SystemClock(t *testing.T) {
+	q := NewDelay[int]()
+	q.PutAfter(1, 10*time.Millisecond)
+
+	start := time.Now()
+	value, err := q.Take(context.Background())
+	if err != nil || value != 1 {
+		t.Errorf("expected (1, nil) but got (%d, %v)", value, err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("expected take to wait at least 10ms but it took %v", elapsed)
+	}
+}
@@ -0,0 +1,616 @@
+package queue
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/zytekaron/structs/list"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SyncPolicy determines when a DurableQueue flushes
+// its writes to stable storage with fsync.
+type SyncPolicy int
+
+const (
+	// SyncEveryWrite flushes after every Offer and Ack, so
+	// no acknowledged write is lost if the machine crashes.
+	SyncEveryWrite SyncPolicy = iota
+	// SyncOnRotate flushes when a segment is full, when the queue
+	// is closed, and when Sync is called. Recent writes may be lost
+	// if the machine crashes, but not if only the process crashes.
+	SyncOnRotate
+	// SyncNever leaves flushing to the operating system, other than
+	// when a segment is full, so that a crash can only tear the last
+	// segment. Recent writes may be lost if the machine crashes.
+	SyncNever
+)
+
+const (
+	// DefaultSegmentSize is the default size in bytes
+	// after which a DurableQueue starts a new segment.
+	DefaultSegmentSize = 64 << 20
+
+	segmentExt = ".wal"
+
+	recordHeaderSize = 8 // payload length and checksum
+	recordData       = byte(1)
+	recordAck        = byte(2)
+)
+
+var (
+	// ErrCorrupt is returned when opening a DurableQueue
+	// whose segments are damaged other than by a torn write.
+	ErrCorrupt = errors.New("durable queue corrupt")
+	// ErrNotInFlight is returned when acknowledging a sequence
+	// number which was not returned by Poll, or was already acknowledged.
+	ErrNotInFlight = errors.New("sequence number not in flight")
+)
+
+// SyncError is returned by Offer and Ack when a value or acknowledgement
+// was written and has taken effect, but could not be flushed to stable
+// storage, so it may be lost if the machine crashes. The operation
+// should not be retried, since it would then take effect twice.
+type SyncError struct {
+	Err error
+}
+
+func (e *SyncError) Error() string {
+	return "durable queue sync: " + e.Err.Error()
+}
+
+func (e *SyncError) Unwrap() error {
+	return e.Err
+}
+
+// DurableQueue is an implementation of a FIFO queue which survives
+// process restarts, backed by a write-ahead log of segment files in
+// a directory. Values are encoded for storage using a Codec.
+//
+// Polling a value does not remove it from the log: it remains in
+// flight until it is acknowledged with Ack. Values which are in
+// flight when the queue is closed or the process crashes are
+// delivered again once the queue is reopened, so each value is
+// delivered at least once. Segments are deleted once all their
+// values have been acknowledged.
+//
+// When opened, a segment which ends with a partially written record,
+// such as after a crash mid-write, is truncated to its last complete
+// record. DurableQueue is safe for concurrent use.
+//
+// DurableQueue does not implement structs.Queue: Offer returns an
+// error, since writing to disk can fail, and Peek and Poll return a
+// DurableEntry, since the caller needs its sequence number to Ack it.
+type DurableQueue[V any] struct {
+	mu          sync.Mutex
+	dir         string
+	codec       Codec[V]
+	sync        SyncPolicy
+	segmentSize int64
+
+	segments []*walSegment // oldest first; the last is being written
+	file     walFile       // the file of the last segment
+	nextID   uint64        // id of the next segment
+	nextSeq  uint64        // sequence number of the next value
+
+	pending  *list.List[*DurableEntry[V]]
+	inFlight map[uint64]*DurableEntry[V]
+	closed   bool
+	// failed is the error which left the last segment with a partial
+	// record that could not be truncated, after which nothing more is
+	// written, since recovery would discard any records following it.
+	failed error
+}
+
+// DurableEntry is a value in a DurableQueue with its sequence number.
+type DurableEntry[V any] struct {
+	Seq     uint64
+	Value   V
+	segment *walSegment
+}
+
+// walFile is the file of the segment being written,
+// which is an *os.File other than in tests.
+type walFile interface {
+	io.Writer
+	Sync() error
+	Truncate(size int64) error
+	Close() error
+}
+
+// walSegment is a segment file of a DurableQueue's log.
+type walSegment struct {
+	id     uint64
+	path   string
+	size   int64
+	values int // number of values written to the segment
+	acked  int // number of those values which have been acknowledged
+}
+
+// OpenDurable opens the DurableQueue stored in the directory, creating
+// the directory if it does not exist, and recovers its unacknowledged
+// values, which are all ready to be polled again.
+func OpenDurable[V any](dir string, codec Codec[V]) (*DurableQueue[V], error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	q := &DurableQueue[V]{
+		dir:         dir,
+		codec:       codec,
+		sync:        SyncEveryWrite,
+		segmentSize: DefaultSegmentSize,
+		pending:     list.New[*DurableEntry[V]](nil),
+		inFlight:    make(map[uint64]*DurableEntry[V]),
+	}
+	if err := q.recover(); err != nil {
+		return nil, err
+	}
+	if err := q.openLast(); err != nil {
+		return nil, err
+	}
+	q.deleteAcked()
+	return q, nil
+}
+
+// SetSyncPolicy sets when writes are flushed to stable storage.
+// The default is SyncEveryWrite.
+func (q *DurableQueue[V]) SetSyncPolicy(policy SyncPolicy) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.sync = policy
+}
+
+// SetSegmentSize sets the size in bytes after which a new segment
+// is started. The default is DefaultSegmentSize.
+func (q *DurableQueue[V]) SetSegmentSize(size int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.segmentSize = size
+}
+
+// Offer appends a value to the end of the queue, returning an error
+// if it could not be encoded or written, or a *SyncError if it was
+// added but could not be flushed to stable storage.
+func (q *DurableQueue[V]) Offer(value V) error {
+	data, err := q.codec.Encode(value)
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+	seq := q.nextSeq
+	if err := q.write(recordData, seq, data); err != nil {
+		return err
+	}
+	q.nextSeq++
+
+	segment := q.segments[len(q.segments)-1]
+	segment.values++
+	q.pending.AddLast(&DurableEntry[V]{
+		Seq:     seq,
+		Value:   value,
+		segment: segment,
+	})
+	q.rotateIfFull()
+	return q.flush()
+}
+
+// Peek returns the entry at the head of the queue and true without
+// removing it, or false if no values are pending or the queue is closed.
+func (q *DurableQueue[V]) Peek() (DurableEntry[V], bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || q.pending.IsEmpty() {
+		return DurableEntry[V]{}, false
+	}
+	return *q.pending.GetFirst(), true
+}
+
+// Poll removes and returns the entry at the head of the queue and true,
+// or false if no values are pending or the queue is closed. The entry
+// remains in flight, and is delivered again after a restart, until its
+// sequence number is acknowledged with Ack.
+func (q *DurableQueue[V]) Poll() (DurableEntry[V], bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || q.pending.IsEmpty() {
+		return DurableEntry[V]{}, false
+	}
+	entry := q.pending.RemoveFirst()
+	q.inFlight[entry.Seq] = entry
+	return *entry, true
+}
+
+// Ack acknowledges that the entry with the sequence number has been
+// processed, permanently removing it from the queue.
+//
+// Returns ErrNotInFlight if the sequence number is not in flight, or a
+// *SyncError if the acknowledgement was written but could not be flushed
+// to stable storage.
+func (q *DurableQueue[V]) Ack(seq uint64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+	entry, ok := q.inFlight[seq]
+	if !ok {
+		return ErrNotInFlight
+	}
+	if err := q.write(recordAck, seq, nil); err != nil {
+		return err
+	}
+	delete(q.inFlight, seq)
+	entry.segment.acked++
+	q.deleteAcked()
+	q.rotateIfFull()
+	return q.flush()
+}
+
+// Sync flushes all writes to stable storage.
+func (q *DurableQueue[V]) Sync() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+	return q.file.Sync()
+}
+
+// Close flushes all writes to stable storage and closes the queue,
+// after which no more values are polled, and Offer, Ack and Sync return
+// ErrClosed. Entries which are in flight, or were still pending, will
+// be delivered again when the queue is reopened.
+func (q *DurableQueue[V]) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+	if q.sync != SyncNever {
+		if err := q.file.Sync(); err != nil {
+			q.file.Close()
+			return err
+		}
+	}
+	return q.file.Close()
+}
+
+// IsEmpty returns whether no values are pending.
+func (q *DurableQueue[V]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// Size returns the number of values which are pending, excluding those in flight.
+func (q *DurableQueue[V]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.pending.Size()
+}
+
+// InFlight returns the number of values which have been polled but not acknowledged.
+func (q *DurableQueue[V]) InFlight() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.inFlight)
+}
+
+// Segments returns the number of segment files in the queue's directory.
+func (q *DurableQueue[V]) Segments() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.segments)
+}
+
+// write appends a record to the last segment. If the record is only
+// partially written, it is truncated from the segment, and if that fails
+// too, every later write fails. The lock must be held by the caller.
+func (q *DurableQueue[V]) write(kind byte, seq uint64, data []byte) error {
+	payloadSize := 1 + 8 + len(data)
+	buf := make([]byte, recordHeaderSize+payloadSize)
+	payload := buf[recordHeaderSize:]
+	payload[0] = kind
+	binary.BigEndian.PutUint64(payload[1:], seq)
+	copy(payload[9:], data)
+	binary.BigEndian.PutUint32(buf[0:], uint32(payloadSize))
+	binary.BigEndian.PutUint32(buf[4:], crc32.ChecksumIEEE(payload))
+
+	if q.failed != nil {
+		return q.failed
+	}
+	segment := q.segments[len(q.segments)-1]
+	n, err := q.file.Write(buf)
+	if err == nil && n < len(buf) {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		// remove the partial record, or recovery would truncate
+		// the segment there and discard every later record
+		if n > 0 {
+			if truncErr := q.file.Truncate(segment.size); truncErr != nil {
+				q.failed = fmt.Errorf("truncating partial record: %w", truncErr)
+			}
+		}
+		return err
+	}
+	segment.size += int64(n)
+	return nil
+}
+
+// flush flushes the last segment to stable storage if the sync policy is
+// SyncEveryWrite. It is called once a written record has taken effect,
+// so that a failure is reported as a *SyncError rather than as a failed
+// write, which the caller might retry. The lock must be held by the caller.
+func (q *DurableQueue[V]) flush() error {
+	if q.sync != SyncEveryWrite {
+		return nil
+	}
+	if err := q.file.Sync(); err != nil {
+		return &SyncError{Err: err}
+	}
+	return nil
+}
+
+// rotateIfFull starts a new segment if the last segment is full. The
+// write which filled the segment has already taken effect, so if a new
+// segment cannot be started, writing continues to the full segment and
+// starting one is tried again after the next write.
+// The lock must be held by the caller.
+func (q *DurableQueue[V]) rotateIfFull() {
+	if q.segments[len(q.segments)-1].size < q.segmentSize {
+		return
+	}
+	// always flush a full segment, even under SyncNever, since only
+	// the last segment may be recovered from a torn write
+	if q.file.Sync() != nil {
+		return
+	}
+	full := q.file
+	if q.rotate() != nil {
+		return
+	}
+	// the full segment has been flushed, so closing it cannot lose writes
+	full.Close()
+	q.deleteAcked()
+}
+
+// rotate creates a new segment and makes it the last segment.
+// The lock must be held by the caller.
+func (q *DurableQueue[V]) rotate() error {
+	segment := &walSegment{
+		id:   q.nextID,
+		path: filepath.Join(q.dir, fmt.Sprintf("%020d%s", q.nextID, segmentExt)),
+	}
+	file, err := os.OpenFile(segment.path, os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	// flush the directory too, or the segment and every
+	// record written to it may be lost if the machine crashes
+	if err := q.syncDir(); err != nil {
+		file.Close()
+		os.Remove(segment.path)
+		return err
+	}
+	q.nextID++
+	q.segments = append(q.segments, segment)
+	q.file = file
+	return nil
+}
+
+// openLast continues writing to the last recovered segment if it is
+// not full, so that reopening the queue does not leave behind an empty
+// segment each time, or otherwise starts a new segment.
+// The lock must be held by the caller.
+func (q *DurableQueue[V]) openLast() error {
+	if len(q.segments) == 0 || q.segments[len(q.segments)-1].size >= q.segmentSize {
+		return q.rotate()
+	}
+	file, err := os.OpenFile(q.segments[len(q.segments)-1].path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return err
+	}
+	q.file = file
+	return nil
+}
+
+// deleteAcked deletes the oldest segments, other than the last segment,
+// while all their values have been acknowledged. Acknowledgements are
+// only ever written to the same or later segments as their values, so
+// deleting from the oldest segment never loses an acknowledgement for
+// a value in a remaining segment.
+// The lock must be held by the caller.
+func (q *DurableQueue[V]) deleteAcked() {
+	deleted := false
+	for len(q.segments) > 1 && q.segments[0].acked == q.segments[0].values {
+		if err := os.Remove(q.segments[0].path); err != nil && !os.IsNotExist(err) {
+			// try again after the next acknowledgement
+			break
+		}
+		q.segments[0] = nil
+		q.segments = q.segments[1:]
+		deleted = true
+	}
+	if deleted {
+		// if this fails, a deleted segment may reappear after a crash,
+		// which only delivers its values again, at least once
+		q.syncDir()
+	}
+}
+
+// syncDir flushes the queue's directory to stable storage, so that
+// segments which were created or deleted stay that way after a crash.
+func (q *DurableQueue[V]) syncDir() error {
+	dir, err := os.Open(q.dir)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+// recover reads all the segments in the directory, rebuilding the queue
+// of unacknowledged values and truncating a torn write from the last segment.
+func (q *DurableQueue[V]) recover() error {
+	names, err := q.segmentNames()
+	if err != nil {
+		return err
+	}
+
+	entries := make(map[uint64]*DurableEntry[V])
+	var order []uint64
+	for i, name := range names {
+		id, _ := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64)
+		segment := &walSegment{
+			id:   id,
+			path: filepath.Join(q.dir, name),
+		}
+		q.segments = append(q.segments, segment)
+		q.nextID = id + 1
+
+		last := i == len(names)-1
+		err := q.readSegment(segment, last, func(kind byte, seq uint64, data []byte) error {
+			switch kind {
+			case recordData:
+				value, err := q.codec.Decode(data)
+				if err != nil {
+					return err
+				}
+				entries[seq] = &DurableEntry[V]{
+					Seq:     seq,
+					Value:   value,
+					segment: segment,
+				}
+				order = append(order, seq)
+				segment.values++
+				if seq >= q.nextSeq {
+					q.nextSeq = seq + 1
+				}
+			case recordAck:
+				// the value's segment may already have been deleted
+				if entry, ok := entries[seq]; ok {
+					entry.segment.acked++
+					delete(entries, seq)
+				}
+			default:
+				return ErrCorrupt
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", segment.path, err)
+		}
+	}
+
+	for _, seq := range order {
+		if entry, ok := entries[seq]; ok {
+			q.pending.AddLast(entry)
+		}
+	}
+	return nil
+}
+
+// readSegment calls f for each record in the segment. If the segment
+// is the last segment and ends with an incomplete or damaged record,
+// the segment is truncated to its last complete record. Otherwise,
+// damage results in ErrCorrupt.
+func (q *DurableQueue[V]) readSegment(segment *walSegment, last bool, f func(kind byte, seq uint64, data []byte) error) error {
+	file, err := os.OpenFile(segment.path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	remaining := info.Size()
+
+	header := make([]byte, recordHeaderSize)
+	var offset int64
+	for offset < info.Size() {
+		torn := remaining < recordHeaderSize
+		var payload []byte
+		if !torn {
+			if _, err := io.ReadFull(file, header); err != nil {
+				return err
+			}
+			payloadSize := int64(binary.BigEndian.Uint32(header[0:]))
+			checksum := binary.BigEndian.Uint32(header[4:])
+			torn = payloadSize < 9 || remaining-recordHeaderSize < payloadSize
+			if !torn {
+				payload = make([]byte, payloadSize)
+				if _, err := io.ReadFull(file, payload); err != nil {
+					return err
+				}
+				torn = crc32.ChecksumIEEE(payload) != checksum
+			}
+		}
+		if torn {
+			if !last {
+				return ErrCorrupt
+			}
+			if err := file.Truncate(offset); err != nil {
+				return err
+			}
+			if err := file.Sync(); err != nil {
+				return err
+			}
+			break
+		}
+
+		if err := f(payload[0], binary.BigEndian.Uint64(payload[1:]), payload[9:]); err != nil {
+			return err
+		}
+		size := int64(recordHeaderSize + len(payload))
+		offset += size
+		remaining -= size
+	}
+	segment.size = offset
+	return nil
+}
+
+// segmentNames returns the names of the segment files in the directory, oldest first.
+func (q *DurableQueue[V]) segmentNames() ([]string, error) {
+	dirEntries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range dirEntries {
+		name := entry.Name()
+		if entry.Type().IsRegular() && strings.HasSuffix(name, segmentExt) {
+			if _, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64); err == nil {
+				names = append(names, name)
+			}
+		}
+	}
+	// names are zero padded, so they sort in order of their ids
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,372 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDurableQueue(t *testing.T) {
+	dir := t.TempDir()
+	q, err := OpenDurable[string](dir, StringCodec{})
+	if err != nil {
+		t.Fatal("unexpected error from open:", err)
+	}
+
+	for _, value := range []string{"a", "b", "c"} {
+		if err := q.Offer(value); err != nil {
+			t.Fatal("unexpected error from offer:", err)
+		}
+	}
+	if q.Size() != 3 {
+		t.Error("expected size 3, got", q.Size())
+	}
+
+	entry, ok := q.Peek()
+	if !ok || entry.Value != "a" {
+		t.Error("expected to peek a, got", entry.Value, ok)
+	}
+	entry, ok = q.Poll()
+	if !ok || entry.Value != "a" {
+		t.Error("expected to poll a, got", entry.Value, ok)
+	}
+	if q.Size() != 2 || q.InFlight() != 1 {
+		t.Error("expected size 2 with 1 in flight, got", q.Size(), q.InFlight())
+	}
+	if err := q.Ack(entry.Seq); err != nil {
+		t.Error("unexpected error from ack:", err)
+	}
+	if err := q.Ack(entry.Seq); err != ErrNotInFlight {
+		t.Error("expected ErrNotInFlight acking twice, got", err)
+	}
+
+	entry, _ = q.Poll()
+	if entry.Value != "b" {
+		t.Error("expected to poll b, got", entry.Value)
+	}
+	// b is in flight but not acknowledged, so it is delivered again
+	if err := q.Close(); err != nil {
+		t.Fatal("unexpected error from close:", err)
+	}
+	if err := q.Offer("d"); err != ErrClosed {
+		t.Error("expected ErrClosed offering to closed queue, got", err)
+	}
+	if _, ok := q.Poll(); ok {
+		t.Error("expected poll on closed queue to fail")
+	}
+	if _, ok := q.Peek(); ok {
+		t.Error("expected peek on closed queue to fail")
+	}
+
+	q, err = OpenDurable[string](dir, StringCodec{})
+	if err != nil {
+		t.Fatal("unexpected error from reopen:", err)
+	}
+	defer q.Close()
+
+	expectDurableValues(t, q, "b", "c")
+	if err := q.Offer("d"); err != nil {
+		t.Fatal("unexpected error from offer:", err)
+	}
+	expectDurableValues(t, q, "d")
+	if _, ok := q.Poll(); ok {
+		t.Error("expected poll on empty queue to fail")
+	}
+}
+
+func TestDurableQueue_TornWrite(t *testing.T) {
+	dir := t.TempDir()
+	q, err := OpenDurable[string](dir, StringCodec{})
+	if err != nil {
+		t.Fatal("unexpected error from open:", err)
+	}
+	q.Offer("a")
+	q.Offer("b")
+	q.Close()
+
+	// simulate a crash partway through writing the second record
+	names, _ := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	path := names[len(names)-1]
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(path, info.Size()-2); err != nil {
+		t.Fatal(err)
+	}
+
+	q, err = OpenDurable[string](dir, StringCodec{})
+	if err != nil {
+		t.Fatal("unexpected error from open after torn write:", err)
+	}
+	expectDurableValues(t, q, "a")
+	if !q.IsEmpty() {
+		t.Error("expected torn record to be discarded")
+	}
+
+	// the torn record is truncated, so new records are readable after it
+	q.Offer("c")
+	q.Close()
+	q, err = OpenDurable[string](dir, StringCodec{})
+	if err != nil {
+		t.Fatal("unexpected error from reopen:", err)
+	}
+	defer q.Close()
+	expectDurableValues(t, q, "c")
+}
+
+func TestDurableQueue_Corrupt(t *testing.T) {
+	dir := t.TempDir()
+	q, err := OpenDurable[string](dir, StringCodec{})
+	if err != nil {
+		t.Fatal("unexpected error from open:", err)
+	}
+	q.SetSegmentSize(1)
+	q.Offer("a")
+	q.Offer("b")
+	q.Close()
+
+	// damage to a segment other than the last is not a torn write
+	names, _ := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	data, err := os.ReadFile(names[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)-1] ^= 0xff
+	if err := os.WriteFile(names[0], data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenDurable[string](dir, StringCodec{}); err == nil {
+		t.Error("expected error opening corrupt queue")
+	}
+}
+
+func TestDurableQueue_Segments(t *testing.T) {
+	dir := t.TempDir()
+	q, err := OpenDurable[int](dir, JSONCodec[int]{})
+	if err != nil {
+		t.Fatal("unexpected error from open:", err)
+	}
+	q.SetSyncPolicy(SyncOnRotate)
+	q.SetSegmentSize(64)
+
+	const count = 100
+	for i := 0; i < count; i++ {
+		if err := q.Offer(i); err != nil {
+			t.Fatal("unexpected error from offer:", err)
+		}
+	}
+	if q.Segments() < 2 {
+		t.Fatal("expected values to span several segments, got", q.Segments())
+	}
+
+	for i := 0; i < count/2; i++ {
+		entry, _ := q.Poll()
+		if entry.Value != i {
+			t.Fatal("expected to poll", i, "got", entry.Value)
+		}
+		if err := q.Ack(entry.Seq); err != nil {
+			t.Fatal("unexpected error from ack:", err)
+		}
+	}
+	q.Close()
+
+	q, err = OpenDurable[int](dir, JSONCodec[int]{})
+	if err != nil {
+		t.Fatal("unexpected error from reopen:", err)
+	}
+	q.SetSegmentSize(64)
+	for i := count / 2; i < count; i++ {
+		entry, ok := q.Poll()
+		if !ok || entry.Value != i {
+			t.Fatal("expected to poll", i, "got", entry.Value, ok)
+		}
+		if err := q.Ack(entry.Seq); err != nil {
+			t.Fatal("unexpected error from ack:", err)
+		}
+	}
+	q.Close()
+
+	// every segment but the one being written has been deleted
+	names, _ := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	if len(names) > 2 {
+		t.Error("expected acknowledged segments to be deleted, got", len(names), "segments")
+	}
+}
+
+func TestDurableQueue_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	q, err := OpenDurable[string](dir, StringCodec{})
+	if err != nil {
+		t.Fatal("unexpected error from open:", err)
+	}
+	q.Offer("a")
+	q.Close()
+
+	// reopening continues the last segment rather than starting another
+	for i := 0; i < 3; i++ {
+		q, err = OpenDurable[string](dir, StringCodec{})
+		if err != nil {
+			t.Fatal("unexpected error from reopen:", err)
+		}
+		if q.Segments() != 1 {
+			t.Error("expected 1 segment after reopening, got", q.Segments())
+		}
+		q.Close()
+	}
+
+	q, err = OpenDurable[string](dir, StringCodec{})
+	if err != nil {
+		t.Fatal("unexpected error from reopen:", err)
+	}
+	defer q.Close()
+	q.Offer("b")
+	expectDurableValues(t, q, "a", "b")
+}
+
+func TestDurableQueue_RotateError(t *testing.T) {
+	dir := t.TempDir()
+	q, err := OpenDurable[string](dir, StringCodec{})
+	if err != nil {
+		t.Fatal("unexpected error from open:", err)
+	}
+	q.SetSegmentSize(1)
+
+	// a directory in the way of the next segment stops it being created
+	next := filepath.Join(dir, fmt.Sprintf("%020d%s", q.nextID, segmentExt))
+	if err := os.Mkdir(next, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Offer("a"); err != nil {
+		t.Fatal("unexpected error from offer when unable to rotate:", err)
+	}
+	if q.Segments() != 1 {
+		t.Error("expected the full segment to be kept, got", q.Segments(), "segments")
+	}
+
+	// writing continues to the full segment until a new one can be created
+	if err := os.Remove(next); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Offer("b"); err != nil {
+		t.Fatal("unexpected error from offer:", err)
+	}
+	if q.Segments() != 2 {
+		t.Error("expected a new segment to be started, got", q.Segments(), "segments")
+	}
+	q.Close()
+
+	q, err = OpenDurable[string](dir, StringCodec{})
+	if err != nil {
+		t.Fatal("unexpected error from reopen:", err)
+	}
+	defer q.Close()
+	expectDurableValues(t, q, "a", "b")
+}
+
+// shortWriteFile writes only half of the next record, as if the disk
+// had filled up partway through it.
+type shortWriteFile struct {
+	walFile
+	failed bool
+}
+
+func (f *shortWriteFile) Write(p []byte) (int, error) {
+	if f.failed {
+		return f.walFile.Write(p)
+	}
+	f.failed = true
+	n, _ := f.walFile.Write(p[:len(p)/2])
+	return n, errors.New("no space left on device")
+}
+
+func TestDurableQueue_ShortWrite(t *testing.T) {
+	dir := t.TempDir()
+	q, err := OpenDurable[string](dir, StringCodec{})
+	if err != nil {
+		t.Fatal("unexpected error from open:", err)
+	}
+	q.Offer("a")
+	q.file = &shortWriteFile{walFile: q.file}
+	if err := q.Offer("b"); err == nil {
+		t.Error("expected error from short write")
+	}
+	if err := q.Offer("c"); err != nil {
+		t.Fatal("unexpected error from offer after short write:", err)
+	}
+	q.Close()
+
+	// the partial record was removed, so c is not discarded with it
+	q, err = OpenDurable[string](dir, StringCodec{})
+	if err != nil {
+		t.Fatal("unexpected error from reopen:", err)
+	}
+	defer q.Close()
+	expectDurableValues(t, q, "a", "c")
+	if !q.IsEmpty() {
+		t.Error("expected only a and c to be recovered")
+	}
+}
+
+// syncFailFile fails to flush once, after the record has been written.
+type syncFailFile struct {
+	walFile
+	failed bool
+}
+
+func (f *syncFailFile) Sync() error {
+	if f.failed {
+		return f.walFile.Sync()
+	}
+	f.failed = true
+	return errors.New("input/output error")
+}
+
+func TestDurableQueue_SyncError(t *testing.T) {
+	dir := t.TempDir()
+	q, err := OpenDurable[string](dir, StringCodec{})
+	if err != nil {
+		t.Fatal("unexpected error from open:", err)
+	}
+	q.file = &syncFailFile{walFile: q.file}
+
+	// the value was written, so it is added despite the error
+	var syncErr *SyncError
+	if err := q.Offer("a"); !errors.As(err, &syncErr) {
+		t.Error("expected a SyncError from offer, got", err)
+	}
+	if err := q.Offer("b"); err != nil {
+		t.Fatal("unexpected error from offer:", err)
+	}
+	if q.Size() != 2 {
+		t.Error("expected size 2, got", q.Size())
+	}
+	q.Close()
+
+	// each value is recovered once, with its own sequence number
+	q, err = OpenDurable[string](dir, StringCodec{})
+	if err != nil {
+		t.Fatal("unexpected error from reopen:", err)
+	}
+	defer q.Close()
+	expectDurableValues(t, q, "a", "b")
+	if !q.IsEmpty() {
+		t.Error("expected only a and b to be recovered, got size", q.Size())
+	}
+}
+
+func expectDurableValues[V comparable](t *testing.T, q *DurableQueue[V], values ...V) {
+	t.Helper()
+	for _, value := range values {
+		entry, ok := q.Poll()
+		if !ok || entry.Value != value {
+			t.Fatal("expected to poll", value, "got", entry.Value, ok)
+		}
+		if err := q.Ack(entry.Seq); err != nil {
+			t.Fatal("unexpected error from ack:", err)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package queue
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/list"
+)
+
+// WeightedFairQueue is an implementation of a queue which holds a FIFO
+// sub-queue (flow) for each key, and dequeues from the flows using
+// deficit round robin, so that no key can starve the others.
+//
+// Each value costs one unit. When a flow's turn comes, its deficit is
+// increased by its weight, and values are dequeued from it until its
+// deficit is spent or it is empty, after which the next flow takes its
+// turn. A flow with weight 3 therefore dequeues three values for each
+// value dequeued from a flow with weight 1, while both are non-empty.
+//
+// The value equality function may be omitted (nil)
+// if no methods are called which use it.
+type WeightedFairQueue[K comparable, V any] struct {
+	key           func(value V) K
+	eq            structs.EqualFunc[V]
+	flows         map[K]*fairFlow[K, V]
+	active        *list.List[*fairFlow[K, V]] // non-empty flows in turn order
+	weights       map[K]int
+	defaultWeight int
+	size          int
+}
+
+// fairFlow is the sub-queue for a single key.
+type fairFlow[K comparable, V any] struct {
+	key     K
+	data    *list.List[V]
+	deficit int
+	turn    bool // whether the flow's current turn has started
+}
+
+var _ structs.Queue[int] = (*WeightedFairQueue[int, int])(nil)
+
+// NewWeightedFair creates an empty WeightedFairQueue, which uses
+// the key function to determine the flow for each value.
+func NewWeightedFair[K comparable, V any](key func(value V) K, eq structs.EqualFunc[V]) *WeightedFairQueue[K, V] {
+	return &WeightedFairQueue[K, V]{
+		key:   key,
+		eq:    eq,
+		flows: make(map[K]*fairFlow[K, V]),
+		active: list.New(func(a, b *fairFlow[K, V]) bool {
+			return a == b
+		}),
+		weights:       make(map[K]int),
+		defaultWeight: 1,
+	}
+}
+
+// SetWeight sets the weight of the flow for a key, which takes
+// effect from the flow's next turn.
+//
+// Panics if the weight is less than 1.
+func (q *WeightedFairQueue[K, V]) SetWeight(key K, weight int) {
+	checkWeight(weight)
+	q.weights[key] = weight
+}
+
+// SetDefaultWeight sets the weight of flows for keys
+// which have not been given a weight with SetWeight.
+//
+// Panics if the weight is less than 1.
+func (q *WeightedFairQueue[K, V]) SetDefaultWeight(weight int) {
+	checkWeight(weight)
+	q.defaultWeight = weight
+}
+
+// Weight returns the weight of the flow for a key.
+func (q *WeightedFairQueue[K, V]) Weight(key K) int {
+	if weight, ok := q.weights[key]; ok {
+		return weight
+	}
+	return q.defaultWeight
+}
+
+// Add adds a value to the end of its flow. It always returns true.
+//
+// Time Complexity: O(1)
+func (q *WeightedFairQueue[K, V]) Add(value V) bool {
+	key := q.key(value)
+	flow, ok := q.flows[key]
+	if !ok {
+		flow = &fairFlow[K, V]{
+			key:  key,
+			data: list.New[V](q.eq),
+		}
+		q.flows[key] = flow
+		q.active.AddLast(flow)
+	}
+	flow.data.AddLast(value)
+	q.size++
+	return true
+}
+
+// AddAll adds all the values in the other collection to the queue.
+//
+// Time Complexity: O(m)
+func (q *WeightedFairQueue[K, V]) AddAll(other structs.Collection[V]) bool {
+	return q.AddIterator(other.Iterator())
+}
+
+// AddIterator adds all the values in the iterator to the queue.
+//
+// Time Complexity: O(m)
+func (q *WeightedFairQueue[K, V]) AddIterator(iter structs.Iterator[V]) bool {
+	changed := iter.HasNext()
+	for iter.HasNext() {
+		q.Add(iter.Next())
+	}
+	return changed
+}
+
+// Clear removes all the values from the queue. Weights are kept.
+func (q *WeightedFairQueue[K, V]) Clear() {
+	q.flows = make(map[K]*fairFlow[K, V])
+	q.active.Clear()
+	q.size = 0
+}
+
+// Contains returns whether the value is present in the queue.
+//
+// Time Complexity: O(n)
+func (q *WeightedFairQueue[K, V]) Contains(value V) bool {
+	flow, ok := q.flows[q.key(value)]
+	return ok && flow.data.Contains(value)
+}
+
+// ContainsAll returns whether all the values in the other collection are present in the queue.
+//
+// Time Complexity: O(nm)
+func (q *WeightedFairQueue[K, V]) ContainsAll(other structs.Collection[V]) bool {
+	return q.ContainsIterator(other.Iterator())
+}
+
+// ContainsIterator returns whether all the values in the iterator are present in the queue.
+//
+// Time Complexity: O(nm)
+func (q *WeightedFairQueue[K, V]) ContainsIterator(iter structs.Iterator[V]) bool {
+	for iter.HasNext() {
+		if !q.Contains(iter.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Element returns the value which will be dequeued next.
+//
+// Panics if the queue is empty.
+//
+// Time Complexity: O(1)
+func (q *WeightedFairQueue[K, V]) Element() V {
+	q.checkEmpty()
+
+	return q.active.GetFirst().data.GetFirst()
+}
+
+// IsEmpty returns whether the queue is empty.
+func (q *WeightedFairQueue[K, V]) IsEmpty() bool {
+	return q.size == 0
+}
+
+// Iterator returns an Iterator over the queue's values, flow
+// by flow in turn order, which is not necessarily the order
+// in which they will be dequeued.
+func (q *WeightedFairQueue[K, V]) Iterator() structs.Iterator[V] {
+	flows := q.active.Values()
+	lists := make([]*list.List[V], len(flows))
+	for i, flow := range flows {
+		lists[i] = flow.data
+	}
+	return newMultiIterator(lists, func(index int) {
+		q.size--
+		q.removeIfEmpty(flows[index])
+	})
+}
+
+// Offer adds a value to the end of its flow. It always returns true.
+//
+// Time Complexity: O(1)
+func (q *WeightedFairQueue[K, V]) Offer(value V) bool {
+	return q.Add(value)
+}
+
+// Peek returns the value which will be dequeued next, or
+// the zero value of the type if the queue is empty.
+//
+// Time Complexity: O(1)
+func (q *WeightedFairQueue[K, V]) Peek() V {
+	if q.IsEmpty() {
+		var null V
+		return null
+	}
+	return q.active.GetFirst().data.GetFirst()
+}
+
+// Poll removes and returns the next value from the flow whose turn it is,
+// or returns the zero value of the type if the queue is empty.
+//
+// Time Complexity: O(1)
+func (q *WeightedFairQueue[K, V]) Poll() V {
+	if q.IsEmpty() {
+		var null V
+		return null
+	}
+
+	flow := q.active.GetFirst()
+	if !flow.turn {
+		flow.deficit += q.Weight(flow.key)
+		flow.turn = true
+	}
+	value := flow.data.RemoveFirst()
+	flow.deficit--
+	q.size--
+
+	if !q.removeIfEmpty(flow) && flow.deficit == 0 {
+		// the flow's turn is over, so the next flow takes its turn
+		flow.turn = false
+		q.active.RemoveFirst()
+		q.active.AddLast(flow)
+	}
+	return value
+}
+
+// Remove removes the first occurrence of a value from
+// the queue and returns whether the value was present.
+//
+// Time Complexity: O(n)
+func (q *WeightedFairQueue[K, V]) Remove(value V) bool {
+	flow, ok := q.flows[q.key(value)]
+	if !ok || !flow.data.Remove(value) {
+		return false
+	}
+	q.size--
+	q.removeIfEmpty(flow)
+	return true
+}
+
+// RemoveAll removes all the values in the other collection from the queue.
+//
+// Time Complexity: O(nm)
+func (q *WeightedFairQueue[K, V]) RemoveAll(other structs.Collection[V]) bool {
+	return q.RemoveIterator(other.Iterator())
+}
+
+// RemoveHead removes and returns the next value from the flow whose turn it is.
+//
+// Panics if the queue is empty.
+//
+// Time Complexity: O(1)
+func (q *WeightedFairQueue[K, V]) RemoveHead() V {
+	q.checkEmpty()
+
+	return q.Poll()
+}
+
+// RemoveIterator removes all the values in the iterator from the queue.
+//
+// Time Complexity: O(nm)
+func (q *WeightedFairQueue[K, V]) RemoveIterator(iter structs.Iterator[V]) bool {
+	changed := false
+	for iter.HasNext() {
+		if q.Remove(iter.Next()) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// RetainAll removes all the values not present in the other collection from the queue.
+//
+// Time Complexity: O(nm)
+func (q *WeightedFairQueue[K, V]) RetainAll(other structs.Collection[V]) bool {
+	changed := false
+	it := q.Iterator()
+	for it.HasNext() {
+		if !other.Contains(it.Next()) {
+			it.Remove()
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Size returns the number of values in the queue.
+func (q *WeightedFairQueue[K, V]) Size() int {
+	return q.size
+}
+
+// FlowSize returns the number of values in the flow for a key.
+func (q *WeightedFairQueue[K, V]) FlowSize(key K) int {
+	flow, ok := q.flows[key]
+	if !ok {
+		return 0
+	}
+	return flow.data.Size()
+}
+
+// Values returns a slice of the values in the queue, flow by flow
+// in turn order, which is not necessarily the order in which they
+// will be dequeued.
+func (q *WeightedFairQueue[K, V]) Values() []V {
+	values := make([]V, 0, q.size)
+	q.active.Each(func(flow *fairFlow[K, V]) {
+		values = append(values, flow.data.Values()...)
+	})
+	return values
+}
+
+// removeIfEmpty removes the flow if it is empty,
+// forfeiting its deficit, and returns whether it did.
+func (q *WeightedFairQueue[K, V]) removeIfEmpty(flow *fairFlow[K, V]) bool {
+	if !flow.data.IsEmpty() {
+		return false
+	}
+	q.active.Remove(flow)
+	delete(q.flows, flow.key)
+	return true
+}
+
+// checkEmpty panics if the queue is empty.
+func (q *WeightedFairQueue[K, V]) checkEmpty() {
+	if q.IsEmpty() {
+		panic(structs.PanicNoSuchElement)
+	}
+}
+
+// checkWeight panics if a weight is less than 1.
+func checkWeight(weight int) {
+	if weight < 1 {
+		panic("weight must be at least 1")
+	}
+}
@@ -0,0 +1,145 @@
+package queue
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/wrap"
+	"golang.org/x/exp/slices"
+	"strings"
+	"testing"
+)
+
+type tenantJob struct {
+	tenant string
+	id     int
+}
+
+func newTenantQueue() *WeightedFairQueue[string, tenantJob] {
+	return NewWeightedFair(func(job tenantJob) string {
+		return job.tenant
+	}, func(a, b tenantJob) bool {
+		return a == b
+	})
+}
+
+func pollTenants(q *WeightedFairQueue[string, tenantJob], count int) string {
+	var buf strings.Builder
+	for i := 0; i < count; i++ {
+		buf.WriteString(q.Poll().tenant)
+	}
+	return buf.String()
+}
+
+func TestWeightedFairQueue(t *testing.T) {
+	q := newTenantQueue()
+	q.SetWeight("a", 3)
+	for i := 0; i < 10; i++ {
+		q.Add(tenantJob{"a", i})
+		q.Add(tenantJob{"b", i})
+	}
+	if q.Weight("a") != 3 || q.Weight("b") != 1 {
+		t.Errorf("expected weights 3 and 1 but got %d and %d", q.Weight("a"), q.Weight("b"))
+	}
+
+	got := pollTenants(q, 12)
+	if expect := "aaabaaabaaab"; got != expect {
+		t.Errorf("expected tenants %s but got %s", expect, got)
+	}
+
+	// a flow which joins later takes its turn after the existing flows
+	q.Add(tenantJob{"c", 0})
+	q.Add(tenantJob{"c", 1})
+	got = pollTenants(q, 10)
+	if expect := "abcbcbbbbb"; got != expect {
+		t.Errorf("expected tenants %s but got %s", expect, got)
+	}
+	if !q.IsEmpty() {
+		t.Error("expected queue to be empty, got size", q.Size())
+	}
+}
+
+func TestWeightedFairQueue_FIFO(t *testing.T) {
+	q := newTenantQueue()
+	q.SetDefaultWeight(2)
+	for i := 0; i < 5; i++ {
+		q.Add(tenantJob{"a", i})
+		q.Add(tenantJob{"b", i})
+	}
+
+	next := map[string]int{}
+	for !q.IsEmpty() {
+		job := q.RemoveHead()
+		if job.id != next[job.tenant] {
+			t.Errorf("expected job %d for tenant %s but got %d", next[job.tenant], job.tenant, job.id)
+		}
+		next[job.tenant]++
+	}
+}
+
+func TestWeightedFairQueue_Collection(t *testing.T) {
+	var q structs.Queue[int] = NewWeightedFair(func(value int) int {
+		return value % 3
+	}, structs.EqualOrdered[int])
+
+	q.AddAll(wrap.OrderedValues(0, 1, 2, 3, 4, 5, 6, 7, 8))
+	if q.Size() != 9 {
+		t.Errorf("expected size 9 but got %d", q.Size())
+	}
+	if !q.ContainsAll(wrap.OrderedValues(0, 8)) || q.Contains(9) {
+		t.Error("expected queue to contain 0 and 8 but not 9")
+	}
+	if q.Element() != 0 || q.Peek() != 0 {
+		t.Error("expected the head of the queue to be 0")
+	}
+
+	q.Remove(4)
+	q.RemoveAll(wrap.OrderedValues(1, 7)) // empties flow 1
+	q.RetainAll(wrap.OrderedValues(0, 2, 3, 5, 6, 8))
+	if q.Size() != 6 {
+		t.Errorf("expected size 6 but got %d", q.Size())
+	}
+
+	var got []int
+	for !q.IsEmpty() {
+		got = append(got, q.Poll())
+	}
+	if expect := []int{0, 2, 3, 5, 6, 8}; !slices.Equal(got, expect) {
+		t.Errorf("expected values %v but got %v", expect, got)
+	}
+}
+
+func TestWeightedFairQueue_IteratorRemove(t *testing.T) {
+	q := newTenantQueue()
+	q.Add(tenantJob{"a", 0})
+	q.Add(tenantJob{"b", 0})
+	q.Add(tenantJob{"b", 1})
+
+	it := q.Iterator()
+	for it.HasNext() {
+		if it.Next().tenant == "a" {
+			it.Remove()
+		}
+	}
+	if q.Size() != 2 || q.FlowSize("a") != 0 || q.FlowSize("b") != 2 {
+		t.Errorf("expected only tenant b's 2 jobs to remain, got %v", q.Values())
+	}
+	if got := pollTenants(q, 2); got != "bb" {
+		t.Errorf("expected tenants bb but got %s", got)
+	}
+}
+
+func TestWeightedFairQueue_IteratorRemoveAtBoundary(t *testing.T) {
+	q := newTenantQueue()
+	q.Add(tenantJob{"a", 0})
+	q.Add(tenantJob{"b", 0})
+
+	it := q.Iterator()
+	it.Next()
+	it.HasNext() // moves on to the next flow
+	it.Remove()  // removes a's job, from the previous flow
+	if q.Size() != 1 || q.FlowSize("a") != 0 || q.FlowSize("b") != 1 {
+		t.Errorf("expected only tenant b's job to remain, got %v", q.Values())
+	}
+	if got := pollTenants(q, 1); got != "b" {
+		t.Errorf("expected tenant b but got %s", got)
+	}
+}
@@ -0,0 +1,58 @@
+package queue
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/list"
+)
+
+// multiIterator is an iterator over several lists in turn,
+// used by queues which are composed of multiple sub-queues.
+type multiIterator[V any] struct {
+	lists []*list.List[V]
+	index int
+	iter  structs.Iterator[V]
+	// lastIter and lastIndex are the iterator and list index which
+	// produced the last value, since HasNext may move on to the next
+	// list before Remove is called.
+	lastIter  structs.Iterator[V]
+	lastIndex int
+	// removed is called with the index of the
+	// list from which a value has been removed.
+	removed func(index int)
+}
+
+func newMultiIterator[V any](lists []*list.List[V], removed func(index int)) *multiIterator[V] {
+	return &multiIterator[V]{
+		lists:   lists,
+		index:   -1,
+		removed: removed,
+	}
+}
+
+func (it *multiIterator[V]) HasNext() bool {
+	for it.iter == nil || !it.iter.HasNext() {
+		if it.index+1 >= len(it.lists) {
+			return false
+		}
+		it.index++
+		it.iter = it.lists[it.index].Iterator()
+	}
+	return true
+}
+
+func (it *multiIterator[V]) Next() V {
+	if !it.HasNext() {
+		panic(structs.PanicNoSuchElement)
+	}
+	it.lastIter, it.lastIndex = it.iter, it.index
+	return it.iter.Next()
+}
+
+func (it *multiIterator[V]) Remove() {
+	if it.lastIter == nil {
+		panic(structs.PanicIllegalState)
+	}
+	it.lastIter.Remove()
+	it.lastIter = nil
+	it.removed(it.lastIndex)
+}
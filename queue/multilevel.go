@@ -0,0 +1,353 @@
+package queue
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/list"
+	"golang.org/x/exp/constraints"
+)
+
+// MultiLevelQueue is an implementation of a multi-level feedback queue,
+// which holds a FIFO queue for each priority level. Values are dequeued
+// from the highest priority (lowest numbered) level which is non-empty.
+//
+// New values enter at the highest priority level. A consumer which is
+// not finished with a value after its turn can Demote it, so long-running
+// values sink to lower priority levels and short ones are served first.
+// To prevent starvation, all values can be periodically moved back to
+// the highest priority level with Boost, or automatically with
+// SetBoostInterval.
+//
+// The value equality function may be omitted (nil)
+// if no methods are called which use it.
+type MultiLevelQueue[V any] struct {
+	levels        []*list.List[V]
+	size          int
+	boostInterval int // number of polls between boosts, or 0 to never boost
+	polls         int // number of polls since the last boost
+}
+
+var _ structs.Queue[int] = (*MultiLevelQueue[int])(nil)
+
+// NewMultiLevel creates an empty MultiLevelQueue with the number of levels.
+//
+// Panics if there are fewer than 1 levels.
+func NewMultiLevel[V any](levels int, eq structs.EqualFunc[V]) *MultiLevelQueue[V] {
+	if levels < 1 {
+		panic("multi-level queue must have at least 1 level")
+	}
+	q := &MultiLevelQueue[V]{
+		levels: make([]*list.List[V], levels),
+	}
+	for i := range q.levels {
+		q.levels[i] = list.New[V](eq)
+	}
+	return q
+}
+
+// NewOrderedMultiLevel creates an empty MultiLevelQueue with the number
+// of levels from a type that implements constraints.Ordered.
+//
+// Panics if there are fewer than 1 levels.
+func NewOrderedMultiLevel[V constraints.Ordered](levels int) *MultiLevelQueue[V] {
+	return NewMultiLevel[V](levels, structs.EqualOrdered[V])
+}
+
+// SetBoostInterval sets the number of polls after which all values are
+// moved back to the highest priority level, or 0 to never boost automatically.
+func (q *MultiLevelQueue[V]) SetBoostInterval(polls int) {
+	q.boostInterval = polls
+	q.polls = 0
+}
+
+// Levels returns the number of levels in the queue.
+func (q *MultiLevelQueue[V]) Levels() int {
+	return len(q.levels)
+}
+
+// LevelSize returns the number of values at a level.
+//
+// Panics if the level is out of bounds.
+func (q *MultiLevelQueue[V]) LevelSize(level int) int {
+	q.checkLevel(level)
+
+	return q.levels[level].Size()
+}
+
+// Add adds a value to the end of the highest priority level. It always returns true.
+//
+// Time Complexity: O(1)
+func (q *MultiLevelQueue[V]) Add(value V) bool {
+	q.AddLevel(value, 0)
+	return true
+}
+
+// AddLevel adds a value to the end of a level.
+//
+// Panics if the level is out of bounds.
+//
+// Time Complexity: O(1)
+func (q *MultiLevelQueue[V]) AddLevel(value V, level int) {
+	q.checkLevel(level)
+
+	q.levels[level].AddLast(value)
+	q.size++
+}
+
+// AddAll adds all the values in the other collection to the highest priority level.
+//
+// Time Complexity: O(m)
+func (q *MultiLevelQueue[V]) AddAll(other structs.Collection[V]) bool {
+	return q.AddIterator(other.Iterator())
+}
+
+// AddIterator adds all the values in the iterator to the highest priority level.
+//
+// Time Complexity: O(m)
+func (q *MultiLevelQueue[V]) AddIterator(iter structs.Iterator[V]) bool {
+	changed := iter.HasNext()
+	for iter.HasNext() {
+		q.Add(iter.Next())
+	}
+	return changed
+}
+
+// Boost moves all values back to the highest priority level,
+// keeping higher priority values ahead of lower priority ones.
+//
+// Time Complexity: O(n)
+func (q *MultiLevelQueue[V]) Boost() {
+	top := q.levels[0]
+	for _, level := range q.levels[1:] {
+		for !level.IsEmpty() {
+			top.AddLast(level.RemoveFirst())
+		}
+	}
+	q.polls = 0
+}
+
+// Clear removes all the values from the queue.
+func (q *MultiLevelQueue[V]) Clear() {
+	for _, level := range q.levels {
+		level.Clear()
+	}
+	q.size = 0
+	q.polls = 0
+}
+
+// Contains returns whether the value is present in the queue.
+//
+// Time Complexity: O(n)
+func (q *MultiLevelQueue[V]) Contains(value V) bool {
+	for _, level := range q.levels {
+		if level.Contains(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsAll returns whether all the values in the other collection are present in the queue.
+//
+// Time Complexity: O(nm)
+func (q *MultiLevelQueue[V]) ContainsAll(other structs.Collection[V]) bool {
+	return q.ContainsIterator(other.Iterator())
+}
+
+// ContainsIterator returns whether all the values in the iterator are present in the queue.
+//
+// Time Complexity: O(nm)
+func (q *MultiLevelQueue[V]) ContainsIterator(iter structs.Iterator[V]) bool {
+	for iter.HasNext() {
+		if !q.Contains(iter.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Demote adds a value to the end of the level below the one it
+// was dequeued from, or to the lowest priority level if it was
+// dequeued from there.
+//
+// Panics if the level is out of bounds.
+//
+// Time Complexity: O(1)
+func (q *MultiLevelQueue[V]) Demote(value V, from int) {
+	q.checkLevel(from)
+
+	if from < len(q.levels)-1 {
+		from++
+	}
+	q.AddLevel(value, from)
+}
+
+// Element returns the value at the head of the queue.
+//
+// Panics if the queue is empty.
+//
+// Time Complexity: O(l)
+func (q *MultiLevelQueue[V]) Element() V {
+	q.checkEmpty()
+
+	return q.Peek()
+}
+
+// IsEmpty returns whether the queue is empty.
+func (q *MultiLevelQueue[V]) IsEmpty() bool {
+	return q.size == 0
+}
+
+// Iterator returns an Iterator over the queue's values, from
+// the highest priority level to the lowest priority level.
+func (q *MultiLevelQueue[V]) Iterator() structs.Iterator[V] {
+	return newMultiIterator(q.levels, func(int) {
+		q.size--
+	})
+}
+
+// Offer adds a value to the end of the highest priority level. It always returns true.
+//
+// Time Complexity: O(1)
+func (q *MultiLevelQueue[V]) Offer(value V) bool {
+	return q.Add(value)
+}
+
+// Peek returns the value at the head of the queue, or
+// the zero value of the type if the queue is empty.
+//
+// Time Complexity: O(l)
+//
+//	l = number of levels
+func (q *MultiLevelQueue[V]) Peek() V {
+	for _, level := range q.levels {
+		if !level.IsEmpty() {
+			return level.GetFirst()
+		}
+	}
+	var null V
+	return null
+}
+
+// Poll removes and returns the value at the head of the queue,
+// or returns the zero value of the type if the queue is empty.
+//
+// Time Complexity: O(l), or O(n) when boosting
+//
+//	l = number of levels
+func (q *MultiLevelQueue[V]) Poll() V {
+	value, _, _ := q.PollLevel()
+	return value
+}
+
+// PollLevel removes and returns the value at the head of the queue,
+// the level it was at, and true, or returns the zero value of the type,
+// -1 and false if the queue is empty. The level can be passed to
+// Demote if the value is re-queued.
+//
+// Time Complexity: O(l), or O(n) when boosting
+//
+//	l = number of levels
+func (q *MultiLevelQueue[V]) PollLevel() (V, int, bool) {
+	for i, level := range q.levels {
+		if !level.IsEmpty() {
+			value := level.RemoveFirst()
+			q.size--
+			q.polls++
+			if q.boostInterval > 0 && q.polls >= q.boostInterval {
+				q.Boost()
+			}
+			return value, i, true
+		}
+	}
+	var null V
+	return null, -1, false
+}
+
+// Remove removes the first occurrence of a value from
+// the queue and returns whether the value was present.
+//
+// Time Complexity: O(n)
+func (q *MultiLevelQueue[V]) Remove(value V) bool {
+	for _, level := range q.levels {
+		if level.Remove(value) {
+			q.size--
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveAll removes all the values in the other collection from the queue.
+//
+// Time Complexity: O(nm)
+func (q *MultiLevelQueue[V]) RemoveAll(other structs.Collection[V]) bool {
+	return q.RemoveIterator(other.Iterator())
+}
+
+// RemoveHead removes and returns the value at the head of the queue.
+//
+// Panics if the queue is empty.
+//
+// Time Complexity: O(l), or O(n) when boosting
+func (q *MultiLevelQueue[V]) RemoveHead() V {
+	q.checkEmpty()
+
+	return q.Poll()
+}
+
+// RemoveIterator removes all the values in the iterator from the queue.
+//
+// Time Complexity: O(nm)
+func (q *MultiLevelQueue[V]) RemoveIterator(iter structs.Iterator[V]) bool {
+	changed := false
+	for iter.HasNext() {
+		if q.Remove(iter.Next()) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// RetainAll removes all the values not present in the other collection from the queue.
+//
+// Time Complexity: O(nm)
+func (q *MultiLevelQueue[V]) RetainAll(other structs.Collection[V]) bool {
+	changed := false
+	for _, level := range q.levels {
+		before := level.Size()
+		if level.RetainAll(other) {
+			q.size -= before - level.Size()
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Size returns the number of values in the queue.
+func (q *MultiLevelQueue[V]) Size() int {
+	return q.size
+}
+
+// Values returns a slice of the values in the queue, from
+// the highest priority level to the lowest priority level.
+func (q *MultiLevelQueue[V]) Values() []V {
+	values := make([]V, 0, q.size)
+	for _, level := range q.levels {
+		values = append(values, level.Values()...)
+	}
+	return values
+}
+
+// checkEmpty panics if the queue is empty.
+func (q *MultiLevelQueue[V]) checkEmpty() {
+	if q.IsEmpty() {
+		panic(structs.PanicNoSuchElement)
+	}
+}
+
+// checkLevel panics if the level is out of bounds.
+func (q *MultiLevelQueue[V]) checkLevel(level int) {
+	if level < 0 || level >= len(q.levels) {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+}
@@ -0,0 +1,114 @@
+package queue
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/wrap"
+	"golang.org/x/exp/slices"
+	"testing"
+)
+
+func TestMultiLevelQueue(t *testing.T) {
+	q := NewOrderedMultiLevel[int](3)
+	q.Add(1)
+	q.Add(2)
+	q.AddLevel(3, 2)
+
+	value, level, ok := q.PollLevel()
+	if !ok || value != 1 || level != 0 {
+		t.Fatalf("expected (1, 0, true) but got (%d, %d, %t)", value, level, ok)
+	}
+	q.Demote(value, level) // 1 moves to level 1
+
+	expect := []int{2, 1, 3}
+	if !slices.Equal(q.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, q.Values())
+	}
+
+	// values at the lowest level stay there when demoted
+	q.Demote(4, 2)
+	if q.LevelSize(2) != 2 {
+		t.Errorf("expected 2 values at the lowest level but got %d", q.LevelSize(2))
+	}
+
+	var got []int
+	for !q.IsEmpty() {
+		got = append(got, q.RemoveHead())
+	}
+	if expect := []int{2, 1, 3, 4}; !slices.Equal(got, expect) {
+		t.Errorf("expected values %v but got %v", expect, got)
+	}
+	if _, _, ok := q.PollLevel(); ok {
+		t.Error("expected polling an empty queue to fail")
+	}
+}
+
+func TestMultiLevelQueue_Boost(t *testing.T) {
+	q := NewOrderedMultiLevel[int](3)
+	q.AddLevel(3, 2)
+	q.AddLevel(2, 1)
+	q.Add(1)
+	q.Boost()
+	if q.LevelSize(0) != 3 {
+		t.Errorf("expected all values at the highest level but got %d", q.LevelSize(0))
+	}
+	if expect := []int{1, 2, 3}; !slices.Equal(q.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, q.Values())
+	}
+
+	q.Clear()
+	q.SetBoostInterval(2)
+	q.AddLevel(1, 2)
+	q.Add(2)
+	q.Add(3)
+	q.Poll()
+	q.Poll() // boosts 1 to the highest level
+	if q.LevelSize(0) != 1 {
+		t.Errorf("expected automatic boost after 2 polls, got %v at the highest level", q.LevelSize(0))
+	}
+}
+
+func TestMultiLevelQueue_Collection(t *testing.T) {
+	mlq := NewOrderedMultiLevel[int](2)
+	var q structs.Queue[int] = mlq
+
+	q.AddAll(wrap.OrderedValues(1, 2, 3))
+	mlq.AddLevel(4, 1)
+	mlq.AddLevel(5, 1)
+	if !q.ContainsAll(wrap.OrderedValues(1, 5)) || q.Contains(6) {
+		t.Error("expected queue to contain 1 and 5 but not 6")
+	}
+
+	q.Remove(2)
+	q.RemoveAll(wrap.OrderedValues(4))
+	q.RetainAll(wrap.OrderedValues(1, 5))
+	if q.Size() != 2 {
+		t.Errorf("expected size 2 but got %d", q.Size())
+	}
+
+	it := q.Iterator()
+	for it.HasNext() {
+		if it.Next() == 1 {
+			it.Remove()
+		}
+	}
+	if q.Size() != 1 || q.Element() != 5 {
+		t.Errorf("expected only 5 to remain but got %v", q.Values())
+	}
+}
+
+func TestMultiLevelQueue_IteratorRemoveAtBoundary(t *testing.T) {
+	q := NewOrderedMultiLevel[int](2)
+	q.AddLevel(1, 0)
+	q.AddLevel(2, 1)
+
+	it := q.Iterator()
+	it.Next()
+	it.HasNext() // moves on to the next level
+	it.Remove()  // removes 1, from the previous level
+	if q.Size() != 1 || q.LevelSize(0) != 0 || q.LevelSize(1) != 1 {
+		t.Errorf("expected only 2 to remain at level 1 but got %v", q.Values())
+	}
+	if it.Next() != 2 {
+		t.Error("expected the iterator to continue with 2")
+	}
+}
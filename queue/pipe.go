@@ -0,0 +1,56 @@
+package queue
+
+import "context"
+
+// Pipe returns a pair of channels connected by an unbounded buffer,
+// so sending on the input channel never blocks for long, regardless
+// of how far the receiver of the output channel falls behind.
+//
+// Values are received from the output channel in the order they were
+// sent. When the input channel is closed, the remaining buffered
+// values are delivered and then the output channel is closed. If the
+// context is done first, buffered values are discarded and the output
+// channel is closed, but values sent afterwards are still received and
+// discarded, so senders never block. The input channel must be closed
+// eventually to stop the pipe's goroutine.
+func Pipe[V any](ctx context.Context) (chan<- V, <-chan V) {
+	in := make(chan V)
+	out := make(chan V)
+	go pipe(ctx, in, out)
+	return in, out
+}
+
+func pipe[V any](ctx context.Context, in <-chan V, out chan<- V) {
+	buffer := New[V](nil)
+	for in != nil || !buffer.IsEmpty() {
+		// sending on a nil channel blocks forever, which
+		// disables the send case while the buffer is empty
+		var send chan<- V
+		var head V
+		if !buffer.IsEmpty() {
+			send = out
+			head = buffer.Peek()
+		}
+
+		select {
+		case value, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+			buffer.Enqueue(value)
+		case send <- head:
+			buffer.Dequeue()
+		case <-ctx.Done():
+			close(out)
+			// receiving from a nil channel blocks forever,
+			// so only drain the input channel if it is open
+			if in != nil {
+				for range in {
+				}
+			}
+			return
+		}
+	}
+	close(out)
+}
@@ -0,0 +1,96 @@
+package queue
+
+import (
+	"context"
+	"github.com/zytekaron/structs/wrap"
+	"golang.org/x/exp/slices"
+	"testing"
+	"time"
+)
+
+func TestPipe(t *testing.T) {
+	const count = 1000
+	in, out := Pipe[int](context.Background())
+
+	// every value can be sent before any are received
+	for i := 0; i < count; i++ {
+		in <- i
+	}
+	close(in)
+
+	q := NewOrdered[int]()
+	if !wrap.AddChan[int](q, out) {
+		t.Error("expected values to be added from the channel")
+	}
+	if q.Size() != count {
+		t.Fatalf("expected %d values but got %d", count, q.Size())
+	}
+	for i := 0; i < count; i++ {
+		if value := q.Dequeue(); value != i {
+			t.Fatalf("expected %d but got %d", i, value)
+		}
+	}
+}
+
+func TestPipe_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in, out := Pipe[int](ctx)
+	in <- 1
+	in <- 2
+	cancel()
+
+	select {
+	case <-time.After(time.Second):
+		t.Fatal("expected the output channel to close after cancellation")
+	case _, ok := <-out:
+		// a buffered value may be delivered before the pipe notices
+		if ok {
+			for range out {
+			}
+		}
+	}
+}
+
+func TestPipe_SendAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in, out := Pipe[int](ctx)
+	cancel()
+	for range out {
+	}
+
+	// senders which do not watch the context never block
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			in <- i
+		}
+		close(in)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected sends to succeed after cancellation")
+	}
+}
+
+func TestPipe_Iterator(t *testing.T) {
+	q := NewOrdered[int]()
+	q.AddAll(wrap.OrderedValues(1, 2, 3))
+
+	in, out := Pipe[int](context.Background())
+	go func() {
+		for value := range wrap.IteratorChan(context.Background(), q.Iterator()) {
+			in <- value
+		}
+		close(in)
+	}()
+
+	var got []int
+	for value := range out {
+		got = append(got, value)
+	}
+	if expect := []int{1, 2, 3}; !slices.Equal(got, expect) {
+		t.Errorf("expected values %v but got %v", expect, got)
+	}
+}
@@ -5,56 +5,347 @@ import (
 	"github.com/zytekaron/structs/heap"
 )
 
+// PriorityQueue is an implementation of a priority queue
+// backed by *heap.Heap, which dequeues the smallest value
+// first according to its comparison function.
+//
+// In stable mode, values which compare as equal are dequeued
+// in the order in which they were enqueued. Otherwise, the
+// order of equal values is unspecified.
 type PriorityQueue[V any] struct {
-	heap *heap.Heap[V]
+	cmp    structs.CompareFunc[V]
+	heap   *heap.Heap[priorityEntry[V]]
+	stable bool
+	seq    uint64 // sequence number of the next enqueued value
+}
+
+var _ structs.Queue[int] = (*PriorityQueue[int])(nil)
+
+// priorityEntry is a value in a priority queue along with
+// the sequence number used to break ties in stable mode.
+type priorityEntry[V any] struct {
+	value V
+	seq   uint64
 }
 
 func NewPriority[V any](cmp structs.CompareFunc[V]) *PriorityQueue[V] {
-	return &PriorityQueue[V]{
-		heap: heap.New(cmp),
-	}
+	return newPriority(0, cmp, false)
 }
 
 func NewPriorityCap[V any](capacity int, cmp structs.CompareFunc[V]) *PriorityQueue[V] {
-	return &PriorityQueue[V]{
-		heap: heap.NewCap(capacity, cmp),
-	}
+	return newPriority(capacity, cmp, false)
+}
+
+// NewStablePriority creates an empty PriorityQueue in stable mode,
+// which dequeues equal values in insertion (FIFO) order.
+func NewStablePriority[V any](cmp structs.CompareFunc[V]) *PriorityQueue[V] {
+	return newPriority(0, cmp, true)
+}
+
+// NewStablePriorityCap creates an empty PriorityQueue in stable mode with
+// an initial capacity, which dequeues equal values in insertion (FIFO) order.
+func NewStablePriorityCap[V any](capacity int, cmp structs.CompareFunc[V]) *PriorityQueue[V] {
+	return newPriority(capacity, cmp, true)
 }
 
+// FromPriorityHeap creates a PriorityQueue containing the values of
+// an existing heap, ordered by the heap's comparison function.
+//
+// The values are copied, so the heap is not modified by the queue.
 func FromPriorityHeap[V any](h *heap.Heap[V]) *PriorityQueue[V] {
+	p := newPriority(h.Size(), h.CompareFunc(), false)
+	for _, value := range h.Values() {
+		p.Enqueue(value)
+	}
+	return p
+}
+
+func newPriority[V any](capacity int, cmp structs.CompareFunc[V], stable bool) *PriorityQueue[V] {
+	entryCmp := func(a, b priorityEntry[V]) int {
+		return cmp(a.value, b.value)
+	}
+	if stable {
+		entryCmp = func(a, b priorityEntry[V]) int {
+			if result := cmp(a.value, b.value); result != 0 {
+				return result
+			}
+			return structs.CompareOrdered(a.seq, b.seq)
+		}
+	}
 	return &PriorityQueue[V]{
-		heap: h,
+		cmp:    cmp,
+		heap:   heap.NewCap(capacity, entryCmp),
+		stable: stable,
 	}
 }
 
+// IsStable returns whether the queue dequeues
+// equal values in insertion (FIFO) order.
+func (p *PriorityQueue[V]) IsStable() bool {
+	return p.stable
+}
+
+// Add adds a value to the queue. It always returns true.
+//
+// Time Complexity: O(logn)
+func (p *PriorityQueue[V]) Add(value V) bool {
+	p.Enqueue(value)
+	return true
+}
+
+// AddAll adds all the values in the other collection to the queue.
+//
+// Time Complexity: O(mlog(n+m))
+func (p *PriorityQueue[V]) AddAll(other structs.Collection[V]) bool {
+	return p.AddIterator(other.Iterator())
+}
+
+// AddIterator adds all the values in the iterator to the queue.
+//
+// Time Complexity: O(mlog(n+m))
+func (p *PriorityQueue[V]) AddIterator(iter structs.Iterator[V]) bool {
+	changed := iter.HasNext()
+	for iter.HasNext() {
+		p.Enqueue(iter.Next())
+	}
+	return changed
+}
+
+// Clear removes all the values from the queue.
+func (p *PriorityQueue[V]) Clear() {
+	p.heap.Clear()
+	p.seq = 0
+}
+
+// Contains returns whether the value is present in the queue,
+// using the queue's comparison function for equality.
+//
+// Time Complexity: O(n)
+func (p *PriorityQueue[V]) Contains(value V) bool {
+	return p.indexOf(value) >= 0
+}
+
+// ContainsAll returns whether all the values in the other collection are present in the queue.
+//
+// Time Complexity: O(nm)
+func (p *PriorityQueue[V]) ContainsAll(other structs.Collection[V]) bool {
+	return p.ContainsIterator(other.Iterator())
+}
+
+// ContainsIterator returns whether all the values in the iterator are present in the queue.
+//
+// Time Complexity: O(nm)
+func (p *PriorityQueue[V]) ContainsIterator(iter structs.Iterator[V]) bool {
+	for iter.HasNext() {
+		if !p.Contains(iter.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Dequeue removes and returns the value at the head of the queue.
+//
+// Panics if the queue is empty.
+//
+// Time Complexity: O(logn)
+func (p *PriorityQueue[V]) Dequeue() V {
+	p.checkEmpty()
+
+	return p.heap.Pop().value
+}
+
+// Element returns the value at the head of the queue.
+//
+// Panics if the queue is empty.
+//
+// Time Complexity: O(1)
+func (p *PriorityQueue[V]) Element() V {
+	p.checkEmpty()
+
+	return p.heap.Peek().value
+}
+
+// Enqueue adds a value to the queue.
+//
+// Time Complexity: O(logn)
+func (p *PriorityQueue[V]) Enqueue(value V) {
+	p.heap.Push(priorityEntry[V]{
+		value: value,
+		seq:   p.seq,
+	})
+	p.seq++
+}
+
+// IsEmpty returns whether the queue is empty.
 func (p *PriorityQueue[V]) IsEmpty() bool {
 	return p.heap.IsEmpty()
 }
 
-func (p *PriorityQueue[V]) Enqueue(value V) {
-	p.heap.Push(value)
+// Iterator returns an Iterator over the queue's values,
+// which are not necessarily returned in priority order.
+func (p *PriorityQueue[V]) Iterator() structs.Iterator[V] {
+	return &PriorityIterator[V]{
+		iter: p.heap.Iterator(),
+	}
 }
 
+// Offer adds a value to the queue. It always returns true.
+//
+// Time Complexity: O(logn)
+func (p *PriorityQueue[V]) Offer(value V) bool {
+	p.Enqueue(value)
+	return true
+}
+
+// Peek returns the value at the head of the queue, or
+// the zero value of the type if the queue is empty.
+//
+// Time Complexity: O(1)
 func (p *PriorityQueue[V]) Peek() V {
-	return p.heap.Peek()
+	value, _ := p.TryPeek()
+	return value
 }
 
-func (p *PriorityQueue[V]) Dequeue() V {
-	return p.heap.Pop()
+// Poll removes and returns the value at the head of the queue,
+// or returns the zero value of the type if the queue is empty.
+//
+// Time Complexity: O(logn)
+func (p *PriorityQueue[V]) Poll() V {
+	value, _ := p.TryPoll()
+	return value
 }
 
-func (p *PriorityQueue[V]) Contains(value V) bool {
-	return p.heap.Contains(value)
+// Remove removes a value from the queue and returns whether the
+// value was present, using the queue's comparison function for equality.
+//
+// Time Complexity: O(n)
+func (p *PriorityQueue[V]) Remove(value V) bool {
+	i := p.indexOf(value)
+	if i < 0 {
+		return false
+	}
+	p.heap.RemoveIndex(i)
+	return true
+}
+
+// RemoveAll removes all the values in the other collection from the queue.
+//
+// Time Complexity: O(nm)
+func (p *PriorityQueue[V]) RemoveAll(other structs.Collection[V]) bool {
+	return p.RemoveIterator(other.Iterator())
+}
+
+// RemoveHead removes and returns the value at the head of the queue.
+//
+// Panics if the queue is empty.
+//
+// Time Complexity: O(logn)
+func (p *PriorityQueue[V]) RemoveHead() V {
+	return p.Dequeue()
+}
+
+// RemoveIterator removes all the values in the iterator from the queue.
+//
+// Time Complexity: O(nm)
+func (p *PriorityQueue[V]) RemoveIterator(iter structs.Iterator[V]) bool {
+	changed := false
+	for iter.HasNext() {
+		if p.Remove(iter.Next()) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// RetainAll removes all the values not present in the other collection from the queue.
+//
+// Time Complexity: O(nm)
+func (p *PriorityQueue[V]) RetainAll(other structs.Collection[V]) bool {
+	changed := false
+	it := p.heap.Iterator()
+	for it.HasNext() {
+		if !other.Contains(it.Next().value) {
+			it.Remove()
+			changed = true
+		}
+	}
+	return changed
 }
 
+// Size returns the number of values in the queue.
 func (p *PriorityQueue[V]) Size() int {
 	return p.heap.Size()
 }
 
-func (p *PriorityQueue[V]) Clear() {
-	p.heap.Clear()
+// TryPeek returns the value at the head of the queue and true,
+// or the zero value of the type and false if the queue is empty.
+//
+// Time Complexity: O(1)
+func (p *PriorityQueue[V]) TryPeek() (V, bool) {
+	if p.heap.IsEmpty() {
+		var null V
+		return null, false
+	}
+	return p.heap.Peek().value, true
+}
+
+// TryPoll removes and returns the value at the head of the queue and true,
+// or returns the zero value of the type and false if the queue is empty.
+//
+// Time Complexity: O(logn)
+func (p *PriorityQueue[V]) TryPoll() (V, bool) {
+	if p.heap.IsEmpty() {
+		var null V
+		return null, false
+	}
+	return p.heap.Pop().value, true
 }
 
+// Values returns a slice of the values in the queue,
+// which are not necessarily in priority order.
+//
+// Time Complexity: O(n)
 func (p *PriorityQueue[V]) Values() []V {
-	return p.heap.Values()
+	entries := p.heap.Values()
+	values := make([]V, len(entries))
+	for i, entry := range entries {
+		values[i] = entry.value
+	}
+	return values
+}
+
+// checkEmpty panics if the queue is empty.
+func (p *PriorityQueue[V]) checkEmpty() {
+	if p.heap.IsEmpty() {
+		panic(structs.PanicNoSuchElement)
+	}
+}
+
+// indexOf returns the heap index of the first entry whose
+// value compares as equal to the value, or -1 if none does.
+func (p *PriorityQueue[V]) indexOf(value V) int {
+	for i, entry := range p.heap.Values() {
+		if p.cmp(entry.value, value) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// PriorityIterator is an iterator over the values of a PriorityQueue.
+type PriorityIterator[V any] struct {
+	iter structs.Iterator[priorityEntry[V]]
+}
+
+func (i *PriorityIterator[V]) HasNext() bool {
+	return i.iter.HasNext()
+}
+
+func (i *PriorityIterator[V]) Next() V {
+	return i.iter.Next().value
+}
+
+func (i *PriorityIterator[V]) Remove() {
+	i.iter.Remove()
 }
@@ -2,6 +2,8 @@ package queue
 
 import (
 	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/heap"
+	"github.com/zytekaron/structs/wrap"
 	"math/rand"
 	"sort"
 	"testing"
@@ -49,3 +51,140 @@ func TestPriorityQueue(t *testing.T) {
 		}
 	}
 }
+
+func TestStablePriorityQueue(t *testing.T) {
+	type job struct {
+		priority int
+		id       int
+	}
+
+	const count = 256
+	pq := NewStablePriority(func(a, b job) int {
+		return structs.CompareOrdered(a.priority, b.priority)
+	})
+	if !pq.IsStable() {
+		t.Fatal("expected stable priority queue")
+	}
+
+	// few priorities, so most jobs tie with many others
+	for i := 0; i < count; i++ {
+		pq.Enqueue(job{priority: rand.Intn(4), id: i})
+	}
+
+	last := job{priority: -1, id: -1}
+	for !pq.IsEmpty() {
+		got := pq.Dequeue()
+		if got.priority < last.priority {
+			t.Fatalf("expected priority of at least %d but got %d", last.priority, got.priority)
+		}
+		if got.priority == last.priority && got.id < last.id {
+			t.Fatalf("expected job %d to be dequeued after job %d", got.id, last.id)
+		}
+		last = got
+	}
+}
+
+func TestFromPriorityHeap(t *testing.T) {
+	h := heap.FromOrdered([]int{5, 3, 1, 4, 2})
+	pq := FromPriorityHeap(h)
+	if pq.IsStable() {
+		t.Error("expected priority queue from heap not to be stable")
+	}
+
+	for expect := 1; expect <= 5; expect++ {
+		got := pq.Dequeue()
+		if got != expect {
+			t.Errorf("expected %d but got %d", expect, got)
+		}
+	}
+	if h.Size() != 5 {
+		t.Errorf("expected heap to be unmodified with size 5, got %d", h.Size())
+	}
+}
+
+func TestPriorityQueue_Collection(t *testing.T) {
+	var pq structs.Queue[int] = NewPriority[int](structs.CompareOrdered[int])
+
+	pq.AddAll(wrap.OrderedValues(5, 3, 8, 1))
+	pq.AddIterator(wrap.ValueIterator(9, 2))
+	pq.Offer(7)
+	if pq.Size() != 7 {
+		t.Errorf("expected size 7 but got %d", pq.Size())
+	}
+	if !pq.ContainsAll(wrap.OrderedValues(1, 9, 7)) {
+		t.Error("expected queue to contain 1, 9 and 7")
+	}
+	if pq.ContainsIterator(wrap.ValueIterator(4)) {
+		t.Error("expected queue not to contain 4")
+	}
+
+	pq.Remove(7)
+	pq.RemoveAll(wrap.OrderedValues(3, 9))
+	pq.RetainAll(wrap.OrderedValues(1, 2, 5, 8))
+
+	count := 0
+	it := pq.Iterator()
+	for it.HasNext() {
+		it.Next()
+		count++
+	}
+	if count != 4 {
+		t.Errorf("expected iterator to return 4 values but got %d", count)
+	}
+
+	if got := pq.Element(); got != 1 {
+		t.Errorf("expected element 1 but got %d", got)
+	}
+	expect := []int{1, 2, 5, 8}
+	for i, value := range expect {
+		var got int
+		if i%2 == 0 {
+			got = pq.Poll()
+		} else {
+			got = pq.RemoveHead()
+		}
+		if got != value {
+			t.Errorf("expected %d but got %d at index %d", value, got, i)
+		}
+	}
+}
+
+func TestPriorityQueue_Empty(t *testing.T) {
+	pq := NewPriority[int](structs.CompareOrdered[int])
+
+	if value, ok := pq.TryPeek(); ok || value != 0 {
+		t.Errorf("expected (0, false) from TryPeek but got (%d, %t)", value, ok)
+	}
+	if value, ok := pq.TryPoll(); ok || value != 0 {
+		t.Errorf("expected (0, false) from TryPoll but got (%d, %t)", value, ok)
+	}
+	if value := pq.Peek(); value != 0 {
+		t.Errorf("expected 0 from Peek but got %d", value)
+	}
+	if value := pq.Poll(); value != 0 {
+		t.Errorf("expected 0 from Poll but got %d", value)
+	}
+
+	defer func() {
+		if r := recover(); r != structs.PanicNoSuchElement {
+			t.Errorf("expected panic %q but got %v", structs.PanicNoSuchElement, r)
+		}
+	}()
+	pq.Element()
+}
+
+func TestPriorityQueue_TryPoll(t *testing.T) {
+	pq := NewPriority[int](structs.CompareOrdered[int])
+	pq.Add(2)
+	pq.Add(1)
+
+	if value, ok := pq.TryPeek(); !ok || value != 1 {
+		t.Errorf("expected (1, true) from TryPeek but got (%d, %t)", value, ok)
+	}
+	if value, ok := pq.TryPoll(); !ok || value != 1 {
+		t.Errorf("expected (1, true) from TryPoll but got (%d, %t)", value, ok)
+	}
+	if pq.Size() != 1 {
+		t.Errorf("expected size 1 but got %d", pq.Size())
+	}
+}
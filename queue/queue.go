@@ -12,6 +12,8 @@ type Queue[V any] struct {
 	data *list.List[V]
 }
 
+var _ structs.Queue[int] = (*Queue[int])(nil)
+
 func New[V any](eq structs.EqualFunc[V]) *Queue[V] {
 	return &Queue[V]{
 		data: list.New[V](eq),
@@ -24,54 +26,160 @@ func NewOrdered[V constraints.Ordered]() *Queue[V] {
 	}
 }
 
-func (q *Queue[V]) Add(value V) {
+// Add adds a value to the end of the queue. It always returns true.
+//
+// Time Complexity: O(1)
+func (q *Queue[V]) Add(value V) bool {
+	return q.data.AddLast(value)
+}
+
+// AddAll adds all the values in the other collection to the end of the queue.
+//
+// Time Complexity: O(m)
+func (q *Queue[V]) AddAll(other structs.Collection[V]) bool {
+	return q.data.AddAll(other)
+}
+
+// AddIterator adds all the values in the iterator to the end of the queue.
+//
+// Time Complexity: O(m)
+func (q *Queue[V]) AddIterator(iter structs.Iterator[V]) bool {
+	return q.data.AddIterator(iter)
+}
+
+// Enqueue adds a value to the end of the queue.
+//
+// Time Complexity: O(1)
+func (q *Queue[V]) Enqueue(value V) {
 	q.data.AddLast(value)
 }
 
+// Dequeue removes and returns the value at the head of the queue.
+//
+// Panics if the queue is empty.
+//
+// Time Complexity: O(1)
+func (q *Queue[V]) Dequeue() V {
+	return q.data.RemoveHead()
+}
+
+// Element returns the value at the head of the queue.
+//
+// Panics if the queue is empty.
+//
+// Time Complexity: O(1)
 func (q *Queue[V]) Element() V {
-	return q.Peek()
+	return q.data.Element()
 }
 
+// Offer adds a value to the end of the queue. It always returns true.
+//
+// Time Complexity: O(1)
 func (q *Queue[V]) Offer(value V) bool {
 	return q.data.Offer(value)
 }
 
+// Peek returns the value at the head of the queue, or
+// the zero value of the type if the queue is empty.
+//
+// Time Complexity: O(1)
 func (q *Queue[V]) Peek() V {
 	return q.data.Peek()
 }
 
+// Poll removes and returns the value at the head of the queue,
+// or returns the zero value of the type if the queue is empty.
+//
+// Time Complexity: O(1)
 func (q *Queue[V]) Poll() V {
 	return q.data.Poll()
 }
 
+// RemoveHead removes and returns the value at the head of the queue.
+//
+// Panics if the queue is empty.
+//
+// Time Complexity: O(1)
 func (q *Queue[V]) RemoveHead() V {
 	return q.data.RemoveHead()
 }
 
+// Contains returns whether the value is present in the queue.
+//
+// Time Complexity: O(n)
 func (q *Queue[V]) Contains(value V) bool {
 	return q.data.Contains(value)
 }
 
+// ContainsAll returns whether all the values in the other collection are present in the queue.
+//
+// Time Complexity: O(nm)
+func (q *Queue[V]) ContainsAll(other structs.Collection[V]) bool {
+	return q.data.ContainsAll(other)
+}
+
+// ContainsIterator returns whether all the values in the iterator are present in the queue.
+//
+// Time Complexity: O(nm)
+func (q *Queue[V]) ContainsIterator(iter structs.Iterator[V]) bool {
+	return q.data.ContainsIterator(iter)
+}
+
+// Remove removes the first occurrence of a value from
+// the queue and returns whether the value was present.
+//
+// Time Complexity: O(n)
+func (q *Queue[V]) Remove(value V) bool {
+	return q.data.Remove(value)
+}
+
+// RemoveAll removes all the values in the other collection from the queue.
+//
+// Time Complexity: O(nm)
+func (q *Queue[V]) RemoveAll(other structs.Collection[V]) bool {
+	return q.data.RemoveAll(other)
+}
+
+// RemoveIterator removes all the values in the iterator from the queue.
+//
+// Time Complexity: O(nm)
+func (q *Queue[V]) RemoveIterator(iter structs.Iterator[V]) bool {
+	return q.data.RemoveIterator(iter)
+}
+
+// RetainAll removes all the values not present in the other collection from the queue.
+//
+// Time Complexity: O(nm)
+func (q *Queue[V]) RetainAll(other structs.Collection[V]) bool {
+	return q.data.RetainAll(other)
+}
+
+// Iterator returns an Iterator over the queue, starting at the head.
 func (q *Queue[V]) Iterator() structs.Iterator[V] {
 	return q.data.Iterator()
 }
 
+// DescendingIterator returns an Iterator over the queue, starting at the end.
 func (q *Queue[V]) DescendingIterator() structs.Iterator[V] {
 	return q.data.DescendingIterator()
 }
 
+// IsEmpty returns whether the queue is empty.
 func (q *Queue[V]) IsEmpty() bool {
 	return q.data.IsEmpty()
 }
 
+// Size returns the number of values in the queue.
 func (q *Queue[V]) Size() int {
 	return q.data.Size()
 }
 
+// Clear removes all the values from the queue.
 func (q *Queue[V]) Clear() {
 	q.data.Clear()
 }
 
+// Values returns a slice of the values in the queue, starting at the head.
 func (q *Queue[V]) Values() []V {
 	return q.data.Values()
 }
@@ -1,6 +1,11 @@
 package queue
 
-import "testing"
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/wrap"
+	"golang.org/x/exp/slices"
+	"testing"
+)
 
 func TestNew(t *testing.T) {
 	const count = 10
@@ -21,3 +26,49 @@ func TestNew(t *testing.T) {
 		t.Errorf("expected %d elements but got %d", count, expect)
 	}
 }
+
+func TestQueue_Collection(t *testing.T) {
+	var q structs.Queue[int] = NewOrdered[int]()
+
+	if !q.Add(1) || !q.Offer(2) {
+		t.Error("expected adding values to return true")
+	}
+	q.AddAll(wrap.OrderedValues(3, 4, 5))
+	q.AddIterator(wrap.ValueIterator(6, 7, 8))
+	if q.Size() != 8 {
+		t.Errorf("expected size 8 but got %d", q.Size())
+	}
+	if !q.ContainsAll(wrap.OrderedValues(1, 8)) || q.ContainsIterator(wrap.ValueIterator(1, 9)) {
+		t.Error("expected queue to contain all of 1 and 8 but not all of 1 and 9")
+	}
+
+	q.Remove(2)
+	q.RemoveAll(wrap.OrderedValues(4, 6))
+	q.RemoveIterator(wrap.ValueIterator(8))
+	q.RetainAll(wrap.OrderedValues(1, 3, 5))
+
+	expect := []int{1, 3, 5}
+	if !slices.Equal(q.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, q.Values())
+	}
+	if q.Element() != 1 || q.Peek() != 1 {
+		t.Error("expected the head of the queue to be 1")
+	}
+	if q.Poll() != 1 || q.RemoveHead() != 3 {
+		t.Error("expected to remove 1 then 3 from the head of the queue")
+	}
+}
+
+func TestQueue_Empty(t *testing.T) {
+	q := NewOrdered[int]()
+	if q.Peek() != 0 || q.Poll() != 0 {
+		t.Error("expected peeking or polling an empty queue to return the zero value")
+	}
+
+	defer func() {
+		if r := recover(); r != structs.PanicNoSuchElement {
+			t.Errorf("expected panic %q but got %v", structs.PanicNoSuchElement, r)
+		}
+	}()
+	q.Dequeue()
+}
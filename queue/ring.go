@@ -0,0 +1,162 @@
+package queue
+
+import "sync/atomic"
+
+// cacheLinePad is used to keep frequently written fields on separate
+// cache lines, so producers and consumers don't contend on one line.
+const cacheLinePad = 64
+
+// RingBuffer is an implementation of a bounded, lock-free queue which
+// is safe for use by multiple producers and multiple consumers,
+// based on Dmitry Vyukov's bounded MPMC queue.
+//
+// Each slot in the ring holds a sequence number, which tells producers
+// whether the slot is free for the current lap around the ring, and
+// tells consumers whether it has been filled. Producers and consumers
+// claim slots by advancing their position with a compare-and-swap.
+type RingBuffer[V any] struct {
+	_       [cacheLinePad]byte
+	enqueue uintptr // position of the next slot to fill
+	_       [cacheLinePad]byte
+	dequeue uintptr // position of the next slot to drain
+	_       [cacheLinePad]byte
+	mask    uintptr
+	slots   []ringSlot[V]
+}
+
+// ringSlot is a slot in a RingBuffer.
+type ringSlot[V any] struct {
+	seq   uintptr
+	value V
+}
+
+// NewRingBuffer creates an empty RingBuffer which holds at most capacity
+// values. The capacity is rounded up to the next power of two.
+//
+// Panics if the capacity is less than 1.
+func NewRingBuffer[V any](capacity int) *RingBuffer[V] {
+	if capacity < 1 {
+		panic("ring buffer capacity must be at least 1")
+	}
+	size := 1
+	for size < capacity {
+		size <<= 1
+	}
+
+	slots := make([]ringSlot[V], size)
+	for i := range slots {
+		slots[i].seq = uintptr(i)
+	}
+	return &RingBuffer[V]{
+		mask:  uintptr(size - 1),
+		slots: slots,
+	}
+}
+
+// TryEnqueue adds a value to the end of the queue without
+// waiting, returning false if the queue is full.
+func (r *RingBuffer[V]) TryEnqueue(value V) bool {
+	pos := atomic.LoadUintptr(&r.enqueue)
+	for {
+		slot := &r.slots[pos&r.mask]
+		seq := atomic.LoadUintptr(&slot.seq)
+		switch diff := int(seq - pos); {
+		case diff == 0:
+			// the slot is free for this lap; try to claim it
+			if atomic.CompareAndSwapUintptr(&r.enqueue, pos, pos+1) {
+				slot.value = value
+				atomic.StoreUintptr(&slot.seq, pos+1)
+				return true
+			}
+			pos = atomic.LoadUintptr(&r.enqueue)
+		case diff < 0:
+			// the slot still holds a value from the previous lap
+			return false
+		default:
+			// another producer claimed the slot first
+			pos = atomic.LoadUintptr(&r.enqueue)
+		}
+	}
+}
+
+// TryDequeue removes and returns the value at the head of the queue and
+// true without waiting, or the zero value and false if the queue is empty.
+func (r *RingBuffer[V]) TryDequeue() (V, bool) {
+	pos := atomic.LoadUintptr(&r.dequeue)
+	for {
+		slot := &r.slots[pos&r.mask]
+		seq := atomic.LoadUintptr(&slot.seq)
+		switch diff := int(seq - (pos + 1)); {
+		case diff == 0:
+			// the slot was filled this lap; try to claim it
+			if atomic.CompareAndSwapUintptr(&r.dequeue, pos, pos+1) {
+				var null V
+				value := slot.value
+				slot.value = null
+				atomic.StoreUintptr(&slot.seq, pos+r.mask+1)
+				return value, true
+			}
+			pos = atomic.LoadUintptr(&r.dequeue)
+		case diff < 0:
+			// the slot has not been filled yet
+			var null V
+			return null, false
+		default:
+			// another consumer claimed the slot first
+			pos = atomic.LoadUintptr(&r.dequeue)
+		}
+	}
+}
+
+// TryEnqueueBatch adds values to the end of the queue in order without
+// waiting, stopping when the queue is full, and returns the number of
+// values which were added. Values from other producers may be
+// interleaved with the batch.
+func (r *RingBuffer[V]) TryEnqueueBatch(values []V) int {
+	for i, value := range values {
+		if !r.TryEnqueue(value) {
+			return i
+		}
+	}
+	return len(values)
+}
+
+// TryDequeueBatch removes values from the head of the queue into dst
+// without waiting, stopping when the queue is empty or dst is full,
+// and returns the number of values which were removed.
+func (r *RingBuffer[V]) TryDequeueBatch(dst []V) int {
+	for i := range dst {
+		value, ok := r.TryDequeue()
+		if !ok {
+			return i
+		}
+		dst[i] = value
+	}
+	return len(dst)
+}
+
+// Cap returns the maximum number of values in the queue.
+func (r *RingBuffer[V]) Cap() int {
+	return len(r.slots)
+}
+
+// Size returns the number of values in the queue. While the
+// queue is in concurrent use, the result is only an estimate.
+func (r *RingBuffer[V]) Size() int {
+	dequeue := atomic.LoadUintptr(&r.dequeue)
+	enqueue := atomic.LoadUintptr(&r.enqueue)
+	size := int(enqueue - dequeue)
+	if size < 0 {
+		return 0
+	}
+	if size > len(r.slots) {
+		return len(r.slots)
+	}
+	return size
+}
+
+// IsEmpty returns whether the queue is empty. While the
+// queue is in concurrent use, the result is only an estimate.
+func (r *RingBuffer[V]) IsEmpty() bool {
+	return r.Size() == 0
+}
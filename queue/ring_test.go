@@ -0,0 +1,141 @@
+package queue
+
+import (
+	"golang.org/x/exp/slices"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestNewRingBuffer(t *testing.T) {
+	r := NewRingBuffer[int](5)
+	if r.Cap() != 8 {
+		t.Errorf("expected capacity to be rounded up to 8 but got %d", r.Cap())
+	}
+	if !r.IsEmpty() {
+		t.Error("expected new ring buffer to be empty, got size", r.Size())
+	}
+}
+
+func TestRingBuffer(t *testing.T) {
+	r := NewRingBuffer[int](4)
+
+	// go around the ring several times
+	for lap := 0; lap < 4; lap++ {
+		for i := 0; i < 4; i++ {
+			if !r.TryEnqueue(lap*4 + i) {
+				t.Fatalf("expected enqueue %d to succeed", i)
+			}
+		}
+		if r.TryEnqueue(-1) {
+			t.Fatal("expected enqueue to a full ring buffer to fail")
+		}
+		if r.Size() != 4 {
+			t.Errorf("expected size 4 but got %d", r.Size())
+		}
+
+		for i := 0; i < 4; i++ {
+			value, ok := r.TryDequeue()
+			if !ok || value != lap*4+i {
+				t.Fatalf("expected (%d, true) but got (%d, %t)", lap*4+i, value, ok)
+			}
+		}
+		if _, ok := r.TryDequeue(); ok {
+			t.Fatal("expected dequeue from an empty ring buffer to fail")
+		}
+	}
+}
+
+func TestRingBuffer_Batch(t *testing.T) {
+	r := NewRingBuffer[int](4)
+
+	if n := r.TryEnqueueBatch([]int{1, 2, 3, 4, 5, 6}); n != 4 {
+		t.Errorf("expected 4 values to be enqueued but got %d", n)
+	}
+
+	dst := make([]int, 3)
+	if n := r.TryDequeueBatch(dst); n != 3 {
+		t.Errorf("expected 3 values to be dequeued but got %d", n)
+	}
+	if !slices.Equal(dst, []int{1, 2, 3}) {
+		t.Errorf("expected dequeued values %v but got %v", []int{1, 2, 3}, dst)
+	}
+
+	dst = make([]int, 3)
+	if n := r.TryDequeueBatch(dst); n != 1 || dst[0] != 4 {
+		t.Errorf("expected only 4 to be dequeued but got %v", dst)
+	}
+}
+
+// TestRingBuffer_Stress should be run with -race.
+func TestRingBuffer_Stress(t *testing.T) {
+	const producers = 4
+	const consumers = 4
+	const count = 10000
+
+	r := NewRingBuffer[int](64)
+	seen := make([]int32, producers*count)
+
+	var produced sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		produced.Add(1)
+		go func(p int) {
+			defer produced.Done()
+			for i := 0; i < count; {
+				if r.TryEnqueue(p*count + i) {
+					i++
+				} else {
+					runtime.Gosched()
+				}
+			}
+		}(p)
+	}
+
+	var mu sync.Mutex
+	var consumed sync.WaitGroup
+	done := make(chan struct{})
+	for c := 0; c < consumers; c++ {
+		consumed.Add(1)
+		go func() {
+			defer consumed.Done()
+			local := make([]int, 0, count)
+			buf := make([]int, 8)
+			for {
+				n := r.TryDequeueBatch(buf)
+				local = append(local, buf[:n]...)
+				if n == 0 {
+					select {
+					case <-done:
+						// drain anything enqueued before done was closed
+						n = r.TryDequeueBatch(buf)
+						for n > 0 {
+							local = append(local, buf[:n]...)
+							n = r.TryDequeueBatch(buf)
+						}
+						mu.Lock()
+						for _, value := range local {
+							seen[value]++
+						}
+						mu.Unlock()
+						return
+					default:
+						runtime.Gosched()
+					}
+				}
+			}
+		}()
+	}
+
+	produced.Wait()
+	close(done)
+	consumed.Wait()
+
+	for value, times := range seen {
+		if times != 1 {
+			t.Fatalf("expected value %d to be dequeued once but it was dequeued %d times", value, times)
+		}
+	}
+	if !r.IsEmpty() {
+		t.Error("expected ring buffer to be empty, got size", r.Size())
+	}
+}
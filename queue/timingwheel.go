@@ -0,0 +1,260 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TimingWheel is an implementation of a hierarchical timing wheel,
+// which schedules very many timers in O(1) time at the cost of
+// rounding their deadlines up to a whole number of ticks.
+//
+// Each level of the wheel has a fixed number of slots. A slot at the
+// first level spans a single tick, and a slot at each higher level
+// spans an entire revolution of the level below it. Timers are placed
+// at the lowest level which can hold their deadline, and are moved
+// down a level each time the wheel reaches their slot, until they
+// expire. Levels are added as needed for timers far in the future.
+//
+// The wheel only advances when Advance is called, either directly
+// or by Run. TimingWheel is safe for concurrent use.
+type TimingWheel[V any] struct {
+	mu      sync.Mutex
+	clock   Clock
+	tick    time.Duration
+	slots   int64
+	start   time.Time
+	current int64 // the last tick which has been processed
+	levels  [][]wheelBucket[V]
+	due     wheelBucket[V] // timers which are due at the next advance
+	size    int
+}
+
+// WheelTimer is a timer scheduled in a TimingWheel.
+type WheelTimer[V any] struct {
+	wheel    *TimingWheel[V]
+	value    V
+	deadline int64 // the tick at which the timer expires
+	bucket   *wheelBucket[V]
+	prev     *WheelTimer[V]
+	next     *WheelTimer[V]
+}
+
+// wheelBucket is a doubly linked list of the timers in a slot.
+type wheelBucket[V any] struct {
+	head *WheelTimer[V]
+	tail *WheelTimer[V]
+}
+
+// NewTimingWheel creates an empty TimingWheel which uses the system clock,
+// with the given tick duration and number of slots per level.
+//
+// Panics if the tick is not positive or there are fewer than 2 slots.
+func NewTimingWheel[V any](tick time.Duration, slots int) *TimingWheel[V] {
+	return NewTimingWheelClock[V](SystemClock, tick, slots)
+}
+
+// NewTimingWheelClock creates an empty TimingWheel which uses the given
+// clock, with the given tick duration and number of slots per level.
+//
+// Panics if the tick is not positive or there are fewer than 2 slots.
+func NewTimingWheelClock[V any](clock Clock, tick time.Duration, slots int) *TimingWheel[V] {
+	if tick <= 0 {
+		panic("timing wheel tick must be positive")
+	}
+	if slots < 2 {
+		panic("timing wheel must have at least 2 slots")
+	}
+	return &TimingWheel[V]{
+		clock:  clock,
+		tick:   tick,
+		slots:  int64(slots),
+		start:  clock.Now(),
+		levels: [][]wheelBucket[V]{make([]wheelBucket[V], slots)},
+	}
+}
+
+// Schedule schedules a value to expire after the delay,
+// which is rounded up to a whole number of ticks.
+//
+// Time Complexity: O(1)
+func (w *TimingWheel[V]) Schedule(value V, delay time.Duration) *WheelTimer[V] {
+	return w.ScheduleAt(value, w.clock.Now().Add(delay))
+}
+
+// ScheduleAt schedules a value to expire at the given
+// time, which is rounded up to a whole number of ticks.
+//
+// Time Complexity: O(1)
+func (w *TimingWheel[V]) ScheduleAt(value V, at time.Time) *WheelTimer[V] {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	elapsed := at.Sub(w.start)
+	deadline := int64(elapsed / w.tick)
+	if elapsed%w.tick > 0 {
+		deadline++
+	}
+
+	t := &WheelTimer[V]{
+		wheel:    w,
+		value:    value,
+		deadline: deadline,
+	}
+	w.add(t)
+	w.size++
+	return t
+}
+
+// Advance moves the wheel forward to the given time, and returns the
+// values of all the timers which expired, in order of their deadlines.
+//
+// Time Complexity: O(t + e)
+//
+//	t = number of ticks advanced while timers are scheduled
+//	e = number of expired timers
+func (w *TimingWheel[V]) Advance(now time.Time) []V {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var expired []V
+	expired = w.expire(&w.due, expired)
+
+	target := int64(now.Sub(w.start) / w.tick)
+	for w.current < target {
+		if w.size == 0 {
+			w.current = target
+			break
+		}
+		w.current++
+
+		// move timers down from each higher level whose slot was reached
+		span := w.slots
+		for level := 1; level < len(w.levels) && w.current%span == 0; level++ {
+			bucket := &w.levels[level][(w.current/span)%w.slots]
+			t := bucket.head
+			bucket.head, bucket.tail = nil, nil
+			for t != nil {
+				next := t.next
+				t.bucket, t.prev, t.next = nil, nil, nil
+				w.add(t)
+				t = next
+			}
+			span *= w.slots
+		}
+
+		expired = w.expire(&w.due, expired)
+		expired = w.expire(&w.levels[0][w.current%w.slots], expired)
+	}
+	return expired
+}
+
+// Run advances the wheel once per tick until the context is done,
+// calling f with the value of each timer as it expires. It returns
+// the context's error.
+func (w *TimingWheel[V]) Run(ctx context.Context, f func(value V)) error {
+	for {
+		timer := w.clock.NewTimer(w.tick)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C():
+			for _, value := range w.Advance(w.clock.Now()) {
+				f(value)
+			}
+		}
+	}
+}
+
+// Size returns the number of timers which have not yet expired or been cancelled.
+func (w *TimingWheel[V]) Size() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.size
+}
+
+// add places a timer in the lowest level which can hold its deadline,
+// or in the due bucket if its deadline has already been reached.
+// The lock must be held by the caller.
+func (w *TimingWheel[V]) add(t *WheelTimer[V]) {
+	if t.deadline <= w.current {
+		w.due.push(t)
+		return
+	}
+
+	level := 0
+	span := int64(1)
+	for t.deadline/span-w.current/span >= w.slots {
+		level++
+		span *= w.slots
+	}
+	for len(w.levels) <= level {
+		w.levels = append(w.levels, make([]wheelBucket[V], w.slots))
+	}
+	w.levels[level][(t.deadline/span)%w.slots].push(t)
+}
+
+// expire removes all the timers in the bucket, appending their values.
+// The lock must be held by the caller.
+func (w *TimingWheel[V]) expire(bucket *wheelBucket[V], expired []V) []V {
+	t := bucket.head
+	bucket.head, bucket.tail = nil, nil
+	for t != nil {
+		next := t.next
+		t.bucket, t.prev, t.next = nil, nil, nil
+		expired = append(expired, t.value)
+		w.size--
+		t = next
+	}
+	return expired
+}
+
+// Value returns the value of the timer.
+func (t *WheelTimer[V]) Value() V {
+	return t.value
+}
+
+// Cancel cancels the timer, returning false if it
+// already expired or was previously cancelled.
+//
+// Time Complexity: O(1)
+func (t *WheelTimer[V]) Cancel() bool {
+	w := t.wheel
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if t.bucket == nil {
+		return false
+	}
+	t.bucket.remove(t)
+	w.size--
+	return true
+}
+
+func (b *wheelBucket[V]) push(t *WheelTimer[V]) {
+	t.bucket = b
+	t.prev = b.tail
+	if b.tail == nil {
+		b.head = t
+	} else {
+		b.tail.next = t
+	}
+	b.tail = t
+}
+
+func (b *wheelBucket[V]) remove(t *WheelTimer[V]) {
+	if t.prev == nil {
+		b.head = t.next
+	} else {
+		t.prev.next = t.next
+	}
+	if t.next == nil {
+		b.tail = t.prev
+	} else {
+		t.next.prev = t.prev
+	}
+	t.bucket, t.prev, t.next = nil, nil, nil
+}
@@ -0,0 +1,142 @@
+package queue
+
+import (
+	"context"
+	"golang.org/x/exp/slices"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestTimingWheel(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	w := NewTimingWheelClock[int](clock, time.Millisecond, 8)
+
+	// deadlines spanning several levels of the wheel
+	delays := []int{1, 5, 7, 8, 9, 63, 64, 65, 511, 512, 1000, 5000}
+	for _, delay := range delays {
+		w.Schedule(delay, time.Duration(delay)*time.Millisecond)
+	}
+	if w.Size() != len(delays) {
+		t.Errorf("expected size %d but got %d", len(delays), w.Size())
+	}
+
+	// advance one tick at a time, checking each timer expires exactly on time
+	for now := 1; now <= 5000; now++ {
+		clock.Advance(time.Millisecond)
+		expired := w.Advance(clock.Now())
+		var expect []int
+		if slices.Contains(delays, now) {
+			expect = []int{now}
+		}
+		if !slices.Equal(expired, expect) {
+			t.Fatalf("expected %v to expire at %dms but got %v", expect, now, expired)
+		}
+	}
+	if w.Size() != 0 {
+		t.Errorf("expected all timers to expire, got size %d", w.Size())
+	}
+}
+
+func TestTimingWheel_Random(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	w := NewTimingWheelClock[int](clock, time.Millisecond, 16)
+
+	const count = 2000
+	delays := make([]int, count)
+	for i := range delays {
+		delays[i] = rand.Intn(20000)
+		w.Schedule(delays[i], time.Duration(delays[i])*time.Millisecond)
+	}
+
+	// advance in uneven steps
+	var got []int
+	for w.Size() > 0 {
+		clock.Advance(time.Duration(rand.Intn(500)) * time.Millisecond)
+		now := int(clock.Now().Sub(time.Unix(0, 0)) / time.Millisecond)
+		for _, delay := range w.Advance(clock.Now()) {
+			if delay > now {
+				t.Fatalf("timer for %dms expired early at %dms", delay, now)
+			}
+			got = append(got, delay)
+		}
+	}
+
+	sort.Ints(delays)
+	if !sort.IntsAreSorted(got) {
+		t.Error("expected timers to expire in order of their deadlines")
+	}
+	if !slices.Equal(got, delays) {
+		t.Error("expected every timer to expire exactly once")
+	}
+}
+
+func TestTimingWheel_Cancel(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	w := NewTimingWheelClock[string](clock, time.Millisecond, 4)
+
+	a := w.Schedule("a", 10*time.Millisecond)
+	b := w.Schedule("b", 10*time.Millisecond)
+	w.Schedule("c", 10*time.Millisecond)
+	if !b.Cancel() {
+		t.Error("expected pending timer to be cancelled")
+	}
+	if b.Cancel() {
+		t.Error("expected cancelled timer not to be cancelled again")
+	}
+
+	clock.Advance(10 * time.Millisecond)
+	expired := w.Advance(clock.Now())
+	if expect := []string{"a", "c"}; !slices.Equal(expired, expect) {
+		t.Errorf("expected %v to expire but got %v", expect, expired)
+	}
+	if a.Cancel() {
+		t.Error("expected expired timer not to be cancelled")
+	}
+	if a.Value() != "a" {
+		t.Errorf("expected timer value a but got %s", a.Value())
+	}
+}
+
+func TestTimingWheel_Past(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	w := NewTimingWheelClock[int](clock, time.Millisecond, 4)
+
+	clock.Advance(100 * time.Millisecond)
+	w.Advance(clock.Now())
+	w.Schedule(1, -time.Second)
+	w.Schedule(2, 0)
+
+	expired := w.Advance(clock.Now())
+	if expect := []int{1, 2}; !slices.Equal(expired, expect) {
+		t.Errorf("expected %v to expire but got %v", expect, expired)
+	}
+}
+
+func TestTimingWheel_Run(t *testing.T) {
+	w := NewTimingWheel[int](time.Millisecond, 16)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	expired := make(chan int, 3)
+	done := make(chan error)
+	go func() {
+		done <- w.Run(ctx, func(value int) {
+			expired <- value
+		})
+	}()
+
+	w.Schedule(3, 30*time.Millisecond)
+	w.Schedule(1, 10*time.Millisecond)
+	w.Schedule(2, 20*time.Millisecond)
+	for expect := 1; expect <= 3; expect++ {
+		if value := <-expired; value != expect {
+			t.Errorf("expected %d to expire but got %d", expect, value)
+		}
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("expected %v from run but got %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,64 @@
+package rope
+
+import "github.com/zytekaron/structs"
+
+// Iterator is an iterator over the values of a Rope.
+//
+// It caches the leaf at its cursor, so iterating
+// over the whole rope takes O(n) rather than O(nlogn).
+type Iterator[V any] struct {
+	rope  *Rope[V]
+	root  *node[V] // tree the leaf was found in
+	leaf  []V
+	start int // index of the first value of the leaf
+	index int
+	last  int // index of the last value returned, or -1
+
+	expectedModCount int
+}
+
+func (it *Iterator[V]) HasNext() bool {
+	return it.index < it.rope.Size()
+}
+
+func (it *Iterator[V]) Next() V {
+	it.checkModCount()
+	if it.index >= it.rope.Size() {
+		panic(structs.PanicNoSuchElement)
+	}
+
+	// values which are set create a new tree,
+	// so a leaf is only valid for its own tree
+	offset := it.index - it.start
+	if it.root != it.rope.root || offset < 0 || offset >= len(it.leaf) {
+		it.root = it.rope.root
+		it.leaf, it.start = leafAt(it.root, it.index)
+		offset = it.index - it.start
+	}
+	it.last = it.index
+	it.index++
+	return it.leaf[offset]
+}
+
+// Remove removes the value last returned by Next.
+//
+// Panics if Next has not been called since the last call to Remove.
+func (it *Iterator[V]) Remove() {
+	it.checkModCount()
+	if it.last < 0 {
+		panic(structs.PanicIllegalState)
+	}
+
+	it.rope.RemoveAt(it.last)
+	it.index = it.last
+	it.last = -1
+	it.expectedModCount = it.rope.modCount
+}
+
+// checkModCount panics if the rope was modified
+// other than through this iterator.
+func (it *Iterator[V]) checkModCount() {
+	if it.rope.modCount != it.expectedModCount {
+		panic(structs.PanicConcurrentModification)
+	}
+}
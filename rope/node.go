@@ -0,0 +1,212 @@
+package rope
+
+// maxLeaf is the maximum number of values held by a leaf.
+const maxLeaf = 64
+
+// node is a node of the AVL tree backing a Rope. Nodes are never modified
+// once created, so trees may be shared between ropes, and an operation
+// which changes a tree returns a new root which shares the untouched
+// nodes with the old tree.
+//
+// Internal nodes have both children, and leaves have neither. A nil
+// node is an empty tree.
+type node[V any] struct {
+	left   *node[V]
+	right  *node[V]
+	values []V // nil for internal nodes
+	size   int
+	height int // 0 for leaves
+}
+
+// newLeaf creates a leaf holding the values,
+// or returns nil if there are none.
+func newLeaf[V any](values []V) *node[V] {
+	if len(values) == 0 {
+		return nil
+	}
+	return &node[V]{
+		values: values,
+		size:   len(values),
+	}
+}
+
+// newInternal creates an internal node with two non-nil children.
+func newInternal[V any](left, right *node[V]) *node[V] {
+	height := left.height
+	if right.height > height {
+		height = right.height
+	}
+	return &node[V]{
+		left:   left,
+		right:  right,
+		size:   left.size + right.size,
+		height: height + 1,
+	}
+}
+
+func (n *node[V]) isLeaf() bool {
+	return n.left == nil
+}
+
+// build creates a balanced tree from the values, which it takes ownership of.
+//
+// Time Complexity: O(n)
+func build[V any](values []V) *node[V] {
+	if len(values) <= maxLeaf {
+		return newLeaf(values)
+	}
+	// split on a leaf boundary so the leaves are full
+	leaves := (len(values) + maxLeaf - 1) / maxLeaf
+	mid := leaves / 2 * maxLeaf
+	return newInternal(build(values[:mid:mid]), build(values[mid:]))
+}
+
+// join concatenates two trees, rebalancing along
+// the spine of the taller tree where they meet.
+//
+// Time Complexity: O(|h1-h2|+1)
+func join[V any](left, right *node[V]) *node[V] {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	if left.isLeaf() && right.isLeaf() && left.size+right.size <= maxLeaf {
+		values := make([]V, 0, left.size+right.size)
+		values = append(values, left.values...)
+		return newLeaf(append(values, right.values...))
+	}
+	if left.height > right.height+1 {
+		return balance(left.left, join(left.right, right))
+	}
+	if right.height > left.height+1 {
+		return balance(join(left, right.left), right.right)
+	}
+	return newInternal(left, right)
+}
+
+// balance creates a node from two non-nil subtrees whose
+// heights differ by at most 2, rotating if they differ by 2.
+func balance[V any](left, right *node[V]) *node[V] {
+	switch {
+	case left.height > right.height+1:
+		if left.left.height >= left.right.height {
+			return newInternal(left.left, newInternal(left.right, right))
+		}
+		inner := left.right
+		return newInternal(newInternal(left.left, inner.left), newInternal(inner.right, right))
+	case right.height > left.height+1:
+		if right.right.height >= right.left.height {
+			return newInternal(newInternal(left, right.left), right.right)
+		}
+		inner := right.left
+		return newInternal(newInternal(left, inner.left), newInternal(inner.right, right.right))
+	}
+	return newInternal(left, right)
+}
+
+// split splits a tree into the values before the index and the rest.
+//
+// Time Complexity: O(logn)
+func split[V any](n *node[V], index int) (*node[V], *node[V]) {
+	switch {
+	case index == 0:
+		return nil, n
+	case index == n.size:
+		return n, nil
+	case n.isLeaf():
+		// the leaves share the values, which are never modified
+		return newLeaf(n.values[:index:index]), newLeaf(n.values[index:])
+	case index < n.left.size:
+		left, right := split(n.left, index)
+		return left, join(right, n.right)
+	default:
+		left, right := split(n.right, index-n.left.size)
+		return join(n.left, left), right
+	}
+}
+
+// get returns the value at the index.
+func get[V any](n *node[V], index int) V {
+	values, start := leafAt(n, index)
+	return values[index-start]
+}
+
+// leafAt returns the values of the leaf holding the
+// index, and the index of the leaf's first value.
+func leafAt[V any](n *node[V], index int) ([]V, int) {
+	start := 0
+	for !n.isLeaf() {
+		if index-start < n.left.size {
+			n = n.left
+		} else {
+			start += n.left.size
+			n = n.right
+		}
+	}
+	return n.values, start
+}
+
+// set returns a tree with the value at the index replaced.
+func set[V any](n *node[V], index int, value V) *node[V] {
+	if n.isLeaf() {
+		values := make([]V, n.size)
+		copy(values, n.values)
+		values[index] = value
+		return newLeaf(values)
+	}
+	if index < n.left.size {
+		return newInternal(set(n.left, index, value), n.right)
+	}
+	return newInternal(n.left, set(n.right, index-n.left.size, value))
+}
+
+// insert returns a tree with the value inserted at the index.
+func insert[V any](n *node[V], index int, value V) *node[V] {
+	if n == nil {
+		return newLeaf([]V{value})
+	}
+	if n.isLeaf() {
+		values := make([]V, n.size+1)
+		copy(values, n.values[:index])
+		values[index] = value
+		copy(values[index+1:], n.values[index:])
+		if len(values) <= maxLeaf {
+			return newLeaf(values)
+		}
+		half := len(values) / 2
+		return newInternal(newLeaf(values[:half:half]), newLeaf(values[half:]))
+	}
+	if index <= n.left.size {
+		return join(insert(n.left, index, value), n.right)
+	}
+	return join(n.left, insert(n.right, index-n.left.size, value))
+}
+
+// remove returns a tree with the value at the index removed, and the removed value.
+func remove[V any](n *node[V], index int) (*node[V], V) {
+	if n.isLeaf() {
+		value := n.values[index]
+		values := make([]V, 0, n.size-1)
+		values = append(values, n.values[:index]...)
+		return newLeaf(append(values, n.values[index+1:]...)), value
+	}
+	if index < n.left.size {
+		left, value := remove(n.left, index)
+		return join(left, n.right), value
+	}
+	right, value := remove(n.right, index-n.left.size)
+	return join(n.left, right), value
+}
+
+// appendValues appends the values of a tree to a slice in order.
+func appendValues[V any](values []V, n *node[V]) []V {
+	if n == nil {
+		return values
+	}
+	if n.isLeaf() {
+		return append(values, n.values...)
+	}
+	return appendValues(appendValues(values, n.left), n.right)
+}
@@ -0,0 +1,507 @@
+// Package rope implements sequences backed by balanced trees of chunks,
+// which support efficient insertion and removal at any position.
+package rope
+
+import (
+	"fmt"
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/listview"
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+	"strings"
+)
+
+// Rope is an implementation of a list backed by a balanced
+// tree whose leaves hold chunks of up to 64 values.
+//
+// Access, insertion and removal at any index take O(logn),
+// as do concatenating and splitting ropes. Since the tree's
+// nodes are never modified, ropes created from one another
+// (Clone, Concat, SplitAt, Slice) share their common nodes.
+//
+// The value equality function may be omitted (nil)
+// if no methods are called which use it.
+type Rope[V any] struct {
+	eq   structs.EqualFunc[V]
+	root *node[V]
+	// modCount is incremented whenever the rope is structurally
+	// modified, which allows iterators to detect concurrent changes.
+	modCount int
+}
+
+var _ structs.List[int] = (*Rope[int])(nil)
+
+// New creates an empty Rope.
+func New[V any](eq structs.EqualFunc[V]) *Rope[V] {
+	return &Rope[V]{
+		eq: eq,
+	}
+}
+
+// NewOrdered creates an empty Rope from a type that implements constraints.Ordered.
+func NewOrdered[V constraints.Ordered]() *Rope[V] {
+	return New(structs.EqualOrdered[V])
+}
+
+// From creates a Rope from an existing collection.
+func From[V any](eq structs.EqualFunc[V], other structs.Collection[V]) *Rope[V] {
+	return &Rope[V]{
+		eq:   eq,
+		root: build(other.Values()),
+	}
+}
+
+// FromOrdered creates a Rope from an existing collection of a type that implements constraints.Ordered.
+func FromOrdered[V constraints.Ordered](other structs.Collection[V]) *Rope[V] {
+	return From(structs.EqualOrdered[V], other)
+}
+
+// Of creates a Rope from an existing slice. The values are copied.
+func Of[V any](eq structs.EqualFunc[V], values ...V) *Rope[V] {
+	return &Rope[V]{
+		eq:   eq,
+		root: build(slices.Clone(values)),
+	}
+}
+
+// OfOrdered creates a Rope from an existing slice of a type
+// that implements constraints.Ordered. The values are copied.
+func OfOrdered[V constraints.Ordered](values ...V) *Rope[V] {
+	return Of(structs.EqualOrdered[V], values...)
+}
+
+// Add adds a value to the end of the rope.
+//
+// Time Complexity: O(logn)
+func (r *Rope[V]) Add(value V) bool {
+	r.AddAt(r.Size(), value)
+	return true
+}
+
+// AddAt adds a value at the specified index in the rope.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(logn)
+func (r *Rope[V]) AddAt(index int, value V) {
+	r.checkBounds(index, true)
+
+	r.root = insert(r.root, index, value)
+	r.modCount++
+}
+
+// AddAll adds all the values in the other collection to the end of the rope.
+//
+// Time Complexity: O(m+logn)
+func (r *Rope[V]) AddAll(other structs.Collection[V]) bool {
+	return r.AddAllAt(r.Size(), other)
+}
+
+// AddAllAt adds all the values in the other collection at the
+// specified index in the rope, in the order they are iterated.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(m+logn)
+func (r *Rope[V]) AddAllAt(index int, other structs.Collection[V]) bool {
+	r.checkBounds(index, true)
+
+	values := other.Values()
+	if len(values) == 0 {
+		return false
+	}
+	left, right := split(r.root, index)
+	r.root = join(join(left, build(values)), right)
+	r.modCount++
+	return true
+}
+
+// AddIterator adds all the values in the iterator to the end of the rope.
+//
+// Time Complexity: O(m+logn)
+func (r *Rope[V]) AddIterator(iter structs.Iterator[V]) bool {
+	var values []V
+	for iter.HasNext() {
+		values = append(values, iter.Next())
+	}
+	if len(values) == 0 {
+		return false
+	}
+	r.root = join(r.root, build(values))
+	r.modCount++
+	return true
+}
+
+// Clear clears the rope.
+//
+// Time Complexity: O(1)
+func (r *Rope[V]) Clear() {
+	r.root = nil
+	r.modCount++
+}
+
+// Clone creates a copy of the rope, which shares its nodes with the rope.
+//
+// Time Complexity: O(1)
+func (r *Rope[V]) Clone() *Rope[V] {
+	return &Rope[V]{
+		eq:   r.eq,
+		root: r.root,
+	}
+}
+
+// Concat adds all the values in the other rope to the end of the rope.
+// The other rope is unchanged, and shares its nodes with the rope.
+//
+// Time Complexity: O(logn+logm)
+func (r *Rope[V]) Concat(other *Rope[V]) {
+	if other.root == nil {
+		return
+	}
+	r.root = join(r.root, other.root)
+	r.modCount++
+}
+
+// Contains returns whether the value is present in the rope.
+//
+// Time Complexity: O(n)
+func (r *Rope[V]) Contains(value V) bool {
+	return r.IndexOf(value) >= 0
+}
+
+// ContainsAll returns whether all the values in the other collection are present in the rope.
+//
+// Time Complexity: O(nm)
+func (r *Rope[V]) ContainsAll(other structs.Collection[V]) bool {
+	return r.ContainsIterator(other.Iterator())
+}
+
+// ContainsIterator returns whether all the values in the iterator are present in the rope.
+//
+// Time Complexity: O(nm)
+func (r *Rope[V]) ContainsIterator(iter structs.Iterator[V]) bool {
+	for iter.HasNext() {
+		if !r.Contains(iter.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Each calls a function for each value in the rope, starting at the front of the rope.
+func (r *Rope[V]) Each(f func(value V)) {
+	each(r.root, f)
+}
+
+// Get returns the value at the specified index.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(logn)
+func (r *Rope[V]) Get(index int) V {
+	r.checkBounds(index, false)
+
+	return get(r.root, index)
+}
+
+// IndexOf returns the first index of a value in the rope,
+// or -1 if the value is not present in the rope.
+//
+// Time Complexity: O(n)
+func (r *Rope[V]) IndexOf(value V) int {
+	index, found := 0, false
+	eachLeaf(r.root, func(values []V) bool {
+		for _, val := range values {
+			if r.eq(val, value) {
+				found = true
+				return false
+			}
+			index++
+		}
+		return true
+	})
+	if !found {
+		return -1
+	}
+	return index
+}
+
+// IsEmpty returns whether the rope is empty.
+func (r *Rope[V]) IsEmpty() bool {
+	return r.root == nil
+}
+
+// Iterator returns an Iterator for the rope.
+func (r *Rope[V]) Iterator() structs.Iterator[V] {
+	return &Iterator[V]{
+		rope:             r,
+		last:             -1,
+		expectedModCount: r.modCount,
+	}
+}
+
+// LastIndexOf returns the last index of a value in the rope,
+// or -1 if the value is not present in the rope.
+//
+// Time Complexity: O(n)
+func (r *Rope[V]) LastIndexOf(value V) int {
+	last, index := -1, 0
+	eachLeaf(r.root, func(values []V) bool {
+		for _, val := range values {
+			if r.eq(val, value) {
+				last = index
+			}
+			index++
+		}
+		return true
+	})
+	return last
+}
+
+// Remove removes the first occurrence of a value from
+// the rope and returns whether the value was present.
+//
+// Time Complexity: O(n)
+func (r *Rope[V]) Remove(value V) bool {
+	index := r.IndexOf(value)
+	if index < 0 {
+		return false
+	}
+	r.RemoveAt(index)
+	return true
+}
+
+// RemoveAll removes all the values in the other collection from the rope.
+//
+// Time Complexity: O(nm)
+func (r *Rope[V]) RemoveAll(other structs.Collection[V]) bool {
+	return r.filter(func(value V) bool {
+		return !other.Contains(value)
+	})
+}
+
+// RemoveAt removes the value at the specified index from the rope and returns it.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(logn)
+func (r *Rope[V]) RemoveAt(index int) V {
+	r.checkBounds(index, false)
+
+	root, value := remove(r.root, index)
+	r.root = root
+	r.modCount++
+	return value
+}
+
+// RemoveIterator removes all the values in the iterator from the rope.
+//
+// Time Complexity: O(nm)
+func (r *Rope[V]) RemoveIterator(iter structs.Iterator[V]) bool {
+	changed := false
+	for iter.HasNext() {
+		if r.Remove(iter.Next()) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// RemoveRange removes the values between the from index,
+// inclusive, and the to index, exclusive, from the rope.
+//
+// Panics if the range is out of bounds.
+//
+// Time Complexity: O(logn)
+func (r *Rope[V]) RemoveRange(from, to int) {
+	checkRange(from, to, r.Size())
+	if from == to {
+		return
+	}
+
+	left, rest := split(r.root, from)
+	_, right := split(rest, to-from)
+	r.root = join(left, right)
+	r.modCount++
+}
+
+// RetainAll removes all the values not present in the other collection from the rope.
+//
+// Time Complexity: O(nm)
+//
+//	n = size of the rope
+//	m = time complexity of Contains on the other collection
+func (r *Rope[V]) RetainAll(other structs.Collection[V]) bool {
+	return r.filter(other.Contains)
+}
+
+// Set sets the value at the specified index, returning the old value.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(logn)
+func (r *Rope[V]) Set(index int, value V) V {
+	r.checkBounds(index, false)
+
+	oldValue := get(r.root, index)
+	r.root = set(r.root, index, value)
+	return oldValue
+}
+
+// Size returns the number of values in the rope.
+//
+// Time Complexity: O(1)
+func (r *Rope[V]) Size() int {
+	if r.root == nil {
+		return 0
+	}
+	return r.root.size
+}
+
+// Slice returns a new rope containing the values between the from
+// index, inclusive, and the to index, exclusive. Unlike SubList,
+// the new rope is independent of the rope, but shares its nodes.
+//
+// Panics if the range is out of bounds.
+//
+// Time Complexity: O(logn)
+func (r *Rope[V]) Slice(from, to int) *Rope[V] {
+	checkRange(from, to, r.Size())
+
+	_, rest := split(r.root, from)
+	middle, _ := split(rest, to-from)
+	return &Rope[V]{
+		eq:   r.eq,
+		root: middle,
+	}
+}
+
+// Sort sorts the rope based on a comparator.
+//
+// Time Complexity: O(nlogn)
+// See slices.SortFunc (algorithm: pattern-defeating quicksort).
+//
+// Space Complexity: O(n)
+func (r *Rope[V]) Sort(cmp structs.LessFunc[V]) {
+	values := r.Values()
+	slices.SortFunc(values, cmp)
+	r.root = build(values)
+}
+
+// SplitAt removes the values from the index onward
+// from the rope, and returns them as a new rope.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(logn)
+func (r *Rope[V]) SplitAt(index int) *Rope[V] {
+	r.checkBounds(index, true)
+
+	left, right := split(r.root, index)
+	r.root = left
+	r.modCount++
+	return &Rope[V]{
+		eq:   r.eq,
+		root: right,
+	}
+}
+
+// String returns a string representation of the rope, with brackets and comma separated.
+func (r *Rope[V]) String() string {
+	var buf strings.Builder
+	buf.WriteRune('[')
+	r.Each(func(value V) {
+		if buf.Len() > 1 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(fmt.Sprint(value))
+	})
+	buf.WriteRune(']')
+	return buf.String()
+}
+
+// SubList returns a view of the rope between the from index, inclusive,
+// and the to index, exclusive. The view is backed by the rope, and
+// becomes invalid if the rope is structurally modified other than
+// through the view. To copy a range of the rope instead, use Slice.
+//
+// Panics if the range is out of bounds.
+//
+// Time Complexity: O(1)
+func (r *Rope[V]) SubList(from, to int) structs.List[V] {
+	return listview.New(&listview.Backing[V]{
+		Equal:    r.eq,
+		Get:      r.Get,
+		Set:      r.Set,
+		AddAt:    r.AddAt,
+		RemoveAt: r.RemoveAt,
+		ModCount: func() int { return r.modCount },
+	}, r.Size(), from, to)
+}
+
+// Values returns a slice of the values in the rope, starting at the front of the rope.
+//
+// Time Complexity: O(n)
+//
+// Space Complexity: O(n)
+func (r *Rope[V]) Values() []V {
+	return appendValues(make([]V, 0, r.Size()), r.root)
+}
+
+// filter keeps only the values for which keep returns
+// true, and returns whether any values were removed.
+func (r *Rope[V]) filter(keep func(value V) bool) bool {
+	var kept []V
+	r.Each(func(value V) {
+		if keep(value) {
+			kept = append(kept, value)
+		}
+	})
+	if len(kept) == r.Size() {
+		return false
+	}
+	r.root = build(kept)
+	r.modCount++
+	return true
+}
+
+// checkBounds checks whether an index is within the bounds of the rope.
+//
+// if allowEnd is true, index == r.Size() is allowed. this is useful
+// for operations which may insert after the rope's end.
+func (r *Rope[V]) checkBounds(index int, allowEnd bool) {
+	size := r.Size()
+	if index < 0 || index > size || (!allowEnd && index == size) {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+}
+
+// checkRange checks whether a range is within the bounds of a collection of the size provided.
+func checkRange(from, to, size int) {
+	if from < 0 || to > size || from > to {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+}
+
+// each calls a function for each value of a tree in order.
+func each[V any](n *node[V], f func(value V)) {
+	eachLeaf(n, func(values []V) bool {
+		for _, value := range values {
+			f(value)
+		}
+		return true
+	})
+}
+
+// eachLeaf calls a function for the values of each leaf of a
+// tree in order, until the function returns false. It returns
+// whether the function returned true for every leaf.
+func eachLeaf[V any](n *node[V], f func(values []V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if n.isLeaf() {
+		return f(n.values)
+	}
+	return eachLeaf(n.left, f) && eachLeaf(n.right, f)
+}
@@ -0,0 +1,151 @@
+package rope
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/listtest"
+	"github.com/zytekaron/structs/wrap"
+	"golang.org/x/exp/slices"
+	"math/rand"
+	"testing"
+)
+
+func TestRope(t *testing.T) {
+	listtest.Run(t, func() structs.List[int] {
+		return NewOrdered[int]()
+	})
+}
+
+func TestSubList(t *testing.T) {
+	listtest.Run(t, func() structs.List[int] {
+		// an empty view in the middle of a rope
+		return OfOrdered(-1, -2).SubList(1, 1)
+	})
+}
+
+func TestRope_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	rope := NewOrdered[int]()
+	var model []int
+	for i := 0; i < 3000; i++ {
+		switch op := r.Intn(10); {
+		case op < 4 || len(model) == 0:
+			index := r.Intn(len(model) + 1)
+			rope.AddAt(index, i)
+			model = slices.Insert(model, index, i)
+		case op < 6:
+			index := r.Intn(len(model))
+			if got := rope.RemoveAt(index); got != model[index] {
+				t.Fatalf("expected RemoveAt(%d) to return %d but got %d", index, model[index], got)
+			}
+			model = slices.Delete(model, index, index+1)
+		case op < 7:
+			index := r.Intn(len(model))
+			rope.Set(index, -i)
+			model[index] = -i
+		case op < 8:
+			from := r.Intn(len(model) + 1)
+			to := from + r.Intn(len(model)-from+1)
+			if to-from < 20 {
+				rope.RemoveRange(from, to)
+				model = slices.Delete(model, from, to)
+			}
+		case op < 9:
+			values := make([]int, r.Intn(200))
+			for j := range values {
+				values[j] = i
+			}
+			index := r.Intn(len(model) + 1)
+			rope.AddAllAt(index, wrap.OrderedValues(values...))
+			model = slices.Insert(model, index, values...)
+		default:
+			index := r.Intn(len(model) + 1)
+			tail := rope.SplitAt(index)
+			listtest.ExpectValues(t, tail.Values(), model[index:])
+			rope.Concat(tail)
+		}
+
+		checkTree(t, rope.root)
+		listtest.ExpectValues(t, rope.Values(), model)
+	}
+
+	for i, value := range model {
+		if got := rope.Get(i); got != value {
+			t.Fatalf("expected Get(%d) to return %d but got %d", i, value, got)
+		}
+	}
+}
+
+func TestRope_Build(t *testing.T) {
+	values := make([]int, 10_000)
+	for i := range values {
+		values[i] = i
+	}
+
+	rope := OfOrdered(values...)
+	checkTree(t, rope.root)
+	if rope.root.height > 10 {
+		t.Errorf("expected a balanced tree but got height %d", rope.root.height)
+	}
+	listtest.ExpectValues(t, rope.Slice(100, 300).Values(), values[100:300])
+}
+
+func TestRope_Sharing(t *testing.T) {
+	a := OfOrdered(1, 2, 3)
+	b := OfOrdered(4, 5)
+
+	a.Concat(b)
+	b.Add(6)
+	clone := a.Clone()
+	clone.Set(0, 0)
+	slice := a.Slice(1, 4)
+	slice.RemoveAt(0)
+
+	// changes to ropes sharing nodes are independent
+	listtest.ExpectValues(t, a.Values(), []int{1, 2, 3, 4, 5})
+	listtest.ExpectValues(t, b.Values(), []int{4, 5, 6})
+	listtest.ExpectValues(t, clone.Values(), []int{0, 2, 3, 4, 5})
+	listtest.ExpectValues(t, slice.Values(), []int{3, 4})
+}
+
+func TestRope_IteratorRemove(t *testing.T) {
+	values := make([]int, 500)
+	for i := range values {
+		values[i] = i
+	}
+	rope := OfOrdered(values...)
+
+	var expect []int
+	for it := rope.Iterator(); it.HasNext(); {
+		if value := it.Next(); value%3 == 0 {
+			it.Remove()
+		} else {
+			expect = append(expect, value)
+		}
+	}
+	listtest.ExpectValues(t, rope.Values(), expect)
+}
+
+// checkTree checks that each node's size and height are consistent
+// with its children, and that the heights of siblings differ by at most 1.
+func checkTree[V any](t *testing.T, n *node[V]) {
+	t.Helper()
+	if n == nil {
+		return
+	}
+	if n.isLeaf() {
+		if n.size != len(n.values) || n.size == 0 || n.size > maxLeaf || n.height != 0 {
+			t.Fatalf("invalid leaf with size %d, %d values, height %d", n.size, len(n.values), n.height)
+		}
+		return
+	}
+	checkTree(t, n.left)
+	checkTree(t, n.right)
+	diff := n.left.height - n.right.height
+	height := n.left.height + 1
+	if n.right.height >= n.left.height {
+		height = n.right.height + 1
+	}
+	if diff < -1 || diff > 1 || n.size != n.left.size+n.right.size || n.height != height {
+		t.Fatalf("unbalanced node with child heights %d, %d", n.left.height, n.right.height)
+	}
+}
@@ -0,0 +1,482 @@
+package rope
+
+import (
+	"github.com/zytekaron/structs"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxText is the maximum number of bytes held by a leaf of a Text.
+const maxText = 1024
+
+// Text is a rope specialized for UTF-8 encoded text, whose leaves
+// hold strings. Each node records both its length in bytes and its
+// number of runes, so positions may be given as either byte offsets
+// or rune offsets, and each can be converted to the other in O(logn).
+//
+// Byte offsets must not split a rune, and neither may strings and
+// texts which are added: one which starts with continuation bytes
+// cannot be added after an incomplete rune. Like utf8.RuneCountInString,
+// each byte of invalid UTF-8 counts as a single rune.
+//
+// The zero value is an empty Text. Like Rope, Texts created from
+// one another share their common nodes.
+type Text struct {
+	root *textNode
+}
+
+// textNode is a node of the AVL tree backing a Text,
+// which is never modified once created. See node.
+type textNode struct {
+	left   *textNode
+	right  *textNode
+	text   string // empty for internal nodes
+	len    int    // length in bytes
+	runes  int
+	height int // 0 for leaves
+}
+
+// NewText creates a Text holding a string.
+//
+// Time Complexity: O(n)
+func NewText(s string) *Text {
+	return &Text{
+		root: buildText(s),
+	}
+}
+
+// Append adds a string to the end of the text.
+//
+// Panics if the string would complete an incomplete rune at the end of the text.
+//
+// Time Complexity: O(m+logn)
+func (t *Text) Append(s string) {
+	checkJoin(t.root, s)
+
+	t.root = joinText(t.root, buildText(s))
+}
+
+// ByteAt returns the byte at the byte offset.
+//
+// Panics if the offset is out of bounds.
+//
+// Time Complexity: O(logn)
+func (t *Text) ByteAt(offset int) byte {
+	if offset < 0 || offset >= t.Len() {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+
+	leaf, start := textLeafAt(t.root, offset)
+	return leaf.text[offset-start]
+}
+
+// ByteOffset returns the byte offset of the rune at the rune offset.
+// The rune offset may be the number of runes, for the end of the text.
+//
+// Panics if the offset is out of bounds.
+//
+// Time Complexity: O(logn)
+func (t *Text) ByteOffset(runeOffset int) int {
+	if runeOffset < 0 || runeOffset > t.RuneCount() {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+
+	n, offset := t.root, 0
+	for n != nil && n.left != nil {
+		if runeOffset < n.left.runes {
+			n = n.left
+		} else {
+			runeOffset -= n.left.runes
+			offset += n.left.len
+			n = n.right
+		}
+	}
+	if n == nil {
+		return 0
+	}
+	for i := range n.text {
+		if runeOffset == 0 {
+			return offset + i
+		}
+		runeOffset--
+	}
+	return offset + n.len
+}
+
+// Clone creates a copy of the text, which shares its nodes with the text.
+//
+// Time Complexity: O(1)
+func (t *Text) Clone() *Text {
+	return &Text{
+		root: t.root,
+	}
+}
+
+// Concat adds the other text to the end of the text. The
+// other text is unchanged, and shares its nodes with the text.
+//
+// Panics if the other text would complete an incomplete rune at the end of the text.
+//
+// Time Complexity: O(logn+logm)
+func (t *Text) Concat(other *Text) {
+	checkJoin(t.root, textSlice(other.root, 0, 1))
+
+	t.root = joinText(t.root, other.root)
+}
+
+// Delete removes the bytes between the from offset,
+// inclusive, and the to offset, exclusive.
+//
+// Panics if the range is out of bounds or splits a rune.
+//
+// Time Complexity: O(logn)
+func (t *Text) Delete(from, to int) {
+	t.checkRange(from, to)
+
+	left, rest := splitText(t.root, from)
+	_, right := splitText(rest, to-from)
+	t.root = joinText(left, right)
+}
+
+// DeleteRunes removes the runes between the from rune
+// offset, inclusive, and the to rune offset, exclusive.
+//
+// Panics if the range is out of bounds.
+//
+// Time Complexity: O(logn)
+func (t *Text) DeleteRunes(from, to int) {
+	checkRange(from, to, t.RuneCount())
+
+	t.Delete(t.ByteOffset(from), t.ByteOffset(to))
+}
+
+// Insert inserts a string at the byte offset.
+//
+// Panics if the offset is out of bounds or splits a rune, or if the
+// string would complete an incomplete rune before the offset.
+//
+// Time Complexity: O(m+logn)
+func (t *Text) Insert(offset int, s string) {
+	t.checkOffset(offset)
+
+	left, right := splitText(t.root, offset)
+	// the right side starts with a rune, so only the left side is checked
+	checkJoin(left, s)
+	t.root = joinText(joinText(left, buildText(s)), right)
+}
+
+// InsertAtRune inserts a string at the rune offset.
+//
+// Panics if the offset is out of bounds, or if the string
+// would complete an incomplete rune before the offset.
+//
+// Time Complexity: O(m+logn)
+func (t *Text) InsertAtRune(runeOffset int, s string) {
+	t.Insert(t.ByteOffset(runeOffset), s)
+}
+
+// Len returns the length of the text in bytes.
+//
+// Time Complexity: O(1)
+func (t *Text) Len() int {
+	if t.root == nil {
+		return 0
+	}
+	return t.root.len
+}
+
+// RuneAt returns the rune at the rune offset.
+//
+// Panics if the offset is out of bounds.
+//
+// Time Complexity: O(logn)
+func (t *Text) RuneAt(runeOffset int) rune {
+	if runeOffset < 0 || runeOffset >= t.RuneCount() {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+
+	// leaves never split a rune, so the rune is within a single leaf
+	offset := t.ByteOffset(runeOffset)
+	leaf, start := textLeafAt(t.root, offset)
+	r, _ := utf8.DecodeRuneInString(leaf.text[offset-start:])
+	return r
+}
+
+// RuneCount returns the number of runes in the text.
+//
+// Time Complexity: O(1)
+func (t *Text) RuneCount() int {
+	if t.root == nil {
+		return 0
+	}
+	return t.root.runes
+}
+
+// RuneOffset returns the rune offset of the rune at the byte offset.
+// The byte offset may be the length of the text, for the end of the text.
+//
+// Panics if the offset is out of bounds or splits a rune.
+//
+// Time Complexity: O(logn)
+func (t *Text) RuneOffset(offset int) int {
+	t.checkOffset(offset)
+
+	n, runeOffset := t.root, 0
+	for n != nil && n.left != nil {
+		if offset < n.left.len {
+			n = n.left
+		} else {
+			offset -= n.left.len
+			runeOffset += n.left.runes
+			n = n.right
+		}
+	}
+	if n == nil {
+		return 0
+	}
+	return runeOffset + utf8.RuneCountInString(n.text[:offset])
+}
+
+// Slice returns the bytes between the from offset,
+// inclusive, and the to offset, exclusive, as a string.
+//
+// Panics if the range is out of bounds or splits a rune.
+//
+// Time Complexity: O(m+logn)
+func (t *Text) Slice(from, to int) string {
+	t.checkRange(from, to)
+
+	return textSlice(t.root, from, to)
+}
+
+// SliceRunes returns the runes between the from rune offset,
+// inclusive, and the to rune offset, exclusive, as a string.
+//
+// Panics if the range is out of bounds.
+//
+// Time Complexity: O(m+logn)
+func (t *Text) SliceRunes(from, to int) string {
+	checkRange(from, to, t.RuneCount())
+
+	return t.Slice(t.ByteOffset(from), t.ByteOffset(to))
+}
+
+// SplitAt removes the bytes from the byte offset onward
+// from the text, and returns them as a new text.
+//
+// Panics if the offset is out of bounds or splits a rune.
+//
+// Time Complexity: O(logn)
+func (t *Text) SplitAt(offset int) *Text {
+	t.checkOffset(offset)
+
+	left, right := splitText(t.root, offset)
+	t.root = left
+	return &Text{
+		root: right,
+	}
+}
+
+// String returns the text as a string.
+//
+// Time Complexity: O(n)
+func (t *Text) String() string {
+	return t.Slice(0, t.Len())
+}
+
+// checkOffset checks whether a byte offset is within
+// the bounds of the text and does not split a rune.
+func (t *Text) checkOffset(offset int) {
+	if offset < 0 || offset > t.Len() {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+	if offset < t.Len() && !utf8.RuneStart(t.ByteAt(offset)) {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+}
+
+// checkRange checks whether a range of byte offsets is within
+// the bounds of the text and does not split a rune.
+func (t *Text) checkRange(from, to int) {
+	checkRange(from, to, t.Len())
+	t.checkOffset(from)
+	t.checkOffset(to)
+}
+
+// checkJoin panics if a string which starts with continuation bytes would
+// be added after an incomplete rune at the end of a tree. Leaves count
+// their runes separately, so the rune would otherwise be counted as
+// several runes, depending on whether its bytes are in the same leaf.
+func checkJoin(left *textNode, s string) {
+	if left == nil || s == "" || utf8.RuneStart(s[0]) {
+		return
+	}
+	// an incomplete rune is at most utf8.UTFMax-1 bytes long
+	from := left.len - (utf8.UTFMax - 1)
+	if from < 0 {
+		from = 0
+	}
+	tail := textSlice(left, from, left.len)
+	for i := len(tail) - 1; i >= 0; i-- {
+		if utf8.RuneStart(tail[i]) {
+			if !utf8.FullRuneInString(tail[i:]) {
+				panic("string splits a rune")
+			}
+			return
+		}
+	}
+}
+
+// newTextLeaf creates a leaf holding the string,
+// or returns nil if it is empty.
+func newTextLeaf(s string) *textNode {
+	if s == "" {
+		return nil
+	}
+	return &textNode{
+		text:  s,
+		len:   len(s),
+		runes: utf8.RuneCountInString(s),
+	}
+}
+
+// newTextInternal creates an internal node with two non-nil children.
+func newTextInternal(left, right *textNode) *textNode {
+	height := left.height
+	if right.height > height {
+		height = right.height
+	}
+	return &textNode{
+		left:   left,
+		right:  right,
+		len:    left.len + right.len,
+		runes:  left.runes + right.runes,
+		height: height + 1,
+	}
+}
+
+// buildText creates a balanced tree from a string,
+// splitting it into leaves only between runes.
+//
+// Time Complexity: O(n)
+func buildText(s string) *textNode {
+	if len(s) <= maxText {
+		return newTextLeaf(s)
+	}
+	mid := len(s) / 2
+	for mid > 0 && !utf8.RuneStart(s[mid]) {
+		mid--
+	}
+	if mid == 0 {
+		// a run of invalid continuation bytes
+		mid = len(s) / 2
+	}
+	return joinText(buildText(s[:mid]), buildText(s[mid:]))
+}
+
+// joinText concatenates two trees. See join.
+func joinText(left, right *textNode) *textNode {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	if left.left == nil && right.left == nil && left.len+right.len <= maxText {
+		return newTextLeaf(left.text + right.text)
+	}
+	if left.height > right.height+1 {
+		return balanceText(left.left, joinText(left.right, right))
+	}
+	if right.height > left.height+1 {
+		return balanceText(joinText(left, right.left), right.right)
+	}
+	return newTextInternal(left, right)
+}
+
+// balanceText creates a node from two subtrees. See balance.
+func balanceText(left, right *textNode) *textNode {
+	switch {
+	case left.height > right.height+1:
+		if left.left.height >= left.right.height {
+			return newTextInternal(left.left, newTextInternal(left.right, right))
+		}
+		inner := left.right
+		return newTextInternal(newTextInternal(left.left, inner.left), newTextInternal(inner.right, right))
+	case right.height > left.height+1:
+		if right.right.height >= right.left.height {
+			return newTextInternal(newTextInternal(left, right.left), right.right)
+		}
+		inner := right.left
+		return newTextInternal(newTextInternal(left, inner.left), newTextInternal(inner.right, right.right))
+	}
+	return newTextInternal(left, right)
+}
+
+// splitText splits a tree at a byte offset. See split.
+func splitText(n *textNode, offset int) (*textNode, *textNode) {
+	switch {
+	case offset == 0:
+		return nil, n
+	case offset == n.len:
+		return n, nil
+	case n.left == nil:
+		return newTextLeaf(n.text[:offset]), newTextLeaf(n.text[offset:])
+	case offset < n.left.len:
+		left, right := splitText(n.left, offset)
+		return left, joinText(right, n.right)
+	default:
+		left, right := splitText(n.right, offset-n.left.len)
+		return joinText(n.left, left), right
+	}
+}
+
+// textLeafAt returns the leaf holding the byte offset,
+// and the byte offset of the leaf's first byte.
+func textLeafAt(n *textNode, offset int) (*textNode, int) {
+	start := 0
+	for n.left != nil {
+		if offset-start < n.left.len {
+			n = n.left
+		} else {
+			start += n.left.len
+			n = n.right
+		}
+	}
+	return n, start
+}
+
+// textSlice returns the bytes of a tree between the from
+// offset, inclusive, and the to offset, exclusive.
+func textSlice(n *textNode, from, to int) string {
+	var buf strings.Builder
+	buf.Grow(to - from)
+	writeText(&buf, n, from, to)
+	return buf.String()
+}
+
+// writeText writes the bytes of a tree between the from
+// offset, inclusive, and the to offset, exclusive.
+func writeText(buf *strings.Builder, n *textNode, from, to int) {
+	if n == nil || from >= to {
+		return
+	}
+	if n.left == nil {
+		buf.WriteString(n.text[from:to])
+		return
+	}
+	if from < n.left.len {
+		end := to
+		if end > n.left.len {
+			end = n.left.len
+		}
+		writeText(buf, n.left, from, end)
+	}
+	if to > n.left.len {
+		start := from - n.left.len
+		if start < 0 {
+			start = 0
+		}
+		writeText(buf, n.right, start, to-n.left.len)
+	}
+}
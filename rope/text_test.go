@@ -0,0 +1,151 @@
+package rope
+
+import (
+	"github.com/zytekaron/structs"
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestText(t *testing.T) {
+	var text Text
+	text.Append("héllo")
+	text.Append(" wörld")
+	if text.String() != "héllo wörld" {
+		t.Fatalf("expected 'héllo wörld' but got '%s'", text.String())
+	}
+	if text.Len() != 13 || text.RuneCount() != 11 {
+		t.Errorf("expected 13 bytes and 11 runes but got %d and %d", text.Len(), text.RuneCount())
+	}
+
+	text.InsertAtRune(5, ",")
+	if got := text.String(); got != "héllo, wörld" {
+		t.Errorf("expected 'héllo, wörld' but got '%s'", got)
+	}
+	if r := text.RuneAt(8); r != 'ö' {
+		t.Errorf("expected rune 'ö' at rune offset 8 but got %q", r)
+	}
+	if offset := text.ByteOffset(9); offset != 11 {
+		t.Errorf("expected rune offset 9 at byte offset 11 but got %d", offset)
+	}
+	if offset := text.RuneOffset(11); offset != 9 {
+		t.Errorf("expected byte offset 11 at rune offset 9 but got %d", offset)
+	}
+	if got := text.SliceRunes(7, 12); got != "wörld" {
+		t.Errorf("expected 'wörld' but got '%s'", got)
+	}
+
+	text.DeleteRunes(1, 5)
+	if got := text.String(); got != "h, wörld" {
+		t.Errorf("expected 'h, wörld' but got '%s'", got)
+	}
+}
+
+func TestText_SplitRune(t *testing.T) {
+	defer func() {
+		if r := recover(); r != structs.PanicIndexOutOfBounds {
+			t.Errorf("expected panic %q but got %v", structs.PanicIndexOutOfBounds, r)
+		}
+	}()
+
+	text := NewText("é")
+	text.Insert(1, "x") // within the two bytes of 'é'
+}
+
+func TestText_JoinSplitsRune(t *testing.T) {
+	expectPanic := func(name string, f func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected %s to panic", name)
+			}
+		}()
+		f()
+	}
+
+	// the first byte of '中' in one leaf, and the rest in another
+	text := NewText(strings.Repeat("a", maxText-1) + "\xe4")
+	expectPanic("append", func() { text.Append("\xb8\xad") })
+	expectPanic("concat", func() { text.Concat(NewText("\xb8\xad")) })
+	expectPanic("insert", func() { text.Insert(text.Len(), "\xb8\xad") })
+	if text.RuneCount() != utf8.RuneCountInString(text.String()) {
+		t.Errorf("expected %d runes but got %d", utf8.RuneCountInString(text.String()), text.RuneCount())
+	}
+
+	// continuation bytes after a complete rune are each counted as a rune
+	text = NewText("a")
+	text.Append("\xb8\xad")
+	if text.RuneCount() != 3 || text.RuneCount() != utf8.RuneCountInString(text.String()) {
+		t.Errorf("expected 3 runes but got %d", text.RuneCount())
+	}
+}
+
+func TestText_Random(t *testing.T) {
+	pieces := []string{"a", "é", "語", "😀", "line\n", strings.Repeat("z", 700)}
+
+	r := rand.New(rand.NewSource(1))
+	text := NewText("")
+	var model []rune
+	for i := 0; i < 2000; i++ {
+		switch op := r.Intn(8); {
+		case op < 4 || len(model) == 0:
+			s := pieces[r.Intn(len(pieces))]
+			offset := r.Intn(len(model) + 1)
+			text.InsertAtRune(offset, s)
+			model = append(model[:offset], append([]rune(s), model[offset:]...)...)
+		case op < 6:
+			from := r.Intn(len(model))
+			to := from + r.Intn(len(model)-from+1)
+			if to-from > 1000 {
+				to = from + 1000
+			}
+			text.DeleteRunes(from, to)
+			model = append(model[:from], model[to:]...)
+		case op < 7:
+			offset := r.Intn(len(model) + 1)
+			tail := text.SplitAt(text.ByteOffset(offset))
+			if got := tail.String(); got != string(model[offset:]) {
+				t.Fatalf("expected tail '%s' but got '%s'", string(model[offset:]), got)
+			}
+			text.Concat(tail)
+		default:
+			offset := r.Intn(len(model))
+			if got := text.RuneAt(offset); got != model[offset] {
+				t.Fatalf("expected rune %q at %d but got %q", model[offset], offset, got)
+			}
+			if got := text.RuneOffset(text.ByteOffset(offset)); got != offset {
+				t.Fatalf("expected rune offset %d to round trip but got %d", offset, got)
+			}
+		}
+
+		checkText(t, text.root)
+		if got := text.String(); got != string(model) {
+			t.Fatalf("expected text of %d runes but got %d runes", len(model), utf8.RuneCountInString(got))
+		}
+		if text.RuneCount() != len(model) {
+			t.Fatalf("expected %d runes but got %d", len(model), text.RuneCount())
+		}
+	}
+}
+
+// checkText checks that each leaf starts with a rune, and that each
+// node's length, rune count and height are consistent with its children.
+func checkText(t *testing.T, n *textNode) {
+	t.Helper()
+	if n == nil {
+		return
+	}
+	if n.left == nil {
+		if n.text == "" || len(n.text) > maxText || !utf8.RuneStart(n.text[0]) {
+			t.Fatalf("invalid leaf of %d bytes", len(n.text))
+		}
+		return
+	}
+	checkText(t, n.left)
+	checkText(t, n.right)
+	diff := n.left.height - n.right.height
+	if diff < -1 || diff > 1 || n.len != n.left.len+n.right.len || n.runes != n.left.runes+n.right.runes {
+		t.Fatalf("invalid node with child heights %d, %d", n.left.height, n.right.height)
+	}
+}
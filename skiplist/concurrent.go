@@ -0,0 +1,507 @@
+package skiplist
+
+import (
+	"github.com/zytekaron/structs"
+	"golang.org/x/exp/constraints"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// ConcurrentSkipList is an ordered map safe for concurrent use
+// by many readers and writers, implemented as a lazy skip list.
+//
+// Lookups, queries and iteration never block. Writers lock only the
+// nodes whose links they change, so writes to distant keys proceed in
+// parallel. A removed node is first marked, which removes it logically,
+// and then unlinked; an inserted node is linked from the bottom level up
+// and only becomes visible once it is linked at every level.
+//
+// Unlike SkipList, it does not support lookup by rank, since it
+// does not maintain the span of each link. Its iterators are weakly
+// consistent: they never panic due to concurrent changes, and reflect
+// some, but not necessarily all, changes made after their creation.
+//
+// The key comparison function must be provided to
+// use most methods, but the value comparison
+// function may be omitted (nil) if no methods that
+// are called depend on value equality (ie ContainsValue).
+type ConcurrentSkipList[K, V any] struct {
+	size   int64 // accessed atomically; first for 64-bit alignment
+	keyCmp structs.CompareFunc[K]
+	valEq  structs.EqualFunc[V]
+	head   *cnode[K, V] // sentinel, with MaxLevel links
+}
+
+// cnode is an entry in a ConcurrentSkipList.
+type cnode[K, V any] struct {
+	key   K
+	value unsafe.Pointer   // *V, accessed atomically
+	next  []unsafe.Pointer // *cnode[K, V], accessed atomically
+
+	mu          sync.Mutex // guards changes to the node's links
+	marked      uint32     // 1 once the node is logically removed
+	fullyLinked uint32     // 1 once the node is linked at every level
+}
+
+var _ structs.Map[int, int] = (*ConcurrentSkipList[int, int])(nil)
+
+func NewConcurrent[K, V any](keyCompare structs.CompareFunc[K], valueEqual structs.EqualFunc[V]) *ConcurrentSkipList[K, V] {
+	return &ConcurrentSkipList[K, V]{
+		keyCmp: keyCompare,
+		valEq:  valueEqual,
+		head:   &cnode[K, V]{next: make([]unsafe.Pointer, MaxLevel)},
+	}
+}
+
+func NewConcurrentOrdered[K, V constraints.Ordered]() *ConcurrentSkipList[K, V] {
+	return NewConcurrent[K, V](structs.CompareOrdered[K], structs.EqualOrdered[V])
+}
+
+func NewConcurrentOrderedKeys[K constraints.Ordered, V any](valueEqual structs.EqualFunc[V]) *ConcurrentSkipList[K, V] {
+	return NewConcurrent[K, V](structs.CompareOrdered[K], valueEqual)
+}
+
+func (s *ConcurrentSkipList[K, V]) ContainsKey(key K) bool {
+	_, ok := s.Load(key)
+	return ok
+}
+
+// ContainsValue returns whether any key maps to the value.
+//
+// Time Complexity: O(n)
+func (s *ConcurrentSkipList[K, V]) ContainsValue(value V) bool {
+	found := false
+	s.Range(func(_ K, v V) bool {
+		found = s.valEq(value, v)
+		return !found
+	})
+	return found
+}
+
+func (s *ConcurrentSkipList[K, V]) Get(key K) V {
+	value, _ := s.Load(key)
+	return value
+}
+
+// Load returns the value the key maps to, and whether the key is present.
+//
+// Time Complexity: O(logn)
+func (s *ConcurrentSkipList[K, V]) Load(key K) (V, bool) {
+	x := s.head
+	for i := MaxLevel - 1; i >= 0; i-- {
+		next := x.loadNext(i)
+		for next != nil && s.keyCmp(next.key, key) < 0 {
+			x, next = next, next.loadNext(i)
+		}
+		if next != nil && s.keyCmp(next.key, key) == 0 {
+			if next.isLive() {
+				return next.loadValue(), true
+			}
+			break
+		}
+	}
+	var null V
+	return null, false
+}
+
+// Put maps the key to the value and returns the previous value,
+// or the zero value of the type if the key was not present.
+//
+// Time Complexity: O(logn)
+func (s *ConcurrentSkipList[K, V]) Put(key K, value V) V {
+	old, _ := s.put(key, value, true)
+	return old
+}
+
+// PutIfAbsent maps the key to the value if the key is not present. It
+// returns the value the key maps to, and whether the key was present.
+//
+// Time Complexity: O(logn)
+func (s *ConcurrentSkipList[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	return s.put(key, value, false)
+}
+
+func (s *ConcurrentSkipList[K, V]) Remove(key K) V {
+	value, _ := s.Delete(key)
+	return value
+}
+
+// Delete removes the key, and returns the value it mapped
+// to and whether it was present.
+//
+// Time Complexity: O(logn)
+func (s *ConcurrentSkipList[K, V]) Delete(key K) (V, bool) {
+	var preds, succs [MaxLevel]*cnode[K, V]
+	var victim *cnode[K, V]
+	for {
+		found := s.find(key, &preds, &succs)
+		if victim == nil {
+			if found < 0 {
+				var null V
+				return null, false
+			}
+			victim = succs[found]
+			// only the top level at which a node is found is certain to be
+			// its own top level; any lower and it is still being linked
+			if !victim.isLive() || len(victim.next)-1 != found {
+				if victim.isMarked() {
+					var null V
+					return null, false
+				}
+				victim = nil
+				runtime.Gosched()
+				continue
+			}
+			victim.mu.Lock()
+			if victim.isMarked() {
+				victim.mu.Unlock()
+				var null V
+				return null, false
+			}
+			atomic.StoreUint32(&victim.marked, 1)
+		}
+
+		level := len(victim.next)
+		locked, valid := lockPreds(&preds, level, func(i int, pred *cnode[K, V]) bool {
+			return !pred.isMarked() && pred.loadNext(i) == victim
+		})
+		if !valid {
+			unlockPreds(&preds, locked)
+			continue
+		}
+		for i := level - 1; i >= 0; i-- {
+			preds[i].storeNext(i, victim.loadNext(i))
+		}
+		victim.mu.Unlock()
+		unlockPreds(&preds, locked)
+		atomic.AddInt64(&s.size, -1)
+		return victim.loadValue(), true
+	}
+}
+
+// Size returns the number of entries in the map. If the map is being
+// modified concurrently, it may not reflect changes in progress.
+func (s *ConcurrentSkipList[K, V]) Size() int {
+	return int(atomic.LoadInt64(&s.size))
+}
+
+func (s *ConcurrentSkipList[K, V]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Clear removes every entry from the map. It is not atomic: entries
+// put concurrently with a call to Clear may or may not be removed.
+//
+// Time Complexity: O(nlogn)
+func (s *ConcurrentSkipList[K, V]) Clear() {
+	for n := s.head.loadNext(0); n != nil; n = n.loadNext(0) {
+		s.Delete(n.key)
+	}
+}
+
+// First returns the entry with the lowest key, and false if the map is empty.
+//
+// Time Complexity: O(1)
+func (s *ConcurrentSkipList[K, V]) First() (K, V, bool) {
+	return s.firstLiveFrom(s.head.loadNext(0))
+}
+
+// Last returns the entry with the highest key, and false if the map is empty.
+//
+// Time Complexity: O(logn)
+func (s *ConcurrentSkipList[K, V]) Last() (K, V, bool) {
+	for {
+		x := s.head
+		for i := MaxLevel - 1; i >= 0; i-- {
+			for next := x.loadNext(i); next != nil; next = x.loadNext(i) {
+				x = next
+			}
+		}
+		if k, v, ok, retry := s.liveEntry(x); !retry {
+			return k, v, ok
+		}
+	}
+}
+
+// Floor returns the entry with the highest key less than or equal
+// to the key provided, and false if there is no such entry.
+//
+// Time Complexity: O(logn)
+func (s *ConcurrentSkipList[K, V]) Floor(key K) (K, V, bool) {
+	return s.lastBefore(key, true)
+}
+
+// Lower returns the entry with the highest key strictly less than
+// the key provided, and false if there is no such entry.
+//
+// Time Complexity: O(logn)
+func (s *ConcurrentSkipList[K, V]) Lower(key K) (K, V, bool) {
+	return s.lastBefore(key, false)
+}
+
+// Ceiling returns the entry with the lowest key greater than or equal
+// to the key provided, and false if there is no such entry.
+//
+// Time Complexity: O(logn)
+func (s *ConcurrentSkipList[K, V]) Ceiling(key K) (K, V, bool) {
+	return s.firstLiveFrom(s.findLast(key, false).loadNext(0))
+}
+
+// Higher returns the entry with the lowest key strictly greater than
+// the key provided, and false if there is no such entry.
+//
+// Time Complexity: O(logn)
+func (s *ConcurrentSkipList[K, V]) Higher(key K) (K, V, bool) {
+	return s.firstLiveFrom(s.findLast(key, true).loadNext(0))
+}
+
+// Range calls a function for each entry in key order, until the function returns false.
+func (s *ConcurrentSkipList[K, V]) Range(f func(key K, value V) bool) {
+	for n := s.head.loadNext(0); n != nil; n = n.loadNext(0) {
+		if n.isLive() && !f(n.key, n.loadValue()) {
+			return
+		}
+	}
+}
+
+// EntryIterator returns a weakly consistent iterator over the entries in key order.
+func (s *ConcurrentSkipList[K, V]) EntryIterator() *ConcurrentEntryIterator[K, V] {
+	return &ConcurrentEntryIterator[K, V]{
+		list: s,
+		next: s.nextLive(s.head.loadNext(0)),
+	}
+}
+
+// EntryIteratorFrom returns a weakly consistent iterator over the entries in key
+// order, starting at the lowest key greater than or equal to the key provided.
+func (s *ConcurrentSkipList[K, V]) EntryIteratorFrom(key K) *ConcurrentEntryIterator[K, V] {
+	return &ConcurrentEntryIterator[K, V]{
+		list: s,
+		next: s.nextLive(s.findLast(key, false).loadNext(0)),
+	}
+}
+
+// put maps the key to the value, replacing the value if
+// the key is present and replace is true. It returns the
+// previous value and whether the key was present.
+func (s *ConcurrentSkipList[K, V]) put(key K, value V, replace bool) (V, bool) {
+	var preds, succs [MaxLevel]*cnode[K, V]
+	level := randomLevel()
+	for {
+		if found := s.find(key, &preds, &succs); found >= 0 {
+			n := succs[found]
+			if n.isMarked() {
+				// wait for the node to be unlinked
+				runtime.Gosched()
+				continue
+			}
+			for atomic.LoadUint32(&n.fullyLinked) == 0 {
+				runtime.Gosched()
+			}
+			if !replace {
+				return n.loadValue(), true
+			}
+			// replace under the node's lock, which Delete holds while
+			// marking it, so a value is never swapped into a node which
+			// has already been deleted and lost
+			n.mu.Lock()
+			if n.isMarked() {
+				n.mu.Unlock()
+				runtime.Gosched()
+				continue
+			}
+			old := (*V)(atomic.SwapPointer(&n.value, unsafe.Pointer(&value)))
+			n.mu.Unlock()
+			return *old, true
+		}
+
+		locked, valid := lockPreds(&preds, level, func(i int, pred *cnode[K, V]) bool {
+			succ := succs[i]
+			return !pred.isMarked() && (succ == nil || !succ.isMarked()) && pred.loadNext(i) == succ
+		})
+		if !valid {
+			unlockPreds(&preds, locked)
+			continue
+		}
+
+		n := &cnode[K, V]{
+			key:   key,
+			value: unsafe.Pointer(&value),
+			next:  make([]unsafe.Pointer, level),
+		}
+		for i := 0; i < level; i++ {
+			n.next[i] = unsafe.Pointer(succs[i])
+		}
+		for i := 0; i < level; i++ {
+			preds[i].storeNext(i, n)
+		}
+		atomic.StoreUint32(&n.fullyLinked, 1)
+		unlockPreds(&preds, locked)
+		atomic.AddInt64(&s.size, 1)
+		var null V
+		return null, false
+	}
+}
+
+// find fills preds and succs with the nodes before and at or after the
+// key at each level, and returns the highest level at which a node with
+// the key was found, or -1 if none was found.
+func (s *ConcurrentSkipList[K, V]) find(key K, preds, succs *[MaxLevel]*cnode[K, V]) int {
+	found := -1
+	x := s.head
+	for i := MaxLevel - 1; i >= 0; i-- {
+		next := x.loadNext(i)
+		for next != nil && s.keyCmp(next.key, key) < 0 {
+			x, next = next, next.loadNext(i)
+		}
+		if found < 0 && next != nil && s.keyCmp(next.key, key) == 0 {
+			found = i
+		}
+		preds[i] = x
+		succs[i] = next
+	}
+	return found
+}
+
+// findLast returns the last node whose key is less than the key
+// provided, or less than or equal to it if inclusive is true.
+// If there is no such node, it returns the head.
+func (s *ConcurrentSkipList[K, V]) findLast(key K, inclusive bool) *cnode[K, V] {
+	x := s.head
+	for i := MaxLevel - 1; i >= 0; i-- {
+		for next := x.loadNext(i); next != nil; next = x.loadNext(i) {
+			cmp := s.keyCmp(next.key, key)
+			if cmp > 0 || (cmp == 0 && !inclusive) {
+				break
+			}
+			x = next
+		}
+	}
+	return x
+}
+
+// lastBefore returns the last live entry whose key is less than the
+// key provided, or less than or equal to it if inclusive is true.
+func (s *ConcurrentSkipList[K, V]) lastBefore(key K, inclusive bool) (K, V, bool) {
+	for {
+		if k, v, ok, retry := s.liveEntry(s.findLast(key, inclusive)); !retry {
+			return k, v, ok
+		}
+	}
+}
+
+// liveEntry returns the entry of a node found by a search backwards. If
+// the node is being inserted or removed, the entry before it is not
+// known, so the search must be retried once the change is complete.
+func (s *ConcurrentSkipList[K, V]) liveEntry(n *cnode[K, V]) (k K, v V, ok, retry bool) {
+	if n == s.head {
+		return k, v, false, false
+	}
+	if !n.isLive() {
+		runtime.Gosched()
+		return k, v, false, true
+	}
+	return n.key, n.loadValue(), true, false
+}
+
+// firstLiveFrom returns the entry of the first live node starting at n.
+func (s *ConcurrentSkipList[K, V]) firstLiveFrom(n *cnode[K, V]) (K, V, bool) {
+	n = s.nextLive(n)
+	if n == nil {
+		var nullK K
+		var nullV V
+		return nullK, nullV, false
+	}
+	return n.key, n.loadValue(), true
+}
+
+// nextLive returns the first live node starting at n, or nil if there is none.
+func (s *ConcurrentSkipList[K, V]) nextLive(n *cnode[K, V]) *cnode[K, V] {
+	for n != nil && !n.isLive() {
+		n = n.loadNext(0)
+	}
+	return n
+}
+
+// lockPreds locks each distinct predecessor up to the level provided,
+// stopping early if valid returns false for a predecessor. It returns
+// the number of levels whose predecessors were locked, and whether
+// every predecessor was valid.
+func lockPreds[K, V any](preds *[MaxLevel]*cnode[K, V], level int, valid func(i int, pred *cnode[K, V]) bool) (int, bool) {
+	for i := 0; i < level; i++ {
+		// the same node is often the predecessor at several consecutive levels
+		if i == 0 || preds[i] != preds[i-1] {
+			preds[i].mu.Lock()
+		}
+		if !valid(i, preds[i]) {
+			return i + 1, false
+		}
+	}
+	return level, true
+}
+
+// unlockPreds unlocks each distinct predecessor locked by lockPreds.
+func unlockPreds[K, V any](preds *[MaxLevel]*cnode[K, V], locked int) {
+	for i := 0; i < locked; i++ {
+		if i == 0 || preds[i] != preds[i-1] {
+			preds[i].mu.Unlock()
+		}
+	}
+}
+
+func (n *cnode[K, V]) loadNext(level int) *cnode[K, V] {
+	return (*cnode[K, V])(atomic.LoadPointer(&n.next[level]))
+}
+
+func (n *cnode[K, V]) storeNext(level int, next *cnode[K, V]) {
+	atomic.StorePointer(&n.next[level], unsafe.Pointer(next))
+}
+
+func (n *cnode[K, V]) loadValue() V {
+	return *(*V)(atomic.LoadPointer(&n.value))
+}
+
+func (n *cnode[K, V]) isMarked() bool {
+	return atomic.LoadUint32(&n.marked) == 1
+}
+
+// isLive returns whether the node is fully linked and not removed.
+func (n *cnode[K, V]) isLive() bool {
+	return atomic.LoadUint32(&n.fullyLinked) == 1 && !n.isMarked()
+}
+
+// ConcurrentEntryIterator is a weakly consistent iterator over the entries of a ConcurrentSkipList.
+type ConcurrentEntryIterator[K, V any] struct {
+	list *ConcurrentSkipList[K, V]
+	next *cnode[K, V]
+	last *cnode[K, V]
+}
+
+func (it *ConcurrentEntryIterator[K, V]) HasNext() bool {
+	return it.next != nil
+}
+
+func (it *ConcurrentEntryIterator[K, V]) Next() (K, V) {
+	n := it.next
+	if n == nil {
+		panic(structs.PanicNoSuchElement)
+	}
+
+	it.next = it.list.nextLive(n.loadNext(0))
+	it.last = n
+	return n.key, n.loadValue()
+}
+
+// Remove removes the key last returned by Next from the map,
+// if it has not already been removed.
+//
+// Panics if Next has not been called since the last call to Remove.
+func (it *ConcurrentEntryIterator[K, V]) Remove() {
+	if it.last == nil {
+		panic(structs.PanicIllegalState)
+	}
+	it.list.Delete(it.last.key)
+	it.last = nil
+}
@@ -0,0 +1,169 @@
+package skiplist
+
+import (
+	"golang.org/x/exp/slices"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentSkipList(t *testing.T) {
+	s := NewConcurrentOrdered[int, int]()
+	for _, key := range []int{30, 10, 20} {
+		s.Put(key, key*10)
+	}
+	if s.Put(20, 0) != 200 || s.Get(20) != 0 {
+		t.Error("expected Put to replace the value")
+	}
+	if value, present := s.PutIfAbsent(20, 1); !present || value != 0 {
+		t.Error("expected PutIfAbsent not to replace the value")
+	}
+	if value, ok := s.Delete(10); !ok || value != 100 {
+		t.Errorf("expected Delete to return 100 but got %d", value)
+	}
+	if _, ok := s.Delete(10); ok {
+		t.Error("expected a second Delete to report the key absent")
+	}
+
+	if key, _, _ := s.Floor(25); key != 20 {
+		t.Errorf("expected Floor(25) to be 20 but got %d", key)
+	}
+	if key, _, _ := s.Higher(20); key != 30 {
+		t.Errorf("expected Higher(20) to be 30 but got %d", key)
+	}
+	if _, _, ok := s.Lower(20); ok {
+		t.Error("expected no key lower than 20")
+	}
+	if key, _, _ := s.Last(); key != 30 {
+		t.Errorf("expected last key 30 but got %d", key)
+	}
+	if s.Size() != 2 || !s.ContainsValue(300) {
+		t.Errorf("expected 2 entries including the value 300 but got %d", s.Size())
+	}
+
+	s.Clear()
+	if !s.IsEmpty() {
+		t.Errorf("expected an empty map but got %d entries", s.Size())
+	}
+}
+
+func TestConcurrentSkipList_Parallel(t *testing.T) {
+	const workers = 8
+	const perWorker = 500
+
+	s := NewConcurrentOrdered[int, int]()
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			// each worker puts its own keys, which interleave with the
+			// other workers' keys, and removes every other one
+			for i := 0; i < perWorker; i++ {
+				s.Put(i*workers+w, w)
+				s.Get(i * workers)
+				s.Ceiling(i * workers)
+			}
+			for i := 0; i < perWorker; i += 2 {
+				if _, ok := s.Delete(i*workers + w); !ok {
+					t.Errorf("expected key %d to be present", i*workers+w)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	var keys []int
+	for it := s.EntryIterator(); it.HasNext(); {
+		key, value := it.Next()
+		if value != key%workers {
+			t.Fatalf("expected key %d to map to %d but got %d", key, key%workers, value)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) != workers*perWorker/2 || s.Size() != len(keys) {
+		t.Fatalf("expected %d keys but got %d (size %d)", workers*perWorker/2, len(keys), s.Size())
+	}
+	if !slices.IsSorted(keys) {
+		t.Error("expected keys in sorted order")
+	}
+}
+
+func TestConcurrentSkipList_Contended(t *testing.T) {
+	const workers = 8
+
+	// every worker puts and deletes the same few keys
+	s := NewConcurrentOrdered[int, int]()
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 2000; i++ {
+				key := (i + w) % 4
+				if i%2 == 0 {
+					s.Put(key, w)
+				} else {
+					s.Delete(key)
+				}
+				s.Floor(key)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	count := 0
+	s.Range(func(int, int) bool {
+		count++
+		return true
+	})
+	if count != s.Size() {
+		t.Errorf("expected size %d to match the number of entries %d", s.Size(), count)
+	}
+}
+
+func TestConcurrentSkipList_PutDelete(t *testing.T) {
+	const workers = 8
+	const perWorker = 5000
+
+	// every value put on the key must be returned exactly once, either
+	// as the previous value of a put, by a delete, or as the final value,
+	// so values start at 1 to tell them apart from a missing key
+	s := NewConcurrentOrdered[int, int]()
+	returned := make([][]int, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				var old int
+				if i%2 == 0 {
+					old = s.Put(0, w*perWorker+i+1)
+				} else {
+					old, _ = s.Delete(0)
+				}
+				if old != 0 {
+					returned[w] = append(returned[w], old)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	seen := make(map[int]int)
+	for _, values := range returned {
+		for _, value := range values {
+			seen[value]++
+		}
+	}
+	if value, ok := s.Load(0); ok {
+		seen[value]++
+	}
+	for w := 0; w < workers; w++ {
+		for i := 0; i < perWorker; i += 2 {
+			if count := seen[w*perWorker+i+1]; count != 1 {
+				t.Fatalf("expected value %d to be returned once but it was returned %d times", w*perWorker+i+1, count)
+			}
+		}
+	}
+}
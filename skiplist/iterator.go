@@ -0,0 +1,156 @@
+package skiplist
+
+import "github.com/zytekaron/structs"
+
+type nodeIterator[K, V any] struct {
+	skiplist *SkipList[K, V]
+	next     *node[K, V]
+	last     *node[K, V]
+
+	expectedModCount int
+}
+
+func (it *nodeIterator[K, V]) hasNext() bool {
+	return it.next != nil
+}
+
+func (it *nodeIterator[K, V]) nextNode() *node[K, V] {
+	it.checkModCount()
+	n := it.next
+	if n == nil {
+		panic(structs.PanicNoSuchElement)
+	}
+
+	it.next = n.next[0].node
+	it.last = n
+	return n
+}
+
+func (it *nodeIterator[K, V]) previousNode() *node[K, V] {
+	it.checkModCount()
+	n := it.next
+	if n == nil {
+		panic(structs.PanicNoSuchElement)
+	}
+
+	it.next = n.prev
+	it.last = n
+	return n
+}
+
+func (it *nodeIterator[K, V]) remove() {
+	it.checkModCount()
+	if it.last == nil {
+		panic(structs.PanicIllegalState)
+	}
+
+	it.skiplist.removeKey(it.last.Key)
+	it.last = nil
+	it.expectedModCount = it.skiplist.modCount
+}
+
+// checkModCount panics if the map was modified
+// other than through this iterator.
+func (it *nodeIterator[K, V]) checkModCount() {
+	if it.skiplist.modCount != it.expectedModCount {
+		panic(structs.PanicConcurrentModification)
+	}
+}
+
+type KeyIterator[K, V any] struct {
+	iter *nodeIterator[K, V]
+}
+
+func (it *KeyIterator[K, V]) HasNext() bool {
+	return it.iter.hasNext()
+}
+
+func (it *KeyIterator[K, V]) Next() K {
+	return it.iter.nextNode().Key
+}
+
+func (it *KeyIterator[K, V]) Remove() {
+	it.iter.remove()
+}
+
+type DescendingKeyIterator[K, V any] struct {
+	iter *nodeIterator[K, V]
+}
+
+func (it *DescendingKeyIterator[K, V]) HasNext() bool {
+	return it.iter.hasNext()
+}
+
+func (it *DescendingKeyIterator[K, V]) Next() K {
+	return it.iter.previousNode().Key
+}
+
+func (it *DescendingKeyIterator[K, V]) Remove() {
+	it.iter.remove()
+}
+
+type ValueIterator[K, V any] struct {
+	iter *nodeIterator[K, V]
+}
+
+func (it *ValueIterator[K, V]) HasNext() bool {
+	return it.iter.hasNext()
+}
+
+func (it *ValueIterator[K, V]) Next() V {
+	return it.iter.nextNode().Value
+}
+
+func (it *ValueIterator[K, V]) Remove() {
+	it.iter.remove()
+}
+
+type DescendingValueIterator[K, V any] struct {
+	iter *nodeIterator[K, V]
+}
+
+func (it *DescendingValueIterator[K, V]) HasNext() bool {
+	return it.iter.hasNext()
+}
+
+func (it *DescendingValueIterator[K, V]) Next() V {
+	return it.iter.previousNode().Value
+}
+
+func (it *DescendingValueIterator[K, V]) Remove() {
+	it.iter.remove()
+}
+
+type EntryIterator[K, V any] struct {
+	iter *nodeIterator[K, V]
+}
+
+func (it *EntryIterator[K, V]) HasNext() bool {
+	return it.iter.hasNext()
+}
+
+func (it *EntryIterator[K, V]) Next() (K, V) {
+	next := it.iter.nextNode()
+	return next.Key, next.Value
+}
+
+func (it *EntryIterator[K, V]) Remove() {
+	it.iter.remove()
+}
+
+type DescendingEntryIterator[K, V any] struct {
+	iter *nodeIterator[K, V]
+}
+
+func (it *DescendingEntryIterator[K, V]) HasNext() bool {
+	return it.iter.hasNext()
+}
+
+func (it *DescendingEntryIterator[K, V]) Next() (K, V) {
+	prev := it.iter.previousNode()
+	return prev.Key, prev.Value
+}
+
+func (it *DescendingEntryIterator[K, V]) Remove() {
+	it.iter.remove()
+}
@@ -0,0 +1,278 @@
+package skiplist
+
+import (
+	"fmt"
+	"github.com/zytekaron/structs"
+	"golang.org/x/exp/constraints"
+	"strings"
+)
+
+// Set is an ordered set backed by a SkipList.
+//
+// Set is not safe for concurrent use.
+type Set[V any] struct {
+	list *SkipList[V, struct{}]
+}
+
+var _ structs.Set[int] = (*Set[int])(nil)
+
+// NewSet creates an empty Set ordered by a comparator.
+func NewSet[V any](cmp structs.CompareFunc[V]) *Set[V] {
+	return &Set[V]{
+		list: New[V, struct{}](cmp, nil),
+	}
+}
+
+// NewOrderedSet creates an empty Set from a type that implements constraints.Ordered.
+func NewOrderedSet[V constraints.Ordered]() *Set[V] {
+	return NewSet(structs.CompareOrdered[V])
+}
+
+// SetOf creates a Set from an existing slice.
+func SetOf[V any](cmp structs.CompareFunc[V], values ...V) *Set[V] {
+	set := NewSet(cmp)
+	for _, value := range values {
+		set.Add(value)
+	}
+	return set
+}
+
+// OrderedSetOf creates a Set from an existing slice of a type that implements constraints.Ordered.
+func OrderedSetOf[V constraints.Ordered](values ...V) *Set[V] {
+	return SetOf(structs.CompareOrdered[V], values...)
+}
+
+// Add adds a value to the set and returns whether it was not already present.
+//
+// Time Complexity: O(logn)
+func (s *Set[V]) Add(value V) bool {
+	if s.list.ContainsKey(value) {
+		return false
+	}
+	s.list.Put(value, struct{}{})
+	return true
+}
+
+// AddAll adds all the values in the other collection to the set.
+//
+// Time Complexity: O(mlogn)
+func (s *Set[V]) AddAll(other structs.Collection[V]) bool {
+	return s.AddIterator(other.Iterator())
+}
+
+// AddIterator adds all the values in the iterator to the set.
+//
+// Time Complexity: O(mlogn)
+func (s *Set[V]) AddIterator(iter structs.Iterator[V]) bool {
+	changed := false
+	for iter.HasNext() {
+		if s.Add(iter.Next()) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// At returns the value at the index provided in sorted order.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(logn)
+func (s *Set[V]) At(index int) V {
+	value, _ := s.list.At(index)
+	return value
+}
+
+// Ceiling returns the lowest value greater than or equal to the
+// value provided, and false if there is no such value.
+//
+// Time Complexity: O(logn)
+func (s *Set[V]) Ceiling(value V) (V, bool) {
+	ceiling, _, ok := s.list.Ceiling(value)
+	return ceiling, ok
+}
+
+// Clear clears the set.
+//
+// Time Complexity: O(1)
+func (s *Set[V]) Clear() {
+	s.list.Clear()
+}
+
+// Contains returns whether the value is present in the set.
+//
+// Time Complexity: O(logn)
+func (s *Set[V]) Contains(value V) bool {
+	return s.list.ContainsKey(value)
+}
+
+// ContainsAll returns whether all the values in the other collection are present in the set.
+//
+// Time Complexity: O(mlogn)
+func (s *Set[V]) ContainsAll(other structs.Collection[V]) bool {
+	return s.ContainsIterator(other.Iterator())
+}
+
+// ContainsIterator returns whether all the values in the iterator are present in the set.
+//
+// Time Complexity: O(mlogn)
+func (s *Set[V]) ContainsIterator(iter structs.Iterator[V]) bool {
+	for iter.HasNext() {
+		if !s.Contains(iter.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+// DescendingIterator returns an Iterator over the set from the highest value to the lowest.
+func (s *Set[V]) DescendingIterator() structs.Iterator[V] {
+	return s.list.DescendingKeyIterator()
+}
+
+// First returns the lowest value in the set, and false if the set is empty.
+//
+// Time Complexity: O(1)
+func (s *Set[V]) First() (V, bool) {
+	first, _, ok := s.list.First()
+	return first, ok
+}
+
+// Floor returns the highest value less than or equal to the
+// value provided, and false if there is no such value.
+//
+// Time Complexity: O(logn)
+func (s *Set[V]) Floor(value V) (V, bool) {
+	floor, _, ok := s.list.Floor(value)
+	return floor, ok
+}
+
+// Higher returns the lowest value strictly greater than the
+// value provided, and false if there is no such value.
+//
+// Time Complexity: O(logn)
+func (s *Set[V]) Higher(value V) (V, bool) {
+	higher, _, ok := s.list.Higher(value)
+	return higher, ok
+}
+
+// IsEmpty returns whether the set is empty.
+func (s *Set[V]) IsEmpty() bool {
+	return s.list.IsEmpty()
+}
+
+// Iterator returns an Iterator over the set from the lowest value to the highest.
+func (s *Set[V]) Iterator() structs.Iterator[V] {
+	return s.list.KeyIterator()
+}
+
+// IteratorFrom returns an Iterator over the set starting at the
+// lowest value greater than or equal to the value provided.
+func (s *Set[V]) IteratorFrom(value V) structs.Iterator[V] {
+	return &KeyIterator[V, struct{}]{
+		iter: s.list.EntryIteratorFrom(value).iter,
+	}
+}
+
+// Last returns the highest value in the set, and false if the set is empty.
+//
+// Time Complexity: O(1)
+func (s *Set[V]) Last() (V, bool) {
+	last, _, ok := s.list.Last()
+	return last, ok
+}
+
+// Lower returns the highest value strictly less than the
+// value provided, and false if there is no such value.
+//
+// Time Complexity: O(logn)
+func (s *Set[V]) Lower(value V) (V, bool) {
+	lower, _, ok := s.list.Lower(value)
+	return lower, ok
+}
+
+// Rank returns the number of values in the set which are less than the
+// value provided. If the value is present, this is its index in sorted order.
+//
+// Time Complexity: O(logn)
+func (s *Set[V]) Rank(value V) int {
+	return s.list.Rank(value)
+}
+
+// Remove removes a value from the set and returns whether it was present.
+//
+// Time Complexity: O(logn)
+func (s *Set[V]) Remove(value V) bool {
+	return s.list.removeKey(value) != nil
+}
+
+// RemoveAll removes all the values in the other collection from the set.
+//
+// Time Complexity: O(mlogn)
+func (s *Set[V]) RemoveAll(other structs.Collection[V]) bool {
+	return s.RemoveIterator(other.Iterator())
+}
+
+// RemoveIterator removes all the values in the iterator from the set.
+//
+// Time Complexity: O(mlogn)
+func (s *Set[V]) RemoveIterator(iter structs.Iterator[V]) bool {
+	changed := false
+	for iter.HasNext() {
+		if s.Remove(iter.Next()) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// RetainAll removes all the values not present in the other collection from the set.
+//
+// Time Complexity: O(n(m+logn))
+//
+//	n = size of the set
+//	m = time complexity of Contains on the other collection
+func (s *Set[V]) RetainAll(other structs.Collection[V]) bool {
+	changed := false
+	for it := s.list.KeyIterator(); it.HasNext(); {
+		if !other.Contains(it.Next()) {
+			it.Remove()
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Size returns the number of values in the set.
+//
+// Time Complexity: O(1)
+func (s *Set[V]) Size() int {
+	return s.list.Size()
+}
+
+// String returns a string representation of the set, with brackets and comma separated.
+func (s *Set[V]) String() string {
+	var buf strings.Builder
+	buf.WriteRune('[')
+	for it := s.list.KeyIterator(); it.HasNext(); {
+		if buf.Len() > 1 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(fmt.Sprint(it.Next()))
+	}
+	buf.WriteRune(']')
+	return buf.String()
+}
+
+// Values returns a slice of the values in the set in sorted order.
+//
+// Time Complexity: O(n)
+//
+// Space Complexity: O(n)
+func (s *Set[V]) Values() []V {
+	values := make([]V, 0, s.list.Size())
+	for it := s.list.KeyIterator(); it.HasNext(); {
+		values = append(values, it.Next())
+	}
+	return values
+}
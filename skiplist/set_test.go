@@ -0,0 +1,42 @@
+package skiplist
+
+import (
+	"github.com/zytekaron/structs/internal/listtest"
+	"github.com/zytekaron/structs/wrap"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	s := OrderedSetOf(5, 1, 4, 2, 3)
+	if s.Add(3) || !s.Add(6) {
+		t.Error("expected Add to report whether the value was added")
+	}
+	listtest.ExpectValues(t, s.Values(), []int{1, 2, 3, 4, 5, 6})
+	if s.String() != "[1, 2, 3, 4, 5, 6]" {
+		t.Errorf("expected string [1, 2, 3, 4, 5, 6] but got %s", s.String())
+	}
+
+	if !s.RetainAll(wrap.OrderedValues(2, 4, 6, 8)) {
+		t.Error("expected RetainAll to report a change")
+	}
+	listtest.ExpectValues(t, s.Values(), []int{2, 4, 6})
+
+	if s.Remove(3) || !s.Remove(4) {
+		t.Error("expected Remove to report whether the value was present")
+	}
+	if floor, ok := s.Floor(5); !ok || floor != 2 {
+		t.Errorf("expected Floor(5) to be 2 but got %d", floor)
+	}
+	if ceiling, ok := s.Ceiling(5); !ok || ceiling != 6 {
+		t.Errorf("expected Ceiling(5) to be 6 but got %d", ceiling)
+	}
+	if s.At(1) != 6 || s.Rank(6) != 1 {
+		t.Errorf("expected 6 at index 1 but got %d", s.At(1))
+	}
+
+	var got []int
+	for it := s.DescendingIterator(); it.HasNext(); {
+		got = append(got, it.Next())
+	}
+	listtest.ExpectValues(t, got, []int{6, 2})
+}
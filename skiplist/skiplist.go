@@ -0,0 +1,418 @@
+// Package skiplist implements ordered maps and sets backed by skip lists.
+package skiplist
+
+import (
+	"github.com/zytekaron/structs"
+	"golang.org/x/exp/constraints"
+	"math/bits"
+	"math/rand"
+)
+
+// MaxLevel is the maximum number of levels of a skip list.
+// With a promotion probability of 1/4 per level, it is enough
+// for far more entries than can fit in memory.
+const MaxLevel = 32
+
+// SkipList is an ordered map implemented as an indexable skip list.
+//
+// Each link between nodes records its span, the number of entries it
+// skips over, so the rank of a key and the entry at an index can be
+// found in O(logn) like any other lookup.
+//
+// The key comparison function must be provided to
+// use most methods, but the value comparison
+// function may be omitted (nil) if no methods that
+// are called depend on value equality (ie ContainsValue).
+//
+// SkipList is not safe for concurrent use. See ConcurrentSkipList.
+type SkipList[K, V any] struct {
+	keyCmp structs.CompareFunc[K]
+	valEq  structs.EqualFunc[V]
+	head   *node[K, V] // sentinel, with MaxLevel links
+	tail   *node[K, V]
+	level  int // number of levels in use
+	size   int
+	// modCount is incremented whenever the map is structurally
+	// modified, which allows iterators to detect concurrent changes.
+	modCount int
+}
+
+// node is an entry in a SkipList.
+type node[K, V any] struct {
+	Key   K
+	Value V
+	prev  *node[K, V] // previous node at level 0, or nil if first
+	next  []link[K, V]
+}
+
+// link is a forward link at one level of a node.
+type link[K, V any] struct {
+	node *node[K, V]
+	// span is the number of level 0 steps from the node owning
+	// the link to the linked node. when the link is nil, it is
+	// the number of steps to the end of the list.
+	span int
+}
+
+var _ structs.Map[int, int] = (*SkipList[int, int])(nil)
+
+func New[K, V any](keyCompare structs.CompareFunc[K], valueEqual structs.EqualFunc[V]) *SkipList[K, V] {
+	return &SkipList[K, V]{
+		keyCmp: keyCompare,
+		valEq:  valueEqual,
+		head:   &node[K, V]{next: make([]link[K, V], MaxLevel)},
+		level:  1,
+	}
+}
+
+func NewOrdered[K, V constraints.Ordered]() *SkipList[K, V] {
+	return New[K, V](structs.CompareOrdered[K], structs.EqualOrdered[V])
+}
+
+func NewOrderedKeys[K constraints.Ordered, V any](valueEqual structs.EqualFunc[V]) *SkipList[K, V] {
+	return New[K, V](structs.CompareOrdered[K], valueEqual)
+}
+
+func NewOrderedValues[K any, V constraints.Ordered](keyCompare structs.CompareFunc[K]) *SkipList[K, V] {
+	return New[K, V](keyCompare, structs.EqualOrdered[V])
+}
+
+func (s *SkipList[K, V]) ContainsKey(key K) bool {
+	return s.getNode(key) != nil
+}
+
+func (s *SkipList[K, V]) ContainsValue(value V) bool {
+	for n := s.head.next[0].node; n != nil; n = n.next[0].node {
+		if s.valEq(value, n.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SkipList[K, V]) Get(key K) V {
+	n := s.getNode(key)
+	if n == nil {
+		var null V
+		return null
+	}
+	return n.Value
+}
+
+func (s *SkipList[K, V]) Put(key K, value V) V {
+	var update [MaxLevel]*node[K, V]
+	var rank [MaxLevel]int // rank of update[i]
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		if i < s.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.next[i].node != nil && s.keyCmp(x.next[i].node.Key, key) < 0 {
+			rank[i] += x.next[i].span
+			x = x.next[i].node
+		}
+		update[i] = x
+	}
+
+	if next := x.next[0].node; next != nil && s.keyCmp(next.Key, key) == 0 {
+		oldValue := next.Value
+		next.Key = key
+		next.Value = value
+		return oldValue
+	}
+
+	level := randomLevel()
+	for i := s.level; i < level; i++ {
+		rank[i] = 0
+		update[i] = s.head
+		update[i].next[i].span = s.size
+	}
+	if level > s.level {
+		s.level = level
+	}
+
+	n := &node[K, V]{
+		Key:   key,
+		Value: value,
+		next:  make([]link[K, V], level),
+	}
+	for i := 0; i < level; i++ {
+		prev := &update[i].next[i]
+		n.next[i] = link[K, V]{prev.node, prev.span - (rank[0] - rank[i])}
+		*prev = link[K, V]{n, rank[0] - rank[i] + 1}
+	}
+	// links above the new node's levels now skip over it
+	for i := level; i < s.level; i++ {
+		update[i].next[i].span++
+	}
+
+	if update[0] != s.head {
+		n.prev = update[0]
+	}
+	if next := n.next[0].node; next != nil {
+		next.prev = n
+	} else {
+		s.tail = n
+	}
+	s.size++
+	s.modCount++
+	var null V
+	return null
+}
+
+func (s *SkipList[K, V]) Remove(key K) V {
+	n := s.removeKey(key)
+	if n == nil {
+		var null V
+		return null
+	}
+	return n.Value
+}
+
+func (s *SkipList[K, V]) Size() int {
+	return s.size
+}
+
+func (s *SkipList[K, V]) IsEmpty() bool {
+	return s.size == 0
+}
+
+func (s *SkipList[K, V]) Clear() {
+	s.head = &node[K, V]{next: make([]link[K, V], MaxLevel)}
+	s.tail = nil
+	s.level = 1
+	s.size = 0
+	s.modCount++
+}
+
+// First returns the entry with the lowest key, and false if the map is empty.
+//
+// Time Complexity: O(1)
+func (s *SkipList[K, V]) First() (K, V, bool) {
+	return entry(s.head.next[0].node)
+}
+
+// Last returns the entry with the highest key, and false if the map is empty.
+//
+// Time Complexity: O(1)
+func (s *SkipList[K, V]) Last() (K, V, bool) {
+	return entry(s.tail)
+}
+
+// Floor returns the entry with the highest key less than or equal
+// to the key provided, and false if there is no such entry.
+//
+// Time Complexity: O(logn)
+func (s *SkipList[K, V]) Floor(key K) (K, V, bool) {
+	x := s.findLast(key, true)
+	if x == s.head {
+		return entry[K, V](nil)
+	}
+	return entry(x)
+}
+
+// Lower returns the entry with the highest key strictly less than
+// the key provided, and false if there is no such entry.
+//
+// Time Complexity: O(logn)
+func (s *SkipList[K, V]) Lower(key K) (K, V, bool) {
+	x := s.findLast(key, false)
+	if x == s.head {
+		return entry[K, V](nil)
+	}
+	return entry(x)
+}
+
+// Ceiling returns the entry with the lowest key greater than or equal
+// to the key provided, and false if there is no such entry.
+//
+// Time Complexity: O(logn)
+func (s *SkipList[K, V]) Ceiling(key K) (K, V, bool) {
+	return entry(s.findLast(key, false).next[0].node)
+}
+
+// Higher returns the entry with the lowest key strictly greater than
+// the key provided, and false if there is no such entry.
+//
+// Time Complexity: O(logn)
+func (s *SkipList[K, V]) Higher(key K) (K, V, bool) {
+	return entry(s.findLast(key, true).next[0].node)
+}
+
+// Rank returns the number of keys in the map which are less than the
+// key provided. If the key is present, this is its index in key order.
+//
+// Time Complexity: O(logn)
+func (s *SkipList[K, V]) Rank(key K) int {
+	rank := 0
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i].node != nil && s.keyCmp(x.next[i].node.Key, key) < 0 {
+			rank += x.next[i].span
+			x = x.next[i].node
+		}
+	}
+	return rank
+}
+
+// At returns the entry at the index provided in key order.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(logn)
+func (s *SkipList[K, V]) At(index int) (K, V) {
+	if index < 0 || index >= s.size {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+
+	// node ranks start at 1, since the head is at rank 0
+	target := index + 1
+	rank := 0
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i].node != nil && rank+x.next[i].span <= target {
+			rank += x.next[i].span
+			x = x.next[i].node
+		}
+		if rank == target {
+			break
+		}
+	}
+	return x.Key, x.Value
+}
+
+func (s *SkipList[K, V]) EntryIterator() *EntryIterator[K, V] {
+	return &EntryIterator[K, V]{
+		iter: s.nodeIteratorAt(s.head.next[0].node),
+	}
+}
+
+// EntryIteratorFrom returns an EntryIterator starting at the
+// lowest key greater than or equal to the key provided.
+func (s *SkipList[K, V]) EntryIteratorFrom(key K) *EntryIterator[K, V] {
+	return &EntryIterator[K, V]{
+		iter: s.nodeIteratorAt(s.findLast(key, false).next[0].node),
+	}
+}
+
+func (s *SkipList[K, V]) DescendingEntryIterator() *DescendingEntryIterator[K, V] {
+	return &DescendingEntryIterator[K, V]{
+		iter: s.nodeIteratorAt(s.tail),
+	}
+}
+
+func (s *SkipList[K, V]) KeyIterator() *KeyIterator[K, V] {
+	return &KeyIterator[K, V]{
+		iter: s.nodeIteratorAt(s.head.next[0].node),
+	}
+}
+
+func (s *SkipList[K, V]) DescendingKeyIterator() *DescendingKeyIterator[K, V] {
+	return &DescendingKeyIterator[K, V]{
+		iter: s.nodeIteratorAt(s.tail),
+	}
+}
+
+func (s *SkipList[K, V]) Iterator() *ValueIterator[K, V] {
+	return &ValueIterator[K, V]{
+		iter: s.nodeIteratorAt(s.head.next[0].node),
+	}
+}
+
+func (s *SkipList[K, V]) DescendingIterator() *DescendingValueIterator[K, V] {
+	return &DescendingValueIterator[K, V]{
+		iter: s.nodeIteratorAt(s.tail),
+	}
+}
+
+// getNode returns the node with the key, or nil if there is none.
+func (s *SkipList[K, V]) getNode(key K) *node[K, V] {
+	n := s.findLast(key, false).next[0].node
+	if n != nil && s.keyCmp(n.Key, key) == 0 {
+		return n
+	}
+	return nil
+}
+
+// findLast returns the last node whose key is less than the key
+// provided, or less than or equal to it if inclusive is true.
+// If there is no such node, it returns the head.
+func (s *SkipList[K, V]) findLast(key K, inclusive bool) *node[K, V] {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i].node != nil {
+			cmp := s.keyCmp(x.next[i].node.Key, key)
+			if cmp > 0 || (cmp == 0 && !inclusive) {
+				break
+			}
+			x = x.next[i].node
+		}
+	}
+	return x
+}
+
+// removeKey removes and returns the node with the key, or returns nil if there is none.
+func (s *SkipList[K, V]) removeKey(key K) *node[K, V] {
+	var update [MaxLevel]*node[K, V]
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i].node != nil && s.keyCmp(x.next[i].node.Key, key) < 0 {
+			x = x.next[i].node
+		}
+		update[i] = x
+	}
+
+	n := x.next[0].node
+	if n == nil || s.keyCmp(n.Key, key) != 0 {
+		return nil
+	}
+
+	for i := 0; i < s.level; i++ {
+		prev := &update[i].next[i]
+		if prev.node == n {
+			*prev = link[K, V]{n.next[i].node, prev.span + n.next[i].span - 1}
+		} else {
+			prev.span--
+		}
+	}
+	if next := n.next[0].node; next != nil {
+		next.prev = n.prev
+	} else {
+		s.tail = n.prev
+	}
+	for s.level > 1 && s.head.next[s.level-1].node == nil {
+		s.level--
+	}
+	s.size--
+	s.modCount++
+	return n
+}
+
+func (s *SkipList[K, V]) nodeIteratorAt(n *node[K, V]) *nodeIterator[K, V] {
+	return &nodeIterator[K, V]{
+		skiplist:         s,
+		next:             n,
+		expectedModCount: s.modCount,
+	}
+}
+
+// entry returns the key and value of a node, and whether it is not nil.
+func entry[K, V any](n *node[K, V]) (K, V, bool) {
+	if n == nil {
+		var nullK K
+		var nullV V
+		return nullK, nullV, false
+	}
+	return n.Key, n.Value, true
+}
+
+// randomLevel returns a random level for a new node,
+// promoting it to each further level with probability 1/4.
+func randomLevel() int {
+	// each pair of trailing zero bits is a promotion
+	level := bits.TrailingZeros64(rand.Uint64())/2 + 1
+	if level > MaxLevel {
+		return MaxLevel
+	}
+	return level
+}
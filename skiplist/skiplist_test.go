@@ -0,0 +1,185 @@
+package skiplist
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/listtest"
+	"golang.org/x/exp/slices"
+	"math/rand"
+	"testing"
+)
+
+func TestSkipList(t *testing.T) {
+	s := NewOrdered[string, string]()
+	var _ structs.Map[string, string] = s
+
+	s.Put("hello", "world")
+	s.Put("foo", "bar")
+	s.Put("removed", "woop")
+	if s.Remove("removed") != "woop" {
+		t.Error("removed wasn't removed")
+	}
+	if s.Put("foo", "baz") != "bar" {
+		t.Error("expected Put to return the replaced value")
+	}
+
+	if s.Size() != 2 {
+		t.Error("size not 2, got", s.Size())
+	}
+	if got := s.Get("foo"); got != "baz" {
+		t.Errorf("expected 'foo' to map to 'baz', got '%s'", got)
+	}
+	if s.ContainsKey("removed") || !s.ContainsValue("world") {
+		t.Error("expected 'removed' to be absent and 'world' to be present")
+	}
+}
+
+func TestSkipList_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := NewOrdered[int, int]()
+	model := map[int]int{}
+	for i := 0; i < 5000; i++ {
+		key := r.Intn(500)
+		if r.Intn(3) == 0 {
+			if got, expect := s.Remove(key), model[key]; got != expect {
+				t.Fatalf("expected Remove(%d) to return %d but got %d", key, expect, got)
+			}
+			delete(model, key)
+		} else {
+			if got, expect := s.Put(key, i), model[key]; got != expect {
+				t.Fatalf("expected Put(%d) to return %d but got %d", key, expect, got)
+			}
+			model[key] = i
+		}
+	}
+
+	var keys []int
+	for key := range model {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	if s.Size() != len(keys) {
+		t.Fatalf("expected size %d but got %d", len(keys), s.Size())
+	}
+	for index, key := range keys {
+		if rank := s.Rank(key); rank != index {
+			t.Fatalf("expected Rank(%d) to be %d but got %d", key, index, rank)
+		}
+		if k, v := s.At(index); k != key || v != model[key] {
+			t.Fatalf("expected At(%d) to be %d=%d but got %d=%d", index, key, model[key], k, v)
+		}
+	}
+
+	var got []int
+	for it := s.KeyIterator(); it.HasNext(); {
+		got = append(got, it.Next())
+	}
+	if !slices.Equal(got, keys) {
+		t.Errorf("expected keys %v but got %v", keys, got)
+	}
+}
+
+func TestSkipList_Navigation(t *testing.T) {
+	s := NewOrdered[int, string]()
+	for _, key := range []int{10, 20, 30} {
+		s.Put(key, "")
+	}
+
+	tests := []struct {
+		name   string
+		f      func(int) (int, string, bool)
+		key    int
+		expect int
+		ok     bool
+	}{
+		{"Floor", s.Floor, 20, 20, true},
+		{"Floor", s.Floor, 25, 20, true},
+		{"Floor", s.Floor, 5, 0, false},
+		{"Lower", s.Lower, 20, 10, true},
+		{"Lower", s.Lower, 10, 0, false},
+		{"Ceiling", s.Ceiling, 20, 20, true},
+		{"Ceiling", s.Ceiling, 25, 30, true},
+		{"Ceiling", s.Ceiling, 35, 0, false},
+		{"Higher", s.Higher, 20, 30, true},
+		{"Higher", s.Higher, 30, 0, false},
+	}
+	for _, test := range tests {
+		key, _, ok := test.f(test.key)
+		if key != test.expect || ok != test.ok {
+			t.Errorf("expected %s(%d) to return %d, %t but got %d, %t", test.name, test.key, test.expect, test.ok, key, ok)
+		}
+	}
+
+	if first, _, _ := s.First(); first != 10 {
+		t.Errorf("expected first key 10 but got %d", first)
+	}
+	if last, _, _ := s.Last(); last != 30 {
+		t.Errorf("expected last key 30 but got %d", last)
+	}
+	if rank := s.Rank(25); rank != 2 {
+		t.Errorf("expected Rank(25) to be 2 but got %d", rank)
+	}
+}
+
+func TestSkipList_Iterators(t *testing.T) {
+	s := NewOrdered[int, int]()
+	for _, key := range []int{5, 2, 8, 1, 9, 3} {
+		s.Put(key, key*10)
+	}
+
+	var got []int
+	for it := s.DescendingKeyIterator(); it.HasNext(); {
+		got = append(got, it.Next())
+	}
+	listtest.ExpectValues(t, got, []int{9, 8, 5, 3, 2, 1})
+
+	got = nil
+	for it := s.EntryIteratorFrom(4); it.HasNext(); {
+		key, value := it.Next()
+		got = append(got, key, value)
+	}
+	listtest.ExpectValues(t, got, []int{5, 50, 8, 80, 9, 90})
+
+	// remove the even keys while iterating
+	for it := s.Iterator(); it.HasNext(); {
+		if it.Next()%20 == 0 {
+			it.Remove()
+		}
+	}
+	got = nil
+	for it := s.DescendingIterator(); it.HasNext(); {
+		got = append(got, it.Next())
+	}
+	listtest.ExpectValues(t, got, []int{90, 50, 30, 10})
+	if k, _ := s.At(3); k != 9 || s.Rank(9) != 3 {
+		t.Errorf("expected key 9 at index 3 but got %d", k)
+	}
+}
+
+func TestSkipList_ConcurrentModification(t *testing.T) {
+	defer func() {
+		if r := recover(); r != structs.PanicConcurrentModification {
+			t.Errorf("expected panic %q but got %v", structs.PanicConcurrentModification, r)
+		}
+	}()
+
+	s := NewOrdered[int, int]()
+	s.Put(1, 1)
+	s.Put(2, 2)
+
+	it := s.KeyIterator()
+	it.Next()
+	s.Put(3, 3)
+	it.Next()
+}
+
+func TestSkipList_AtOutOfBounds(t *testing.T) {
+	defer func() {
+		if r := recover(); r != structs.PanicIndexOutOfBounds {
+			t.Errorf("expected panic %q but got %v", structs.PanicIndexOutOfBounds, r)
+		}
+	}()
+
+	s := NewOrdered[int, int]()
+	s.Put(1, 1)
+	s.At(1)
+}
@@ -2,124 +2,401 @@ package stack
 
 import (
 	"github.com/zytekaron/structs"
-	"github.com/zytekaron/structs/list"
 	"golang.org/x/exp/constraints"
 )
 
+// Stack is an implementation of a last-in-first-out stack
+// backed by a slice which grows as needed.
+//
+// As a structs.Queue, the head of the stack is its top, so
+// Offer pushes a value and Poll pops one. Iteration and Values
+// proceed from the top of the stack to the bottom.
+//
+// The value equality function may be omitted (nil)
+// if no methods are called which use it.
 type Stack[V any] struct {
-	data *list.List[V]
+	capFn    structs.CapacityFunc
+	shrinkFn structs.CapacityFunc // nil to never shrink
+	eq       structs.EqualFunc[V]
+	data     []V // the top of the stack is data[size-1]
+	size     int
+	// modCount is incremented whenever the stack is structurally
+	// modified, which allows iterators to detect concurrent changes.
+	modCount int
 }
 
+var _ structs.Queue[int] = (*Stack[int])(nil)
+
+// New creates an empty Stack.
 func New[V any](eq structs.EqualFunc[V]) *Stack[V] {
-	return &Stack[V]{
-		data: list.New[V](eq),
-	}
+	return NewCap(0, eq)
 }
 
+// NewOrdered creates an empty Stack from a type that implements constraints.Ordered.
 func NewOrdered[V constraints.Ordered]() *Stack[V] {
+	return NewCap(0, structs.EqualOrdered[V])
+}
+
+// NewCap creates an empty Stack with an initial capacity.
+func NewCap[V any](capacity int, eq structs.EqualFunc[V]) *Stack[V] {
 	return &Stack[V]{
-		data: list.NewOrdered[V](),
+		capFn: structs.DoubleCapacity,
+		eq:    eq,
+		data:  make([]V, capacity),
 	}
 }
 
+// NewOrderedCap creates an empty Stack with an initial
+// capacity from a type that implements constraints.Ordered.
+func NewOrderedCap[V constraints.Ordered](capacity int) *Stack[V] {
+	return NewCap(capacity, structs.EqualOrdered[V])
+}
+
+// From creates a Stack by pushing the values in
+// order, so the last value is on top of the stack.
 func From[V any](eq structs.EqualFunc[V], values ...V) *Stack[V] {
-	return &Stack[V]{
-		data: list.Of(eq, values...),
-	}
+	s := NewCap(len(values), eq)
+	copy(s.data, values)
+	s.size = len(values)
+	return s
 }
 
+// FromOrdered creates a Stack by pushing the values in order, so the last
+// value is on top of the stack, from a type that implements constraints.Ordered.
 func FromOrdered[V constraints.Ordered](values ...V) *Stack[V] {
-	return &Stack[V]{
-		data: list.OfOrdered(values...),
-	}
+	return From(structs.EqualOrdered[V], values...)
+}
+
+// SetCapFunc sets the function used to increase the capacity of the stack.
+func (s *Stack[V]) SetCapFunc(capFn structs.CapacityFunc) {
+	s.capFn = capFn
 }
 
+// SetShrinkFunc sets the function used to decrease the capacity of
+// the stack after values are removed, for example structs.HalveCapacity.
+// If it is nil, which is the default, the stack never shrinks.
+func (s *Stack[V]) SetShrinkFunc(shrinkFn structs.CapacityFunc) {
+	s.shrinkFn = shrinkFn
+}
+
+// Add pushes a value onto the top of the stack. It always returns true.
+//
+// Time Complexity: amortized O(1)
 func (s *Stack[V]) Add(value V) bool {
-	return s.data.Add(value)
+	s.Push(value)
+	return true
 }
 
+// AddAll pushes all the values in the other collection onto the stack,
+// in iteration order, so the last value iterated is on top.
+//
+// Time Complexity: O(m)
 func (s *Stack[V]) AddAll(other structs.Collection[V]) bool {
-	return s.data.AddAll(other)
+	s.grow(s.size + other.Size())
+	return s.AddIterator(other.Iterator())
+}
+
+// AddIterator pushes all the values in the iterator onto the
+// stack, in order, so the last value iterated is on top.
+//
+// Time Complexity: O(m)
+func (s *Stack[V]) AddIterator(iter structs.Iterator[V]) bool {
+	changed := iter.HasNext()
+	for iter.HasNext() {
+		s.Push(iter.Next())
+	}
+	return changed
 }
 
-func (s *Stack[V]) AddIterator(iterator structs.Iterator[V]) bool {
-	return s.data.AddIterator(iterator)
+// Cap returns the capacity of the stack.
+func (s *Stack[V]) Cap() int {
+	return len(s.data)
 }
 
+// Clear clears the stack. Any references held by
+// the stack are released, so they may be garbage collected.
+//
+// Time Complexity: O(n)
 func (s *Stack[V]) Clear() {
-	s.data.Clear()
+	var null V
+	for i := 0; i < s.size; i++ {
+		s.data[i] = null
+	}
+	s.size = 0
+	s.modCount++
+	s.shrink()
 }
 
+// Contains returns whether the value is present in the stack.
+//
+// Time Complexity: O(n)
 func (s *Stack[V]) Contains(value V) bool {
-	return s.data.Contains(value)
+	return s.indexOf(value) >= 0
 }
 
+// ContainsAll returns whether all the values in the other collection are present in the stack.
+//
+// Time Complexity: O(nm)
 func (s *Stack[V]) ContainsAll(other structs.Collection[V]) bool {
-	return s.data.ContainsAll(other)
+	return s.ContainsIterator(other.Iterator())
+}
+
+// ContainsIterator returns whether all the values in the iterator are present in the stack.
+//
+// Time Complexity: O(nm)
+func (s *Stack[V]) ContainsIterator(iter structs.Iterator[V]) bool {
+	for iter.HasNext() {
+		if !s.Contains(iter.Next()) {
+			return false
+		}
+	}
+	return true
 }
 
+// Element returns the value on top of the stack.
+//
+// Panics if the stack is empty.
+//
+// Time Complexity: O(1)
 func (s *Stack[V]) Element() V {
-	return s.data.Get(0)
+	s.checkEmpty()
+
+	return s.data[s.size-1]
 }
 
+// IsEmpty returns whether the stack is empty.
 func (s *Stack[V]) IsEmpty() bool {
-	return s.data.IsEmpty()
+	return s.size == 0
 }
 
-type Iterator[V any] struct {
-	iter structs.Iterator[V]
+// Iterator returns an Iterator over the stack,
+// starting at the top and ending at the bottom.
+func (s *Stack[V]) Iterator() structs.Iterator[V] {
+	return &Iterator[V]{
+		stack:            s,
+		next:             s.size - 1,
+		last:             -1,
+		expectedModCount: s.modCount,
+	}
 }
 
-func (i *Iterator[V]) HasNext() bool {
-	return i.iter.HasNext()
+// Offer pushes a value onto the top of the stack. It always returns true.
+//
+// Time Complexity: amortized O(1)
+func (s *Stack[V]) Offer(value V) bool {
+	s.Push(value)
+	return true
 }
 
-func (i *Iterator[V]) Next() V {
-	return i.iter.Next()
+// Peek returns the value on top of the stack, or
+// the zero value of the type if the stack is empty.
+//
+// Time Complexity: O(1)
+func (s *Stack[V]) Peek() V {
+	value, _ := s.TryPeek()
+	return value
 }
 
-func (i *Iterator[V]) Remove() {
-	i.iter.Remove()
+// Poll removes and returns the value on top of the stack,
+// or returns the zero value of the type if the stack is empty.
+//
+// Time Complexity: O(1)
+func (s *Stack[V]) Poll() V {
+	value, _ := s.TryPop()
+	return value
 }
 
-func (s *Stack[V]) Iterator() structs.Iterator[V] {
-	return &Iterator[V]{
-		iter: s.data.Iterator(),
-	}
-}
+// Pop removes and returns the value on top of the stack.
+//
+// Panics if the stack is empty.
+//
+// Time Complexity: O(1)
+func (s *Stack[V]) Pop() V {
+	s.checkEmpty()
 
-func (s *Stack[V]) Peek() V {
-	return s.data.Get(0)
+	return s.removeAt(s.size - 1)
 }
 
-func (s *Stack[V]) Poll() V {
-	return s.data.RemoveAt(0)
+// Push pushes a value onto the top of the stack.
+//
+// Time Complexity: amortized O(1)
+func (s *Stack[V]) Push(value V) {
+	s.grow(s.size + 1)
+	s.data[s.size] = value
+	s.size++
+	s.modCount++
 }
 
+// Remove removes the occurrence of a value nearest the top of
+// the stack and returns whether the value was present.
+//
+// Time Complexity: O(n)
 func (s *Stack[V]) Remove(value V) bool {
-	return s.data.Remove(value)
+	i := s.indexOf(value)
+	if i < 0 {
+		return false
+	}
+	s.removeAt(i)
+	return true
 }
 
+// RemoveAll removes all the values in the other collection from the stack.
+//
+// Time Complexity: O(nm)
 func (s *Stack[V]) RemoveAll(other structs.Collection[V]) bool {
-	return s.data.RemoveAll(other)
+	return s.RemoveIterator(other.Iterator())
 }
 
-func (s *Stack[V]) RemoveIterator(iter structs.Iterator[V]) bool {
-	return s.data.RemoveIterator(iter)
+// RemoveHead removes and returns the value on top of the stack.
+//
+// Panics if the stack is empty.
+//
+// Time Complexity: O(1)
+func (s *Stack[V]) RemoveHead() V {
+	return s.Pop()
 }
 
-func (s *Stack[V]) RemoveHead() V {
-	return s.Poll()
+// RemoveIterator removes all the values in the iterator from the stack.
+//
+// Time Complexity: O(nm)
+func (s *Stack[V]) RemoveIterator(iter structs.Iterator[V]) bool {
+	changed := false
+	for iter.HasNext() {
+		if s.Remove(iter.Next()) {
+			changed = true
+		}
+	}
+	return changed
 }
 
+// RetainAll removes all the values not present in the other collection from the stack.
+//
+// Time Complexity: O(nm)
+//
+//	n = size of the stack
+//	m = time complexity of Contains on the other collection
 func (s *Stack[V]) RetainAll(other structs.Collection[V]) bool {
-	return s.data.RetainAll(other)
+	// compact the kept values toward the bottom in a single pass
+	kept := 0
+	for i := 0; i < s.size; i++ {
+		if other.Contains(s.data[i]) {
+			s.data[kept] = s.data[i]
+			kept++
+		}
+	}
+	if kept == s.size {
+		return false
+	}
+
+	var null V
+	for i := kept; i < s.size; i++ {
+		s.data[i] = null
+	}
+	s.size = kept
+	s.modCount++
+	s.shrink()
+	return true
 }
 
+// Size returns the number of values in the stack.
+//
+// Time Complexity: O(1)
 func (s *Stack[V]) Size() int {
-	return s.data.Size()
+	return s.size
+}
+
+// TryPeek returns the value on top of the stack and
+// true, or the zero value and false if the stack is empty.
+//
+// Time Complexity: O(1)
+func (s *Stack[V]) TryPeek() (V, bool) {
+	if s.IsEmpty() {
+		var null V
+		return null, false
+	}
+	return s.data[s.size-1], true
 }
 
+// TryPop removes and returns the value on top of the stack and
+// true, or returns the zero value and false if the stack is empty.
+//
+// Time Complexity: O(1)
+func (s *Stack[V]) TryPop() (V, bool) {
+	if s.IsEmpty() {
+		var null V
+		return null, false
+	}
+	return s.removeAt(s.size - 1), true
+}
+
+// Values returns a slice of the values in the stack,
+// starting at the top and ending at the bottom.
+//
+// Time Complexity: O(n)
+//
+// Space Complexity: O(n)
 func (s *Stack[V]) Values() []V {
-	return s.data.Values()
+	values := make([]V, s.size)
+	for i := range values {
+		values[i] = s.data[s.size-1-i]
+	}
+	return values
+}
+
+// indexOf returns the index in the data of the occurrence of a value
+// nearest the top of the stack, or -1 if the value is not present.
+func (s *Stack[V]) indexOf(value V) int {
+	for i := s.size - 1; i >= 0; i-- {
+		if s.eq(s.data[i], value) {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeAt removes the value at the index in the data,
+// shifting the values above it down by one.
+func (s *Stack[V]) removeAt(i int) V {
+	value := s.data[i]
+	copy(s.data[i:], s.data[i+1:s.size])
+
+	var null V
+	s.size--
+	s.data[s.size] = null
+	s.modCount++
+	s.shrink()
+	return value
+}
+
+// grow reallocates the data if it cannot hold need values.
+func (s *Stack[V]) grow(need int) {
+	if need > len(s.data) {
+		capacity := s.capFn(len(s.data), need)
+		if capacity < need {
+			capacity = need
+		}
+		s.data = structs.Realloc(capacity, s.data[:s.size])
+	}
+}
+
+// shrink reallocates the data with a smaller
+// capacity if the shrink function permits it.
+func (s *Stack[V]) shrink() {
+	if s.shrinkFn == nil {
+		return
+	}
+	size := s.shrinkFn(len(s.data), s.size)
+	if size < s.size {
+		size = s.size
+	}
+	if size < len(s.data) {
+		s.data = structs.Realloc(size, s.data[:s.size])
+	}
+}
+
+// checkEmpty panics if the stack is empty.
+func (s *Stack[V]) checkEmpty() {
+	if s.IsEmpty() {
+		panic(structs.PanicNoSuchElement)
+	}
 }
@@ -0,0 +1,183 @@
+package stack
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/list"
+	"golang.org/x/exp/slices"
+	"testing"
+)
+
+func TestStack_LIFO(t *testing.T) {
+	s := NewOrdered[int]()
+	for i := 0; i < 5; i++ {
+		s.Push(i)
+	}
+	if s.Size() != 5 {
+		t.Errorf("expected size 5 but got %d", s.Size())
+	}
+	if got := s.Peek(); got != 4 {
+		t.Errorf("expected to peek 4 but got %d", got)
+	}
+	for i := 4; i >= 0; i-- {
+		if got := s.Pop(); got != i {
+			t.Errorf("expected to pop %d but got %d", i, got)
+		}
+	}
+	if !s.IsEmpty() {
+		t.Error("expected stack to be empty")
+	}
+
+	if _, ok := s.TryPop(); ok {
+		t.Error("expected TryPop on empty stack to fail")
+	}
+	if _, ok := s.TryPeek(); ok {
+		t.Error("expected TryPeek on empty stack to fail")
+	}
+	if got := s.Peek(); got != 0 {
+		t.Errorf("expected Peek on empty stack to return 0 but got %d", got)
+	}
+	if got := s.Poll(); got != 0 {
+		t.Errorf("expected Poll on empty stack to return 0 but got %d", got)
+	}
+
+	defer func() {
+		if r := recover(); r != structs.PanicNoSuchElement {
+			t.Errorf("expected panic %q but got %v", structs.PanicNoSuchElement, r)
+		}
+	}()
+	s.Pop()
+}
+
+func TestStack_Queue(t *testing.T) {
+	var q structs.Queue[string] = FromOrdered("a", "b")
+	q.Offer("c")
+	if got := q.Element(); got != "c" {
+		t.Errorf("expected element c but got %q", got)
+	}
+	if got := q.Poll(); got != "c" {
+		t.Errorf("expected to poll c but got %q", got)
+	}
+	if got := q.RemoveHead(); got != "b" {
+		t.Errorf("expected to remove b but got %q", got)
+	}
+	expect := []string{"a"}
+	if !slices.Equal(q.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, q.Values())
+	}
+}
+
+func TestStack_Collection(t *testing.T) {
+	s := FromOrdered(1, 2, 3, 2, 4)
+
+	expect := []int{4, 2, 3, 2, 1}
+	if !slices.Equal(s.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, s.Values())
+	}
+	if !s.Contains(3) || s.Contains(5) {
+		t.Error("expected stack to contain 3 and not 5")
+	}
+	if !s.ContainsAll(list.OfOrdered(1, 4)) || s.ContainsAll(list.OfOrdered(1, 5)) {
+		t.Error("unexpected result from ContainsAll")
+	}
+
+	// removes the occurrence nearest the top
+	if !s.Remove(2) || s.Remove(5) {
+		t.Error("unexpected result from Remove")
+	}
+	expect = []int{4, 3, 2, 1}
+	if !slices.Equal(s.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, s.Values())
+	}
+
+	s.AddAll(list.OfOrdered(5, 6))
+	if got := s.Peek(); got != 6 {
+		t.Errorf("expected to peek 6 but got %d", got)
+	}
+	if !s.RemoveAll(list.OfOrdered(1, 6)) {
+		t.Error("expected RemoveAll to change the stack")
+	}
+	if !s.RetainAll(list.OfOrdered(2, 3, 5)) || s.RetainAll(list.OfOrdered(2, 3, 5)) {
+		t.Error("unexpected result from RetainAll")
+	}
+	expect = []int{5, 3, 2}
+	if !slices.Equal(s.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, s.Values())
+	}
+
+	s.Clear()
+	if !s.IsEmpty() || s.Size() != 0 {
+		t.Error("expected stack to be empty after Clear")
+	}
+}
+
+func TestStack_Iterator(t *testing.T) {
+	s := FromOrdered(0, 1, 2, 3, 4, 5)
+
+	var got []int
+	it := s.Iterator()
+	for it.HasNext() {
+		value := it.Next()
+		got = append(got, value)
+		if value%2 == 0 {
+			it.Remove()
+		}
+	}
+	expect := []int{5, 4, 3, 2, 1, 0}
+	if !slices.Equal(got, expect) {
+		t.Errorf("expected to iterate %v but got %v", expect, got)
+	}
+	expect = []int{5, 3, 1}
+	if !slices.Equal(s.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, s.Values())
+	}
+
+	it = s.Iterator()
+	it.Next()
+	s.Push(7)
+	defer func() {
+		if r := recover(); r != structs.PanicConcurrentModification {
+			t.Errorf("expected panic %q but got %v", structs.PanicConcurrentModification, r)
+		}
+	}()
+	it.Next()
+}
+
+func TestStack_Capacity(t *testing.T) {
+	s := NewOrderedCap[int](2)
+	s.SetShrinkFunc(structs.HalveCapacity)
+	for i := 0; i < 64; i++ {
+		s.Push(i)
+	}
+	if s.Cap() != 64 {
+		t.Errorf("expected capacity 64 but got %d", s.Cap())
+	}
+	for i := 0; i < 60; i++ {
+		s.Pop()
+	}
+	if s.Cap() >= 64 {
+		t.Errorf("expected capacity to shrink below 64 but got %d", s.Cap())
+	}
+	expect := []int{3, 2, 1, 0}
+	if !slices.Equal(s.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, s.Values())
+	}
+}
+
+func TestStack_CapacityClamped(t *testing.T) {
+	s := NewOrdered[int]()
+	// capacity functions returning too little are clamped
+	// to the number of values the stack needs to hold
+	s.SetCapFunc(func(before, need int) int { return 0 })
+	s.SetShrinkFunc(func(before, need int) int { return 0 })
+	for i := 0; i < 8; i++ {
+		s.Push(i)
+	}
+	s.Pop()
+	expect := []int{6, 5, 4, 3, 2, 1, 0}
+	if !slices.Equal(s.Values(), expect) {
+		t.Errorf("expected values %v but got %v", expect, s.Values())
+	}
+	if s.Cap() != 7 {
+		t.Errorf("expected capacity 7 but got %d", s.Cap())
+	}
+}
@@ -0,0 +1,49 @@
+package stack
+
+import "github.com/zytekaron/structs"
+
+// Iterator is an iterator over the values of a Stack,
+// from the top of the stack to the bottom.
+type Iterator[V any] struct {
+	stack *Stack[V]
+	next  int // index in the data of the next value
+	last  int // index in the data of the last value returned, or -1
+
+	expectedModCount int
+}
+
+func (it *Iterator[V]) HasNext() bool {
+	return it.next >= 0
+}
+
+func (it *Iterator[V]) Next() V {
+	it.checkModCount()
+	if !it.HasNext() {
+		panic(structs.PanicNoSuchElement)
+	}
+
+	it.last = it.next
+	it.next--
+	return it.stack.data[it.last]
+}
+
+func (it *Iterator[V]) Remove() {
+	it.checkModCount()
+	if it.last < 0 {
+		panic(structs.PanicIllegalState)
+	}
+
+	// only values above the removed value move,
+	// and those have already been returned
+	it.stack.removeAt(it.last)
+	it.last = -1
+	it.expectedModCount = it.stack.modCount
+}
+
+// checkModCount panics if the stack was modified
+// other than through this iterator.
+func (it *Iterator[V]) checkModCount() {
+	if it.stack.modCount != it.expectedModCount {
+		panic(structs.PanicConcurrentModification)
+	}
+}
@@ -31,8 +31,11 @@ type EqualFunc[V any] func(a, b V) (equal bool)
 type CompareFunc[V any] func(a, b V) (result int)
 
 // CapacityFunc is a function used to return a new capacity for a slice
-// when it needs to be increased. It must return an integer equal to or
-// greater than need, otherwise it may cause a panic from the caller.
+// when it needs to be increased, or when it may be decreased. It must
+// return an integer equal to or greater than need, otherwise it may
+// cause a panic from the caller.
+//
+// When used to decrease capacity, returning old leaves the slice as-is.
 type CapacityFunc func(old, need int) int
 
 const (
@@ -44,6 +47,10 @@ const (
 	// invalid state for the operation, for example, calling Iterator.Remove()
 	// prior to calling Iterator.Next().
 	PanicIllegalState = "illegal state"
+	// PanicConcurrentModification is thrown when a collection is structurally
+	// modified while an iterator is in use, other than through the iterator
+	// itself, for example, calling List.Add() between calls to Iterator.Next().
+	PanicConcurrentModification = "concurrent modification"
 	// PanicIndexOutOfBounds is thrown when a method is called with an index
 	// which is out of bounds for the particular operation, for example, -1, or
 	// an index greater than (or in some cases equal to) the collection's size.
@@ -148,7 +155,13 @@ func DoubleCapacity(before, need int) (after int) {
 	return before
 }
 
-//// HalveCapacity returns a capacity half that of the old capacity.
-//func HalveCapacity(before, need int) (after int) {
-//	return before >> 1
-//}
+// HalveCapacity returns a capacity half that of the old capacity
+// if need is at most a quarter of the old capacity, otherwise it
+// returns the old capacity. Waiting until a quarter of the capacity
+// is in use prevents repeatedly growing and shrinking near the boundary.
+func HalveCapacity(before, need int) (after int) {
+	if need > before/4 {
+		return before
+	}
+	return before >> 1
+}
@@ -16,6 +16,9 @@ type TreeMap[K, V any] struct {
 	valEq  structs.EqualFunc[V]
 	root   *treeNode[K, V]
 	size   int
+	// modCount is incremented whenever the map is structurally
+	// modified, which allows iterators to detect concurrent changes.
+	modCount int
 }
 
 func New[K, V any](keyCompare structs.CompareFunc[K], valueEqual structs.EqualFunc[V]) *TreeMap[K, V] {
@@ -76,6 +79,7 @@ func (t *TreeMap[K, V]) Put(key K, value V) V {
 	if t.root == nil {
 		t.root = newNode(key, value, true)
 		t.size = 1
+		t.modCount++
 		var null V
 		return null
 	}
@@ -111,6 +115,7 @@ loop:
 
 	t.insertFixup(inserted)
 	t.size++
+	t.modCount++
 	var null V
 	return null
 }
@@ -133,6 +138,7 @@ func (t *TreeMap[K, V]) Size() int {
 func (t *TreeMap[K, V]) Clear() {
 	t.root = nil
 	t.size = 0
+	t.modCount++
 }
 
 func (t *TreeMap[K, V]) EntryIterator() *EntryIterator[K, V] {
@@ -365,10 +371,11 @@ func (t *TreeMap[K, V]) removeNode(node *treeNode[K, V]) {
 		}
 	}
 	t.size--
+	t.modCount++
 }
 
 func (t *TreeMap[K, V]) nodeIterator() *nodeIterator[K, V] {
-	return t.nodeIteratorAt(t.root)
+	return t.nodeIteratorAt(t.root.minimumNode())
 }
 
 func (t *TreeMap[K, V]) descendingNodeIterator() *nodeIterator[K, V] {
@@ -377,8 +384,9 @@ func (t *TreeMap[K, V]) descendingNodeIterator() *nodeIterator[K, V] {
 
 func (t *TreeMap[K, V]) nodeIteratorAt(node *treeNode[K, V]) *nodeIterator[K, V] {
 	return &nodeIterator[K, V]{
-		treemap: t,
-		next:    node,
-		last:    nil,
+		treemap:          t,
+		next:             node,
+		last:             nil,
+		expectedModCount: t.modCount,
 	}
 }
@@ -2,6 +2,7 @@ package treemap
 
 import (
 	"github.com/zytekaron/structs"
+	"golang.org/x/exp/slices"
 	"testing"
 )
 
@@ -48,3 +49,51 @@ func TestTreeMap(t *testing.T) {
 		t.Errorf("expected 'model' to map to 'T', got '%s'", got)
 	}
 }
+
+func TestTreeMap_ConcurrentModification(t *testing.T) {
+	defer func() {
+		if r := recover(); r != structs.PanicConcurrentModification {
+			t.Errorf("expected panic %q but got %v", structs.PanicConcurrentModification, r)
+		}
+	}()
+
+	tm := NewOrdered[int, int]()
+	tm.Put(1, 1)
+	tm.Put(2, 2)
+
+	it := tm.KeyIterator()
+	it.Next()
+	tm.Put(3, 3)
+	it.Next()
+}
+
+func TestTreeMap_PutExistingIsNotModification(t *testing.T) {
+	tm := NewOrdered[int, int]()
+	tm.Put(1, 1)
+	tm.Put(2, 2)
+
+	it := tm.KeyIterator()
+	it.Next()
+	tm.Put(1, 10) // replaces the value, not a structural modification
+	if !it.HasNext() {
+		t.Fatal("expected iterator to have another key")
+	}
+	it.Next()
+}
+
+func TestTreeMap_KeyIterator(t *testing.T) {
+	tm := NewOrdered[int, int]()
+	for _, key := range []int{5, 2, 8, 1, 9, 3} {
+		tm.Put(key, key)
+	}
+
+	var got []int
+	it := tm.KeyIterator()
+	for it.HasNext() {
+		got = append(got, it.Next())
+	}
+	expect := []int{1, 2, 3, 5, 8, 9}
+	if !slices.Equal(got, expect) {
+		t.Errorf("expected keys %v but got %v", expect, got)
+	}
+}
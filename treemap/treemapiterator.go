@@ -6,6 +6,8 @@ type nodeIterator[K, V any] struct {
 	treemap *TreeMap[K, V]
 	next    *treeNode[K, V]
 	last    *treeNode[K, V]
+
+	expectedModCount int
 }
 
 func (it *nodeIterator[K, V]) hasNext() bool {
@@ -13,6 +15,7 @@ func (it *nodeIterator[K, V]) hasNext() bool {
 }
 
 func (it *nodeIterator[K, V]) nextNode() *treeNode[K, V] {
+	it.checkModCount()
 	e := it.next
 	if e == nil {
 		panic(structs.PanicIllegalState)
@@ -24,6 +27,7 @@ func (it *nodeIterator[K, V]) nextNode() *treeNode[K, V] {
 }
 
 func (it *nodeIterator[K, V]) previousNode() *treeNode[K, V] {
+	it.checkModCount()
 	e := it.next
 	if e == nil {
 		panic(structs.PanicIllegalState)
@@ -35,14 +39,26 @@ func (it *nodeIterator[K, V]) previousNode() *treeNode[K, V] {
 }
 
 func (it *nodeIterator[K, V]) remove() {
+	it.checkModCount()
 	if it.last == nil {
 		panic(structs.PanicIllegalState)
 	}
 
-	if it.last.Left != nil && it.last.Right != nil {
+	// removing a node with two children moves its predecessor's
+	// entry into it, so if the predecessor is next, it moves too
+	if it.last.Left != nil && it.last.Right != nil && it.next == it.last.Left.maximumNode() {
 		it.next = it.last
-		it.treemap.removeNode(it.last)
-		it.last = nil
+	}
+	it.treemap.removeNode(it.last)
+	it.last = nil
+	it.expectedModCount = it.treemap.modCount
+}
+
+// checkModCount panics if the map was modified
+// other than through this iterator.
+func (it *nodeIterator[K, V]) checkModCount() {
+	if it.treemap.modCount != it.expectedModCount {
+		panic(structs.PanicConcurrentModification)
 	}
 }
 
@@ -75,12 +91,7 @@ func (it *DescendingKeyIterator[K, V]) Next() K {
 }
 
 func (it *DescendingKeyIterator[K, V]) Remove() {
-	if it.iter.last == nil {
-		panic(structs.PanicIllegalState)
-	}
-
-	it.iter.treemap.removeNode(it.iter.last)
-	it.iter.last = nil
+	it.iter.remove()
 }
 
 type ValueIterator[K, V any] struct {
@@ -112,12 +123,7 @@ func (it *DescendingValueIterator[K, V]) Next() V {
 }
 
 func (it *DescendingValueIterator[K, V]) Remove() {
-	if it.iter.last == nil {
-		panic(structs.PanicIllegalState)
-	}
-
-	it.iter.treemap.removeNode(it.iter.last)
-	it.iter.last = nil
+	it.iter.remove()
 }
 
 type EntryIterator[K, V any] struct {
@@ -151,10 +157,5 @@ func (it *DescendingEntryIterator[K, V]) Next() (K, V) {
 }
 
 func (it *DescendingEntryIterator[K, V]) Remove() {
-	if it.iter.last == nil {
-		panic(structs.PanicIllegalState)
-	}
-
-	it.iter.treemap.removeNode(it.iter.last)
-	it.iter.last = nil
+	it.iter.remove()
 }
@@ -44,6 +44,16 @@ func (n *treeNode[K, V]) sibling() *treeNode[K, V] {
 	return n.Parent.Left
 }
 
+func (n *treeNode[K, V]) minimumNode() *treeNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	for n.Left != nil {
+		n = n.Left
+	}
+	return n
+}
+
 func (n *treeNode[K, V]) maximumNode() *treeNode[K, V] {
 	if n == nil {
 		return nil
@@ -69,7 +79,7 @@ func (n *treeNode[K, V]) predecessor() *treeNode[K, V] {
 
 	parent := n.Parent
 	tmp := n
-	for tmp != nil && tmp == parent.Left {
+	for parent != nil && tmp == parent.Left {
 		tmp = parent
 		parent = parent.Parent
 	}
@@ -92,7 +102,7 @@ func (n *treeNode[K, V]) successor() *treeNode[K, V] {
 
 	parent := n.Parent
 	tmp := n
-	for tmp != nil && tmp == parent.Right {
+	for parent != nil && tmp == parent.Right {
 		tmp = parent
 		parent = parent.Parent
 	}
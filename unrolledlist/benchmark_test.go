@@ -0,0 +1,96 @@
+package unrolledlist
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/list"
+	"testing"
+)
+
+const benchSize = 10_000
+
+func BenchmarkAddLast(b *testing.B) {
+	b.Run("UnrolledList", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			l := NewOrdered[int]()
+			for j := 0; j < benchSize; j++ {
+				l.AddLast(j)
+			}
+		}
+	})
+	b.Run("List", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			l := list.NewOrdered[int]()
+			for j := 0; j < benchSize; j++ {
+				l.AddLast(j)
+			}
+		}
+	})
+}
+
+func BenchmarkIterate(b *testing.B) {
+	benchmarkLists(b, func(b *testing.B, l structs.List[int]) {
+		for i := 0; i < b.N; i++ {
+			sum := 0
+			for it := l.Iterator(); it.HasNext(); {
+				sum += it.Next()
+			}
+		}
+	})
+}
+
+func BenchmarkEach(b *testing.B) {
+	unrolled := NewOrdered[int]()
+	linked := list.NewOrdered[int]()
+	for i := 0; i < benchSize; i++ {
+		unrolled.Add(i)
+		linked.Add(i)
+	}
+
+	sum := 0
+	b.Run("UnrolledList", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			unrolled.Each(func(value int) { sum += value })
+		}
+	})
+	b.Run("List", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			linked.Each(func(value int) { sum += value })
+		}
+	})
+}
+
+func BenchmarkGet(b *testing.B) {
+	benchmarkLists(b, func(b *testing.B, l structs.List[int]) {
+		for i := 0; i < b.N; i++ {
+			l.Get(i * 7919 % benchSize)
+		}
+	})
+}
+
+func BenchmarkAddAtRemoveAt(b *testing.B) {
+	benchmarkLists(b, func(b *testing.B, l structs.List[int]) {
+		for i := 0; i < b.N; i++ {
+			index := i * 7919 % benchSize
+			l.AddAt(index, i)
+			l.RemoveAt(index)
+		}
+	})
+}
+
+// benchmarkLists runs a benchmark against an UnrolledList
+// and a List, each holding benchSize values.
+func benchmarkLists(b *testing.B, bench func(b *testing.B, l structs.List[int])) {
+	unrolled := NewOrdered[int]()
+	linked := list.NewOrdered[int]()
+	for i := 0; i < benchSize; i++ {
+		unrolled.Add(i)
+		linked.Add(i)
+	}
+
+	b.Run("UnrolledList", func(b *testing.B) {
+		bench(b, unrolled)
+	})
+	b.Run("List", func(b *testing.B) {
+		bench(b, linked)
+	})
+}
@@ -0,0 +1,227 @@
+package unrolledlist
+
+import "github.com/zytekaron/structs"
+
+// Iterator is a bidirectional iterator over the values of an UnrolledList.
+//
+// Like a Java ListIterator, it has no current value: its cursor lies
+// between the value that would be returned by Previous and the value
+// that would be returned by Next. Remove and Set operate on the value
+// last returned by Next or Previous, and Add inserts at the cursor.
+//
+// The iterator caches the node at its cursor, so Next and Previous run
+// in O(1). Add and Remove invalidate the cache, so the following call
+// to Next or Previous locates the cursor again in O(n/B).
+type Iterator[V any] struct {
+	list   *UnrolledList[V]
+	node   *node[V] // node holding the value at index, or nil if not cached
+	offset int      // offset of the value at index in node
+	index  int
+	last   int // index of the last value returned, or -1
+
+	expectedModCount int
+}
+
+var _ structs.ListIterator[int] = (*Iterator[int])(nil)
+
+func (l *UnrolledList[V]) listIterator(index int) *Iterator[V] {
+	return &Iterator[V]{
+		list:             l,
+		index:            index,
+		last:             -1,
+		expectedModCount: l.modCount,
+	}
+}
+
+// Add inserts a value into the list at the cursor, so it would be
+// returned by Previous and subsequent calls to Next are unaffected.
+func (it *Iterator[V]) Add(value V) {
+	it.checkModCount()
+
+	it.list.insertAt(it.index, value)
+	it.index++
+	it.last = -1
+	it.node = nil
+	it.expectedModCount = it.list.modCount
+}
+
+func (it *Iterator[V]) HasNext() bool {
+	return it.index < it.list.size
+}
+
+func (it *Iterator[V]) HasPrevious() bool {
+	return it.index > 0
+}
+
+func (it *Iterator[V]) Next() V {
+	it.checkModCount()
+	if it.index >= it.list.size {
+		panic(structs.PanicNoSuchElement)
+	}
+	it.seek()
+
+	value := it.node.values[it.offset]
+	it.last = it.index
+	it.index++
+	it.offset++
+	if it.offset == it.node.count && it.node.next != nil {
+		it.node, it.offset = it.node.next, 0
+	}
+	return value
+}
+
+func (it *Iterator[V]) NextIndex() int {
+	return it.index
+}
+
+func (it *Iterator[V]) Previous() V {
+	it.checkModCount()
+	if it.index <= 0 {
+		panic(structs.PanicNoSuchElement)
+	}
+	it.seek()
+
+	if it.offset == 0 {
+		it.node, it.offset = it.node.prev, it.node.prev.count
+	}
+	it.offset--
+	it.index--
+	it.last = it.index
+	return it.node.values[it.offset]
+}
+
+func (it *Iterator[V]) PreviousIndex() int {
+	return it.index - 1
+}
+
+// Remove removes the value last returned by Next or Previous.
+//
+// Panics if neither has been called since the last call to Remove or Add.
+func (it *Iterator[V]) Remove() {
+	it.checkModCount()
+	if it.last < 0 {
+		panic(structs.PanicIllegalState)
+	}
+
+	n, offset := it.lastNode()
+	it.list.removeFrom(n, offset)
+	// if removing the previous value (by list order),
+	// decrease the index due to a left shift of data
+	if it.last < it.index {
+		it.index--
+	}
+	it.last = -1
+	it.node = nil
+	it.expectedModCount = it.list.modCount
+}
+
+// Set replaces the value last returned by Next or Previous.
+//
+// Panics if neither has been called since the last call to Remove or Add.
+func (it *Iterator[V]) Set(value V) {
+	it.checkModCount()
+	if it.last < 0 {
+		panic(structs.PanicIllegalState)
+	}
+
+	n, offset := it.lastNode()
+	n.values[offset] = value
+}
+
+// seek caches the node and offset of the value at the cursor if they
+// are not already cached. At the end of the list, the offset is past
+// the last value of the tail node.
+func (it *Iterator[V]) seek() {
+	if it.node != nil {
+		return
+	}
+	l := it.list
+	if it.index == l.size {
+		it.node, it.offset = l.tail, l.tail.count
+		return
+	}
+	n, start := l.locate(it.index)
+	it.node, it.offset = n, it.index-start
+}
+
+// lastNode returns the node and offset of the value last returned,
+// which is either just before or at the cursor.
+func (it *Iterator[V]) lastNode() (*node[V], int) {
+	it.seek()
+	if it.last == it.index {
+		return it.node, it.offset
+	}
+	if it.offset == 0 {
+		return it.node.prev, it.node.prev.count - 1
+	}
+	return it.node, it.offset - 1
+}
+
+// checkModCount panics if the list was modified
+// other than through this iterator.
+func (it *Iterator[V]) checkModCount() {
+	if it.list.modCount != it.expectedModCount {
+		panic(structs.PanicConcurrentModification)
+	}
+}
+
+// DescendingIterator is a bidirectional iterator over the values of
+// an UnrolledList in reverse order. It behaves like an Iterator over a
+// reversed copy of the list, including its indices, except that changes
+// are made to the list itself.
+type DescendingIterator[V any] struct {
+	iter *Iterator[V]
+}
+
+var _ structs.ListIterator[int] = (*DescendingIterator[int])(nil)
+
+// Add inserts a value into the list at the cursor, so it would be
+// returned by Previous and subsequent calls to Next are unaffected.
+// In list order, the value is inserted after the value Next would return.
+func (it *DescendingIterator[V]) Add(value V) {
+	inner := it.iter
+	inner.checkModCount()
+
+	inner.list.insertAt(inner.index, value)
+	inner.last = -1
+	inner.node = nil
+	inner.expectedModCount = inner.list.modCount
+}
+
+func (it *DescendingIterator[V]) HasNext() bool {
+	return it.iter.HasPrevious()
+}
+
+func (it *DescendingIterator[V]) HasPrevious() bool {
+	return it.iter.HasNext()
+}
+
+func (it *DescendingIterator[V]) Next() V {
+	return it.iter.Previous()
+}
+
+func (it *DescendingIterator[V]) NextIndex() int {
+	return it.iter.list.size - it.iter.index
+}
+
+func (it *DescendingIterator[V]) Previous() V {
+	return it.iter.Next()
+}
+
+func (it *DescendingIterator[V]) PreviousIndex() int {
+	return it.NextIndex() - 1
+}
+
+// Remove removes the value last returned by Next or Previous.
+//
+// Panics if neither has been called since the last call to Remove or Add.
+func (it *DescendingIterator[V]) Remove() {
+	it.iter.Remove()
+}
+
+// Set replaces the value last returned by Next or Previous.
+//
+// Panics if neither has been called since the last call to Remove or Add.
+func (it *DescendingIterator[V]) Set(value V) {
+	it.iter.Set(value)
+}
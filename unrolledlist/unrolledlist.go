@@ -0,0 +1,844 @@
+package unrolledlist
+
+import (
+	"fmt"
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/listview"
+	"github.com/zytekaron/structs/wrap"
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+	"strings"
+)
+
+// DefaultNodeSize is the default number of values held by each node.
+const DefaultNodeSize = 64
+
+// UnrolledList is an implementation of an unrolled linked list: a
+// doubly-linked list of nodes which each hold an array of values.
+// Storing values contiguously makes sequential access cache-friendly,
+// and allows indexed access to skip whole nodes at a time.
+//
+// Adjacent nodes are merged when their values fit in a single node,
+// so nodes are on average at least half full.
+//
+// The value equality function may be omitted (nil)
+// if no methods are called which use it.
+type UnrolledList[V any] struct {
+	eq       structs.EqualFunc[V]
+	nodeSize int
+	head     *node[V]
+	tail     *node[V]
+	size     int
+	// modCount is incremented whenever the list is structurally
+	// modified, which allows iterators to detect concurrent changes.
+	modCount int
+}
+
+// node is a node of an UnrolledList, holding up to nodeSize values.
+type node[V any] struct {
+	values []V // len(values) is the list's nodeSize
+	count  int
+	prev   *node[V]
+	next   *node[V]
+}
+
+var (
+	_ structs.List[int]  = (*UnrolledList[int])(nil)
+	_ structs.Deque[int] = (*UnrolledList[int])(nil)
+)
+
+// New creates an empty UnrolledList.
+func New[V any](eq structs.EqualFunc[V]) *UnrolledList[V] {
+	return NewNodeSize(DefaultNodeSize, eq)
+}
+
+// NewOrdered creates an empty UnrolledList from a type that implements constraints.Ordered.
+func NewOrdered[V constraints.Ordered]() *UnrolledList[V] {
+	return NewNodeSize(DefaultNodeSize, structs.EqualOrdered[V])
+}
+
+// NewNodeSize creates an empty UnrolledList whose nodes each hold up to nodeSize values.
+//
+// Panics if nodeSize is less than 2.
+func NewNodeSize[V any](nodeSize int, eq structs.EqualFunc[V]) *UnrolledList[V] {
+	if nodeSize < 2 {
+		panic("unrolled list node size must be at least 2")
+	}
+	return &UnrolledList[V]{
+		eq:       eq,
+		nodeSize: nodeSize,
+	}
+}
+
+// NewOrderedNodeSize creates an empty UnrolledList whose nodes each hold up
+// to nodeSize values from a type that implements constraints.Ordered.
+//
+// Panics if nodeSize is less than 2.
+func NewOrderedNodeSize[V constraints.Ordered](nodeSize int) *UnrolledList[V] {
+	return NewNodeSize(nodeSize, structs.EqualOrdered[V])
+}
+
+// From creates an UnrolledList from an existing collection.
+func From[V any](eq structs.EqualFunc[V], other structs.Collection[V]) *UnrolledList[V] {
+	list := New(eq)
+	list.AddAll(other)
+	return list
+}
+
+// FromOrdered creates an UnrolledList from an existing collection of a type that implements constraints.Ordered.
+func FromOrdered[V constraints.Ordered](other structs.Collection[V]) *UnrolledList[V] {
+	return From(structs.EqualOrdered[V], other)
+}
+
+// Of creates an UnrolledList from an existing slice.
+func Of[V any](eq structs.EqualFunc[V], values ...V) *UnrolledList[V] {
+	list := New(eq)
+	list.AddIterator(wrap.SliceIterator(values))
+	return list
+}
+
+// OfOrdered creates an UnrolledList from an existing slice of a type that implements constraints.Ordered.
+func OfOrdered[V constraints.Ordered](values ...V) *UnrolledList[V] {
+	return Of(structs.EqualOrdered[V], values...)
+}
+
+// Add adds a value to the end of the list.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) Add(value V) bool {
+	return l.AddLast(value)
+}
+
+// AddAt adds a value at the specified index in the list.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n/B+B)
+func (l *UnrolledList[V]) AddAt(index int, value V) {
+	l.checkBounds(index, true)
+
+	l.insertAt(index, value)
+}
+
+// AddAll adds all the values in the other collection to the end of the list.
+//
+// Time Complexity: O(m)
+func (l *UnrolledList[V]) AddAll(other structs.Collection[V]) bool {
+	return l.AddIterator(other.Iterator())
+}
+
+// AddFirst adds a value to the front of the list.
+//
+// Time Complexity: O(B)
+func (l *UnrolledList[V]) AddFirst(value V) bool {
+	if l.head == nil || l.head.count == l.nodeSize {
+		l.linkBefore(l.head, l.newNode())
+	}
+	l.head.insert(0, value)
+	l.size++
+	l.modCount++
+	return true
+}
+
+// AddLast adds a value to the end of the list.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) AddLast(value V) bool {
+	if l.tail == nil || l.tail.count == l.nodeSize {
+		l.linkAfter(l.tail, l.newNode())
+	}
+	l.tail.values[l.tail.count] = value
+	l.tail.count++
+	l.size++
+	l.modCount++
+	return true
+}
+
+// AddIterator adds all the values in the iterator to the end of the list.
+//
+// Time Complexity: O(m)
+func (l *UnrolledList[V]) AddIterator(iter structs.Iterator[V]) bool {
+	changed := iter.HasNext()
+	for iter.HasNext() {
+		l.AddLast(iter.Next())
+	}
+	return changed
+}
+
+// Clear clears the list.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) Clear() {
+	l.head = nil
+	l.tail = nil
+	l.size = 0
+	l.modCount++
+}
+
+// Contains returns whether the value is present in the list.
+//
+// Time Complexity: O(n)
+func (l *UnrolledList[V]) Contains(value V) bool {
+	return l.IndexOf(value) >= 0
+}
+
+// ContainsAll returns whether all the values in the other collection are present in the list.
+//
+// Time Complexity: O(nm)
+func (l *UnrolledList[V]) ContainsAll(other structs.Collection[V]) bool {
+	return l.ContainsIterator(other.Iterator())
+}
+
+// ContainsIterator returns whether all the values in the iterator are present in the list.
+//
+// Time Complexity: O(nm)
+func (l *UnrolledList[V]) ContainsIterator(iter structs.Iterator[V]) bool {
+	for iter.HasNext() {
+		if !l.Contains(iter.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+// DescendingIterator returns a DescendingIterator for the list.
+func (l *UnrolledList[V]) DescendingIterator() structs.Iterator[V] {
+	return &DescendingIterator[V]{
+		iter: l.listIterator(l.size),
+	}
+}
+
+// Each calls a function for each value in the list, starting at the front of the list.
+func (l *UnrolledList[V]) Each(f func(value V)) {
+	for n := l.head; n != nil; n = n.next {
+		for _, value := range n.values[:n.count] {
+			f(value)
+		}
+	}
+}
+
+// EachReverse calls a function for each value in the list, starting at the end of the list.
+func (l *UnrolledList[V]) EachReverse(f func(value V)) {
+	for n := l.tail; n != nil; n = n.prev {
+		for i := n.count - 1; i >= 0; i-- {
+			f(n.values[i])
+		}
+	}
+}
+
+// Element returns the value at the front of the list.
+//
+// Panics if the list is empty.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) Element() V {
+	return l.GetFirst()
+}
+
+// Get returns the value at the specified index.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n/B)
+func (l *UnrolledList[V]) Get(index int) V {
+	l.checkBounds(index, false)
+
+	n, start := l.locate(index)
+	return n.values[index-start]
+}
+
+// GetFirst returns the value at the front of the list.
+//
+// Panics if the list is empty.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) GetFirst() V {
+	l.checkEmpty()
+
+	return l.head.values[0]
+}
+
+// GetLast returns the value at the end of the list.
+//
+// Panics if the list is empty.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) GetLast() V {
+	l.checkEmpty()
+
+	return l.tail.values[l.tail.count-1]
+}
+
+// IndexOf returns the first index of a value in the list,
+// or -1 if the value is not present in the list.
+//
+// Time Complexity: O(n)
+func (l *UnrolledList[V]) IndexOf(value V) int {
+	start := 0
+	for n := l.head; n != nil; n = n.next {
+		for i, val := range n.values[:n.count] {
+			if l.eq(val, value) {
+				return start + i
+			}
+		}
+		start += n.count
+	}
+	return -1
+}
+
+// IsEmpty returns whether the list is empty.
+func (l *UnrolledList[V]) IsEmpty() bool {
+	return l.size == 0
+}
+
+// Iterator returns an Iterator for the list.
+func (l *UnrolledList[V]) Iterator() structs.Iterator[V] {
+	return l.listIterator(0)
+}
+
+// LastIndexOf returns the last index of a value in the list,
+// or -1 if the value is not present in the list.
+//
+// Time Complexity: O(n)
+func (l *UnrolledList[V]) LastIndexOf(value V) int {
+	end := l.size
+	for n := l.tail; n != nil; n = n.prev {
+		end -= n.count
+		for i := n.count - 1; i >= 0; i-- {
+			if l.eq(n.values[i], value) {
+				return end + i
+			}
+		}
+	}
+	return -1
+}
+
+// ListIterator returns a ListIterator over the list whose cursor is
+// before the value at the index provided, so the first call to Next
+// returns that value and the first call to Previous returns the value
+// before it. The index may be the size of the list, to start at the end.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n/B)
+func (l *UnrolledList[V]) ListIterator(index int) structs.ListIterator[V] {
+	l.checkBounds(index, true)
+
+	return l.listIterator(index)
+}
+
+// NodeSize returns the maximum number of values held by each node.
+func (l *UnrolledList[V]) NodeSize() int {
+	return l.nodeSize
+}
+
+// Offer adds a value to the end of the list.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) Offer(value V) bool {
+	return l.AddLast(value)
+}
+
+// OfferFirst adds a value to the front of the list.
+//
+// Time Complexity: O(B)
+func (l *UnrolledList[V]) OfferFirst(value V) bool {
+	return l.AddFirst(value)
+}
+
+// OfferLast adds a value to the end of the list.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) OfferLast(value V) bool {
+	return l.AddLast(value)
+}
+
+// Peek returns the first value in the list, or
+// the zero value of the type if the list is empty.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) Peek() V {
+	return l.PeekFirst()
+}
+
+// PeekFirst returns the first value in the list, or
+// the zero value of the type if the list is empty.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) PeekFirst() V {
+	if l.IsEmpty() {
+		var null V
+		return null
+	}
+	return l.head.values[0]
+}
+
+// PeekLast returns the last value in the list, or
+// the zero value of the type if the list is empty.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) PeekLast() V {
+	if l.IsEmpty() {
+		var null V
+		return null
+	}
+	return l.tail.values[l.tail.count-1]
+}
+
+// Poll removes and returns the first value in the list,
+// or returns the zero value of the type if the list is empty.
+//
+// Time Complexity: O(B)
+func (l *UnrolledList[V]) Poll() V {
+	return l.PollFirst()
+}
+
+// PollFirst removes and returns the first value in the list,
+// or returns the zero value of the type if the list is empty.
+//
+// Time Complexity: O(B)
+func (l *UnrolledList[V]) PollFirst() V {
+	if l.IsEmpty() {
+		var null V
+		return null
+	}
+	return l.removeFrom(l.head, 0)
+}
+
+// PollLast removes and returns the last value in the list,
+// or returns the zero value of the type if the list is empty.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) PollLast() V {
+	if l.IsEmpty() {
+		var null V
+		return null
+	}
+	return l.removeFrom(l.tail, l.tail.count-1)
+}
+
+// Pop removes and returns the first value in the list.
+//
+// Panics if the list is empty.
+//
+// Time Complexity: O(B)
+func (l *UnrolledList[V]) Pop() V {
+	return l.RemoveFirst()
+}
+
+// Push adds a value to the front of the list.
+//
+// Time Complexity: O(B)
+func (l *UnrolledList[V]) Push(value V) {
+	l.AddFirst(value)
+}
+
+// Remove removes the first occurrence of a value from
+// the list and returns whether the value was present.
+//
+// Time Complexity: O(n)
+func (l *UnrolledList[V]) Remove(value V) bool {
+	return l.RemoveFirstOccurrence(value)
+}
+
+// RemoveAll removes all the values in the other collection from the list.
+//
+// Time Complexity: O(nm)
+func (l *UnrolledList[V]) RemoveAll(other structs.Collection[V]) bool {
+	return l.RemoveIterator(other.Iterator())
+}
+
+// RemoveAt removes the value at the specified index from the list and returns it.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n/B+B)
+func (l *UnrolledList[V]) RemoveAt(index int) V {
+	l.checkBounds(index, false)
+
+	n, start := l.locate(index)
+	return l.removeFrom(n, index-start)
+}
+
+// RemoveFirst removes and returns the first value in the list.
+//
+// Panics if the list is empty.
+//
+// Time Complexity: O(B)
+func (l *UnrolledList[V]) RemoveFirst() V {
+	l.checkEmpty()
+
+	return l.removeFrom(l.head, 0)
+}
+
+// RemoveFirstOccurrence removes the first occurrence of a value
+// from the list and returns whether the value was present.
+//
+// Time Complexity: O(n)
+func (l *UnrolledList[V]) RemoveFirstOccurrence(value V) bool {
+	for n := l.head; n != nil; n = n.next {
+		for i, val := range n.values[:n.count] {
+			if l.eq(val, value) {
+				l.removeFrom(n, i)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RemoveHead removes and returns the first value in the list.
+//
+// Panics if the list is empty.
+//
+// Time Complexity: O(B)
+func (l *UnrolledList[V]) RemoveHead() V {
+	return l.RemoveFirst()
+}
+
+// RemoveIterator removes all the values in the iterator from the list.
+//
+// Time Complexity: O(nm)
+func (l *UnrolledList[V]) RemoveIterator(iter structs.Iterator[V]) bool {
+	changed := false
+	for iter.HasNext() {
+		if l.Remove(iter.Next()) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// RemoveLast removes and returns the last value in the list.
+//
+// Panics if the list is empty.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) RemoveLast() V {
+	l.checkEmpty()
+
+	return l.removeFrom(l.tail, l.tail.count-1)
+}
+
+// RemoveLastOccurrence removes the last occurrence of a value
+// from the list and returns whether the value was present.
+//
+// Time Complexity: O(n)
+func (l *UnrolledList[V]) RemoveLastOccurrence(value V) bool {
+	for n := l.tail; n != nil; n = n.prev {
+		for i := n.count - 1; i >= 0; i-- {
+			if l.eq(n.values[i], value) {
+				l.removeFrom(n, i)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RetainAll removes all the values not present in the other collection from the list.
+// The remaining values are packed into as few nodes as possible.
+//
+// Time Complexity: O(nm)
+//
+//	n = size of the list
+//	m = time complexity of Contains on the other collection
+func (l *UnrolledList[V]) RetainAll(other structs.Collection[V]) bool {
+	// compact the kept values toward the front in a single pass. the
+	// write position never passes the read position, since earlier
+	// nodes are filled completely before later nodes are written to
+	w, wi, kept, moved := l.head, 0, 0, false
+	for r := l.head; r != nil; r = r.next {
+		for i := 0; i < r.count; i++ {
+			value := r.values[i]
+			if other.Contains(value) {
+				if wi == l.nodeSize {
+					w.count = wi
+					w, wi = w.next, 0
+				}
+				moved = moved || w != r || wi != i
+				w.values[wi] = value
+				wi++
+				kept++
+			}
+		}
+	}
+	if !moved && kept == l.size {
+		return false
+	}
+	if kept == 0 {
+		l.Clear()
+		return true
+	}
+
+	w.clear(wi, w.count)
+	w.count = wi
+	w.next = nil
+	l.tail = w
+	changed := kept != l.size
+	l.size = kept
+	l.modCount++
+	return changed
+}
+
+// Set sets the value at the specified index, returning the old value.
+//
+// Panics if the index is out of bounds.
+//
+// Time Complexity: O(n/B)
+func (l *UnrolledList[V]) Set(index int, value V) V {
+	l.checkBounds(index, false)
+
+	n, start := l.locate(index)
+	oldValue := n.values[index-start]
+	n.values[index-start] = value
+	return oldValue
+}
+
+// Size returns the number of values in the list.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) Size() int {
+	return l.size
+}
+
+// Sort sorts the list based on a comparator.
+//
+// The values are copied into a slice, sorted, and copied back
+// into the existing nodes, so the list's structure is unchanged.
+//
+// Time Complexity: O(nlogn)
+// See slices.SortFunc (algorithm: pattern-defeating quicksort).
+//
+// Space Complexity: O(n)
+func (l *UnrolledList[V]) Sort(cmp structs.LessFunc[V]) {
+	sorted := l.Values()
+	slices.SortFunc(sorted, cmp)
+
+	for n := l.head; n != nil; n = n.next {
+		sorted = sorted[copy(n.values[:n.count], sorted):]
+	}
+}
+
+// String returns a string representation of the list, with brackets and comma separated.
+func (l *UnrolledList[V]) String() string {
+	var buf strings.Builder
+	buf.WriteRune('[')
+	for n := l.head; n != nil; n = n.next {
+		for _, value := range n.values[:n.count] {
+			if buf.Len() > 1 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(fmt.Sprint(value))
+		}
+	}
+	buf.WriteRune(']')
+	return buf.String()
+}
+
+// SubList returns a view of the list between the from index, inclusive,
+// and the to index, exclusive. The view is backed by the list, and
+// becomes invalid if the list is structurally modified other than
+// through the view.
+//
+// Panics if the range is out of bounds.
+//
+// Time Complexity: O(1)
+func (l *UnrolledList[V]) SubList(from, to int) structs.List[V] {
+	return listview.New(&listview.Backing[V]{
+		Equal:    l.eq,
+		Get:      l.Get,
+		Set:      l.Set,
+		AddAt:    l.AddAt,
+		RemoveAt: l.RemoveAt,
+		ModCount: func() int { return l.modCount },
+	}, l.size, from, to)
+}
+
+// Values returns a slice of the values in the list, starting at the front of the list.
+//
+// Time Complexity: O(n)
+//
+// Space Complexity: O(n)
+func (l *UnrolledList[V]) Values() []V {
+	values := make([]V, 0, l.size)
+	for n := l.head; n != nil; n = n.next {
+		values = append(values, n.values[:n.count]...)
+	}
+	return values
+}
+
+// newNode creates an empty node which can hold nodeSize values.
+func (l *UnrolledList[V]) newNode() *node[V] {
+	return &node[V]{values: make([]V, l.nodeSize)}
+}
+
+// locate returns the node containing the value at the index and the index
+// of the node's first value, walking from whichever end of the list is closer.
+//
+// The index must be within the bounds of the list.
+//
+// Time Complexity: O(n/B)
+func (l *UnrolledList[V]) locate(index int) (*node[V], int) {
+	if index < l.size/2 {
+		n, start := l.head, 0
+		for index >= start+n.count {
+			start += n.count
+			n = n.next
+		}
+		return n, start
+	}
+	n, start := l.tail, l.size-l.tail.count
+	for index < start {
+		n = n.prev
+		start -= n.count
+	}
+	return n, start
+}
+
+// insertAt inserts a value at the index, which may be the size of the list.
+func (l *UnrolledList[V]) insertAt(index int, value V) {
+	if index == l.size {
+		l.AddLast(value)
+		return
+	}
+	if index == 0 {
+		l.AddFirst(value)
+		return
+	}
+
+	n, start := l.locate(index)
+	offset := index - start
+	if offset == 0 && n.prev.count < l.nodeSize {
+		// append to the previous node rather than shifting this one
+		n, offset = n.prev, n.prev.count
+	} else if n.count == l.nodeSize {
+		// split the full node, moving its second half to a new node
+		half := l.nodeSize / 2
+		split := l.newNode()
+		split.count = copy(split.values, n.values[half:n.count])
+		n.clear(half, n.count)
+		n.count = half
+		l.linkAfter(n, split)
+		if offset > half {
+			n, offset = split, offset-half
+		}
+	}
+	n.insert(offset, value)
+	l.size++
+	l.modCount++
+}
+
+// removeFrom removes the value at the offset in the node, merging
+// the node with a neighbor if their values fit in a single node.
+func (l *UnrolledList[V]) removeFrom(n *node[V], offset int) V {
+	value := n.values[offset]
+	copy(n.values[offset:], n.values[offset+1:n.count])
+	n.count--
+	n.clear(n.count, n.count+1)
+	l.size--
+	l.modCount++
+
+	switch {
+	case n.count == 0:
+		l.unlink(n)
+	case n.next != nil && n.count+n.next.count <= l.nodeSize:
+		l.merge(n, n.next)
+	case n.prev != nil && n.prev.count+n.count <= l.nodeSize:
+		l.merge(n.prev, n)
+	}
+	return value
+}
+
+// merge moves all the values of a node into the node before it,
+// and unlinks the emptied node.
+func (l *UnrolledList[V]) merge(into, from *node[V]) {
+	copy(into.values[into.count:], from.values[:from.count])
+	into.count += from.count
+	l.unlink(from)
+}
+
+// linkAfter links a node after the target node, or as the
+// only node of the list if the target is nil.
+func (l *UnrolledList[V]) linkAfter(target, n *node[V]) {
+	if target == nil {
+		l.head = n
+		l.tail = n
+		return
+	}
+	n.prev = target
+	n.next = target.next
+	if target.next != nil {
+		target.next.prev = n
+	} else {
+		l.tail = n
+	}
+	target.next = n
+}
+
+// linkBefore links a node before the target node, or as the
+// only node of the list if the target is nil.
+func (l *UnrolledList[V]) linkBefore(target, n *node[V]) {
+	if target == nil {
+		l.head = n
+		l.tail = n
+		return
+	}
+	n.next = target
+	n.prev = target.prev
+	if target.prev != nil {
+		target.prev.next = n
+	} else {
+		l.head = n
+	}
+	target.prev = n
+}
+
+// unlink removes a node from the list.
+func (l *UnrolledList[V]) unlink(n *node[V]) {
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else {
+		l.head = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else {
+		l.tail = n.prev
+	}
+	n.prev = nil
+	n.next = nil
+}
+
+// checkEmpty panics if the list is empty.
+func (l *UnrolledList[V]) checkEmpty() {
+	if l.IsEmpty() {
+		panic(structs.PanicNoSuchElement)
+	}
+}
+
+// checkBounds checks whether an index is within the bounds of the list.
+//
+// if allowEnd is true, index == l.size is allowed. this is useful
+// for operations which may insert after the list's end.
+func (l *UnrolledList[V]) checkBounds(index int, allowEnd bool) {
+	if index < 0 || index > l.size || (!allowEnd && index == l.size) {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+}
+
+// insert inserts a value at the offset in the node, which must not be full.
+func (n *node[V]) insert(offset int, value V) {
+	copy(n.values[offset+1:], n.values[offset:n.count])
+	n.values[offset] = value
+	n.count++
+}
+
+// clear zeroes the node's slots between from, inclusive, and to,
+// exclusive, so the node doesn't hold references to removed values.
+func (n *node[V]) clear(from, to int) {
+	var null V
+	for i := from; i < to; i++ {
+		n.values[i] = null
+	}
+}
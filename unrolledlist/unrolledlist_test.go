@@ -0,0 +1,235 @@
+package unrolledlist
+
+import (
+	"github.com/zytekaron/structs"
+	"github.com/zytekaron/structs/internal/dequetest"
+	"github.com/zytekaron/structs/internal/listtest"
+	"github.com/zytekaron/structs/wrap"
+	"golang.org/x/exp/slices"
+	"math/rand"
+	"testing"
+)
+
+func TestUnrolledList(t *testing.T) {
+	listtest.Run(t, func() structs.List[int] {
+		return NewOrdered[int]()
+	})
+}
+
+func TestUnrolledList_SmallNodes(t *testing.T) {
+	for _, nodeSize := range []int{2, 3, 4} {
+		listtest.Run(t, func() structs.List[int] {
+			return NewOrderedNodeSize[int](nodeSize)
+		})
+	}
+}
+
+func TestUnrolledList_Deque(t *testing.T) {
+	dequetest.Run(t, func() structs.Deque[int] {
+		return NewOrderedNodeSize[int](3)
+	})
+}
+
+func TestSubList(t *testing.T) {
+	listtest.Run(t, func() structs.List[int] {
+		// an empty view in the middle of a list
+		return OfOrdered(-1, -2).SubList(1, 1)
+	})
+}
+
+func TestUnrolledList_Nodes(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, nodeSize := range []int{2, 3, 8} {
+		l := NewOrderedNodeSize[int](nodeSize)
+		var model []int
+		for i := 0; i < 2000; i++ {
+			switch op := r.Intn(6); {
+			case op < 3 || len(model) == 0:
+				index := r.Intn(len(model) + 1)
+				l.AddAt(index, i)
+				model = slices.Insert(model, index, i)
+			case op < 5:
+				index := r.Intn(len(model))
+				l.RemoveAt(index)
+				model = slices.Delete(model, index, index+1)
+			default:
+				model = retain(model, func(v int) bool { return v%3 != 0 })
+				l.RetainAll(wrap.OrderedValues(model...))
+			}
+			checkNodes(t, l)
+			if !slices.Equal(l.Values(), model) {
+				t.Fatalf("expected values %v but got %v", model, l.Values())
+			}
+		}
+	}
+}
+
+func TestUnrolledList_Split(t *testing.T) {
+	l := NewOrderedNodeSize[int](4)
+	l.AddAll(wrap.OrderedValues(0, 1, 2, 3)) // fills a single node exactly
+
+	l.AddAt(1, 9)
+	if nodes := countNodes(l); nodes != 2 {
+		t.Fatalf("expected a full node to split into 2 nodes but got %d", nodes)
+	}
+	listtest.ExpectValues(t, l.Values(), []int{0, 9, 1, 2, 3})
+	if l.head.count != 3 || l.tail.count != 2 {
+		t.Errorf("expected node counts 3, 2 but got %d, %d", l.head.count, l.tail.count)
+	}
+
+	l.RemoveAt(0)
+	l.RemoveAt(0)
+	if nodes := countNodes(l); nodes != 1 {
+		t.Errorf("expected nodes to merge into 1 node but got %d", nodes)
+	}
+	listtest.ExpectValues(t, l.Values(), []int{1, 2, 3})
+}
+
+func TestUnrolledList_AddEnds(t *testing.T) {
+	l := NewOrderedNodeSize[int](4)
+	for i := 0; i < 8; i++ {
+		l.AddLast(i)
+	}
+	for i := -1; i >= -8; i-- {
+		l.AddFirst(i)
+	}
+	// adding at either end fills new nodes rather than splitting
+	if nodes := countNodes(l); nodes != 4 {
+		t.Errorf("expected 4 full nodes but got %d", nodes)
+	}
+	checkNodes(t, l)
+}
+
+func TestUnrolledList_RetainAll(t *testing.T) {
+	l := NewOrderedNodeSize[int](4)
+	for i := 0; i < 20; i++ {
+		l.Add(i)
+	}
+
+	changed := l.RetainAll(wrap.OrderedValues(0, 2, 4, 6, 8, 10, 12, 14, 16, 18))
+	if !changed {
+		t.Error("expected RetainAll to report a change")
+	}
+	listtest.ExpectValues(t, l.Values(), []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18})
+	// the remaining values are packed into as few nodes as possible
+	if nodes := countNodes(l); nodes != 3 {
+		t.Errorf("expected 3 nodes but got %d", nodes)
+	}
+	if l.GetLast() != 18 || l.Get(9) != 18 {
+		t.Errorf("expected the last value to be 18 but got %d", l.GetLast())
+	}
+}
+
+func TestUnrolledList_ListIterator(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for _, descending := range []bool{false, true} {
+		l := NewOrderedNodeSize[int](3)
+		var model []int // model of the list in iteration order
+		var it structs.ListIterator[int]
+		if descending {
+			it = l.DescendingIterator().(structs.ListIterator[int])
+		} else {
+			it = l.ListIterator(0)
+		}
+		cursor, last := 0, -1
+		for i := 0; i < 2000; i++ {
+			switch op := r.Intn(6); {
+			case op == 0 || op == 1:
+				it.Add(i)
+				model = slices.Insert(model, cursor, i)
+				cursor++
+				last = -1
+			case op == 2 && cursor < len(model):
+				if v := it.Next(); v != model[cursor] {
+					t.Fatalf("expected Next to return %d but got %d", model[cursor], v)
+				}
+				last = cursor
+				cursor++
+			case op == 3 && cursor > 0:
+				cursor--
+				if v := it.Previous(); v != model[cursor] {
+					t.Fatalf("expected Previous to return %d but got %d", model[cursor], v)
+				}
+				last = cursor
+			case op == 4 && last >= 0:
+				it.Remove()
+				model = slices.Delete(model, last, last+1)
+				if last < cursor {
+					cursor--
+				}
+				last = -1
+			case op == 5 && last >= 0:
+				it.Set(-i)
+				model[last] = -i
+			}
+			if it.NextIndex() != cursor {
+				t.Fatalf("expected NextIndex %d but got %d", cursor, it.NextIndex())
+			}
+
+			values := l.Values()
+			if descending {
+				reverse(values)
+			}
+			if !slices.Equal(values, model) {
+				t.Fatalf("expected values %v but got %v", model, values)
+			}
+		}
+		checkNodes(t, l)
+	}
+}
+
+// checkNodes checks that no node is empty, that the nodes are
+// linked consistently, and that their counts add up to the list's size.
+func checkNodes(t *testing.T, l *UnrolledList[int]) {
+	t.Helper()
+	size := 0
+	for n := l.head; n != nil; n = n.next {
+		if n.count == 0 {
+			t.Fatal("expected no empty nodes")
+		}
+		if n.next != nil && n.next.prev != n {
+			t.Fatal("expected node links to be consistent")
+		}
+		size += n.count
+	}
+	if size != l.size {
+		t.Fatalf("expected node counts to add up to %d but got %d", l.size, size)
+	}
+}
+
+func countNodes[V any](l *UnrolledList[V]) int {
+	count := 0
+	for n := l.head; n != nil; n = n.next {
+		count++
+	}
+	return count
+}
+
+func retain(values []int, keep func(int) bool) []int {
+	var kept []int
+	for _, value := range values {
+		if keep(value) {
+			kept = append(kept, value)
+		}
+	}
+	return kept
+}
+
+func reverse(values []int) {
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+}
+
+func TestUnrolledList_Each(t *testing.T) {
+	l := NewOrderedNodeSize[int](2)
+	l.AddAll(wrap.OrderedValues(1, 2, 3, 4, 5))
+
+	var values []int
+	l.Each(func(value int) { values = append(values, value) })
+	listtest.ExpectValues(t, values, []int{1, 2, 3, 4, 5})
+
+	values = nil
+	l.EachReverse(func(value int) { values = append(values, value) })
+	listtest.ExpectValues(t, values, []int{5, 4, 3, 2, 1})
+}
@@ -0,0 +1,69 @@
+package wrap
+
+import (
+	"context"
+	"github.com/zytekaron/structs"
+)
+
+// ChanWrapIterator is an iterator over the values received
+// from a channel, which ends when the channel is closed.
+type ChanWrapIterator[V any] struct {
+	ch      <-chan V
+	next    V
+	hasNext bool
+	fetched bool
+}
+
+// ChanIterator creates an iterator over the values received from a channel.
+// HasNext blocks until a value is received or the channel is closed.
+func ChanIterator[V any](ch <-chan V) *ChanWrapIterator[V] {
+	return &ChanWrapIterator[V]{
+		ch: ch,
+	}
+}
+
+func (i *ChanWrapIterator[V]) HasNext() bool {
+	if !i.fetched {
+		i.next, i.hasNext = <-i.ch
+		i.fetched = true
+	}
+	return i.hasNext
+}
+
+func (i *ChanWrapIterator[V]) Next() V {
+	if !i.HasNext() {
+		panic(structs.PanicNoSuchElement)
+	}
+	i.fetched = false
+	return i.next
+}
+
+// Remove panics when called.
+func (i *ChanWrapIterator[V]) Remove() {
+	panic(structs.PanicUnsupportedOperation)
+}
+
+// AddChan adds all the values received from a channel to the collection
+// until the channel is closed, and returns whether any values were added.
+func AddChan[V any](collection structs.Collection[V], ch <-chan V) bool {
+	return collection.AddIterator(ChanIterator(ch))
+}
+
+// IteratorChan returns a channel which receives the values of the
+// iterator, and is closed when the iterator is exhausted or the
+// context is done. The iterator is consumed by another goroutine,
+// so it must not be used by the caller after this function is called.
+func IteratorChan[V any](ctx context.Context, iter structs.Iterator[V]) <-chan V {
+	ch := make(chan V)
+	go func() {
+		defer close(ch)
+		for iter.HasNext() {
+			select {
+			case ch <- iter.Next():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,58 @@
+package wrap
+
+import (
+	"context"
+	"golang.org/x/exp/slices"
+	"testing"
+)
+
+func TestChanIterator(t *testing.T) {
+	ch := make(chan int)
+	go func() {
+		for i := 0; i < 5; i++ {
+			ch <- i
+		}
+		close(ch)
+	}()
+
+	var got []int
+	it := ChanIterator(ch)
+	for it.HasNext() {
+		got = append(got, it.Next())
+	}
+	if expect := []int{0, 1, 2, 3, 4}; !slices.Equal(got, expect) {
+		t.Errorf("expected values %v but got %v", expect, got)
+	}
+	if it.HasNext() {
+		t.Error("expected iterator over a closed channel to be exhausted")
+	}
+}
+
+func TestIteratorChan(t *testing.T) {
+	var got []int
+	for value := range IteratorChan[int](context.Background(), ValueIterator(1, 2, 3)) {
+		got = append(got, value)
+	}
+	if expect := []int{1, 2, 3}; !slices.Equal(got, expect) {
+		t.Errorf("expected values %v but got %v", expect, got)
+	}
+}
+
+func TestIteratorChan_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := IteratorChan[int](ctx, ValueIterator(1, 2, 3))
+
+	if value := <-ch; value != 1 {
+		t.Errorf("expected 1 but got %d", value)
+	}
+	cancel()
+
+	// at most one more value may already be pending before the channel closes
+	count := 0
+	for range ch {
+		count++
+	}
+	if count > 1 {
+		t.Errorf("expected the channel to close after cancellation, got %d more values", count)
+	}
+}
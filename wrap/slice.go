@@ -181,6 +181,15 @@ func (s *SliceWrap[V]) Sort(structs.LessFunc[V]) {
 	panic(structs.PanicUnsupportedOperation)
 }
 
+// SubList returns a read-only SliceWrap of the wrapped slice between
+// the from index, inclusive, and the to index, exclusive.
+func (s *SliceWrap[V]) SubList(from, to int) structs.List[V] {
+	if from < 0 || to > s.Size() || from > to {
+		panic(structs.PanicIndexOutOfBounds)
+	}
+	return Slice(s.eq, s.data[from:to:to])
+}
+
 // Values returns a copy of the wrapped slice.
 func (s *SliceWrap[V]) Values() []V {
 	out := make([]V, len(s.data))
@@ -17,3 +17,17 @@ func TestSlice(t *testing.T) {
 	// todo: no meaningful test right now,
 	//  so just testing for panics :shrug:
 }
+
+func TestSliceWrap_SubList(t *testing.T) {
+	sub := OrderedValues(0, 1, 2, 3, 4).SubList(1, 4)
+	if sub.Size() != 3 || sub.Get(0) != 1 || sub.Get(2) != 3 {
+		t.Errorf("expected values [1 2 3] but got %v", sub.Values())
+	}
+
+	defer func() {
+		if r := recover(); r != structs.PanicUnsupportedOperation {
+			t.Errorf("expected panic %q but got %v", structs.PanicUnsupportedOperation, r)
+		}
+	}()
+	sub.Add(5)
+}